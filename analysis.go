@@ -1,6 +1,8 @@
 package lookout
 
 import (
+	"time"
+
 	"google.golang.org/grpc"
 	log "gopkg.in/src-d/go-log.v1"
 	"gopkg.in/src-d/lookout-sdk.v0/pb"
@@ -9,6 +11,21 @@ import (
 type EventResponse = pb.EventResponse
 type Comment = pb.Comment
 
+// Fix is the replacement of a range of lines suggested by a Comment
+type Fix = pb.Fix
+
+// Severity is the importance of a Comment
+type Severity = pb.Severity
+
+const (
+	// InfoSeverity is used for suggestions and informative comments
+	InfoSeverity = pb.Severity_INFO
+	// WarningSeverity is used for issues that should be fixed
+	WarningSeverity = pb.Severity_WARNING
+	// ErrorSeverity is used for issues that must be fixed
+	ErrorSeverity = pb.Severity_ERROR
+)
+
 type AnalyzerClient = pb.AnalyzerClient
 type AnalyzerServer = pb.AnalyzerServer
 
@@ -20,11 +37,24 @@ func NewAnalyzerClient(conn *grpc.ClientConn) AnalyzerClient {
 	return pb.NewAnalyzerClient(conn)
 }
 
-// AnalyzerConfig is a configuration of analyzer
+type AnalyzerStreamClient = pb.AnalyzerStreamClient
+type AnalyzerStreamServer = pb.AnalyzerStreamServer
+
+func RegisterAnalyzerStreamServer(s *grpc.Server, srv AnalyzerStreamServer) {
+	pb.RegisterAnalyzerStreamServer(s, srv)
+}
+
+func NewAnalyzerStreamClient(conn *grpc.ClientConn) AnalyzerStreamClient {
+	return pb.NewAnalyzerStreamClient(conn)
+}
+
+// AnalyzerConfig is a configuration of analyzer.
+// Addr, Feedback, DependsOn, MaxComments, Cache and Autofix can be defined
+// only in the global config, they are ignored in the repository-scoped
+// configuration.
 type AnalyzerConfig struct {
 	Name string
 	// Addr is gRPC URL.
-	// can be defined only in global config, repository-scoped configuration is ignored
 	Addr string
 	// Disabled repository-scoped configuration can accept only true value, false value is ignored
 	Disabled bool
@@ -32,12 +62,52 @@ type AnalyzerConfig struct {
 	Feedback string
 	// Settings any configuration for an analyzer
 	Settings map[string]interface{}
+	// MinConfidence is the minimum confidence of the comments to be posted,
+	// comments with a lower confidence are filtered out
+	MinConfidence uint32 `yaml:"min_confidence"`
+	// Include are the path patterns, in .gitignore format, of the files
+	// analyzed by the analyzer. Empty means all the files.
+	Include []string `yaml:"include"`
+	// Exclude are the path patterns, in .gitignore format, of the files
+	// ignored by the analyzer
+	Exclude []string `yaml:"exclude"`
+	// TimeoutReview is the timeout for the analyzer to reply a
+	// NotifyReviewEvent, zero means the server default
+	TimeoutReview time.Duration `yaml:"timeout_review"`
+	// TimeoutPush is the timeout for the analyzer to reply a NotifyPushEvent,
+	// zero means the server default
+	TimeoutPush time.Duration `yaml:"timeout_push"`
+	// DependsOn are the names of the analyzers whose comments are sent to
+	// this one, it is requested once they finish.
+	DependsOn []string `yaml:"depends_on"`
+	// MaxComments is the maximum number of comments of the analyzer to be
+	// posted, the rest are dropped. Zero means no limit.
+	MaxComments int `yaml:"max_comments"`
+	// Cache enables the cache of the analyzer responses, nil means enabled.
+	// It should be disabled for non-deterministic analyzers.
+	Cache *bool
+	// Autofix is the way the fixes of the comments are applied on pull
+	// requests: pull_request or push. Empty means they are only suggested.
+	Autofix string `yaml:"autofix"`
+	// AutofixPush opts a repository in to the push autofix mode, the fixes
+	// are only pushed to the head branch if it is set in the organization or
+	// repository configuration. It is ignored in the global config.
+	AutofixPush bool `yaml:"autofix_push"`
+	// OutOfDiff is the way the comments on lines outside the diff of a pull
+	// request are posted: summary, file or drop. Empty means summary.
+	OutOfDiff string `yaml:"out_of_diff"`
 }
 
 // Analyzer is a struct of analyzer client and config
 type Analyzer struct {
 	Client AnalyzerClient
+	// Stream is the client of the streaming variant of the analyzer, it can
+	// be nil. If the analyzer doesn't implement it, Client is used.
+	Stream AnalyzerStreamClient
 	Config AnalyzerConfig
+	// EventTypes are the types of the events sent to the analyzer, empty
+	// means all of them
+	EventTypes []EventType
 }
 
 // AnalyzerComments contains a group of comments and the config for the
@@ -45,6 +115,9 @@ type Analyzer struct {
 type AnalyzerComments struct {
 	Config   AnalyzerConfig
 	Comments []*Comment
+	// Partial is true if the analysis did not finish, the comments are the
+	// ones produced before it timed out
+	Partial bool
 }
 
 // AnalyzerCommentsGroups list of AnalyzerComments
@@ -74,6 +147,7 @@ func (g AnalyzerCommentsGroups) Filter(fn CommentsFilterFn) ([]AnalyzerComments,
 			result = append(result, AnalyzerComments{
 				Config:   group.Config,
 				Comments: newComments,
+				Partial:  group.Partial,
 			})
 		}
 	}
@@ -124,6 +198,7 @@ func (g AnalyzerCommentsGroups) Dedup() AnalyzerCommentsGroups {
 			result = append(result, AnalyzerComments{
 				Config:   group.Config,
 				Comments: newComments,
+				Partial:  group.Partial,
 			})
 		}
 
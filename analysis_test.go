@@ -106,7 +106,8 @@ func TestAnalyzerCommentsGroupsDedup(t *testing.T) {
 			Comments: []*Comment{
 				{File: "f1.go", Line: 1, Text: "some-text", Confidence: 1},
 			},
-			Config: AnalyzerConfig{Name: "analyzer3"},
+			Config:  AnalyzerConfig{Name: "analyzer3"},
+			Partial: true,
 		},
 	}
 
@@ -116,6 +117,7 @@ func TestAnalyzerCommentsGroupsDedup(t *testing.T) {
 	assert.Len(result[0].Comments, 3)
 	assert.Len(result[1].Comments, 3)
 	assert.Len(result[2].Comments, 1)
+	assert.True(result[2].Partial)
 
 	// for testing use confidence as id, the confidence used in the fixtures
 	// has been chosen so that the sum of every possible combination has a
@@ -31,6 +31,8 @@ type ServeCommand struct {
 	RequestUAST      bool   `long:"uast" env:"LOOKOUT_REQUEST_UAST" description:"analyzer will request UAST from the data server"`
 	RequestFilesPush bool   `long:"files" env:"LOOKOUT_REQUEST_FILES" description:"on push events the analyzer will request files from HEAD, and return comments"`
 	ProbesAddr       string `long:"probes-addr" default:"0.0.0.0:8091" env:"LOOKOUT_ANALYZER_PROBES_ADDRESS" description:"TCP address to bind the health probe endpoints"`
+	Register         bool   `long:"register" env:"LOOKOUT_REGISTER" description:"analyzer will register itself in lookoutd through the data server, instead of being listed in its configuration"`
+	AdvertiseAddr    string `long:"advertise-addr" env:"LOOKOUT_ADVERTISE_ADDRESS" description:"gRPC URL lookoutd uses to connect to the analyzer when it registers, by default the --analyzer one"`
 }
 
 func (c *ServeCommand) Execute(args []string) error {
@@ -66,6 +68,20 @@ func (c *ServeCommand) Execute(args []string) error {
 		return err
 	}
 
+	if c.Register {
+		addr := c.AdvertiseAddr
+		if addr == "" {
+			addr = c.Analyzer
+		}
+
+		go lookout.KeepRegistered(context.Background(), lookout.NewRegistryClient(conn),
+			&lookout.AnalyzerRegistration{
+				Name:    name,
+				Addr:    addr,
+				Version: version,
+			})
+	}
+
 	log.Infof("server has started on '%s'", c.Analyzer)
 	return server.Serve(lis)
 }
@@ -191,10 +191,9 @@ func (c *EventCommand) analyzer() (lookout.Analyzer, error) {
 		return lookout.Analyzer{}, fmt.Errorf("Can't connect to analyzer '%s': %s", grpcAddr, err)
 	}
 
-	client := lookout.NewAnalyzerClient(conn)
-
 	return lookout.Analyzer{
-		Client: client,
+		Client: lookout.NewAnalyzerClient(conn),
+		Stream: lookout.NewAnalyzerStreamClient(conn),
 		Config: lookout.AnalyzerConfig{
 			Name: "test-analyzer",
 			Addr: c.Args.Analyzer,
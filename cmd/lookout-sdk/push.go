@@ -61,8 +61,9 @@ func (c *PushCommand) Execute(args []string) error {
 	}
 
 	srv := server.NewServer(server.Options{
-		Poster:     json.NewPoster(os.Stdout),
-		FileGetter: dataHandler.FileGetter,
+		Poster:       json.NewPoster(os.Stdout),
+		FileGetter:   dataHandler.FileGetter,
+		ChangeGetter: dataHandler.ChangeGetter,
 		Analyzers: map[string]lookout.Analyzer{
 			analyzer.Config.Name: analyzer,
 		},
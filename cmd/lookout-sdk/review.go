@@ -57,8 +57,9 @@ func (c *ReviewCommand) Execute(args []string) error {
 	}
 
 	srv := server.NewServer(server.Options{
-		Poster:     json.NewPoster(os.Stdout),
-		FileGetter: dataHandler.FileGetter,
+		Poster:       json.NewPoster(os.Stdout),
+		FileGetter:   dataHandler.FileGetter,
+		ChangeGetter: dataHandler.ChangeGetter,
 		Analyzers: map[string]lookout.Analyzer{
 			analyzer.Config.Name: analyzer,
 		},
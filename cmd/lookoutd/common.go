@@ -3,11 +3,14 @@ package main
 import (
 	"context"
 	"database/sql"
+	gojson "encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"os"
 	"runtime"
+	"sync"
 	"time"
 
 	"github.com/gregjones/httpcache/diskcache"
@@ -79,7 +82,16 @@ type queueConsumerCommand struct {
 	Library    string `long:"library" default:"/tmp/lookout" env:"LOOKOUT_LIBRARY" description:"path to the lookout library"`
 	Workers    int    `long:"workers" env:"LOOKOUT_WORKERS" default:"1" description:"number of concurrent workers processing events, 0 means the same number as processors"`
 
-	analyzers map[string]lookout.AnalyzerClient
+	ConfigWatchInterval time.Duration `long:"config-watch-interval" default:"10s" env:"LOOKOUT_CONFIG_WATCH_INTERVAL" description:"how often the configuration file is checked for changes to reload it, 0 disables it. It is also reloaded on SIGHUP"`
+
+	// reloadMu guards conf and analyzerConns once the configuration is
+	// watched, they are replaced by reload
+	reloadMu sync.Mutex
+	// analyzerConns are the connections to the running analyzers, by name
+	analyzerConns map[string]*analyzerConn
+	// committer commits the fixes of the analyzers with autofix, in the
+	// library of initDataHandler
+	committer *git.Committer
 }
 
 var defaultInstallationsSyncInterval = 5 * time.Minute
@@ -93,8 +105,14 @@ type Config struct {
 	Providers     struct {
 		Github github.ProviderConfig
 	}
-	Repositories []RepoConfig
-	Timeout      TimeoutConfig
+	Repositories   []RepoConfig
+	Timeout        TimeoutConfig
+	Retry          server.RetryPolicy
+	CircuitBreaker server.BreakerConfig `yaml:"circuit_breaker"`
+	ResponseCache  ResponseCacheConfig  `yaml:"response_cache"`
+	Registry       RegistryConfig
+	Schedules      []github.ScheduleConfig
+	Feedback       FeedbackConfig
 }
 
 // RepoConfig holds configuration for repository, support only github provider
@@ -112,7 +130,43 @@ type TimeoutConfig struct {
 	BblfshParse    time.Duration `yaml:"bblfsh_parse"`
 }
 
+// ResponseCacheConfig holds configuration for the cache of analyzer responses
+type ResponseCacheConfig struct {
+	TTL time.Duration `yaml:"ttl"`
+}
+
+// RegistryConfig holds configuration for the registration of analyzers
+type RegistryConfig struct {
+	// TTL is the time a registration is kept without a heartbeat, 0 disables
+	// the registration
+	TTL time.Duration `yaml:"ttl"`
+	// Analyzers are the names of the analyzers allowed to register
+	Analyzers []string `yaml:"analyzers"`
+}
+
+// FeedbackConfig holds configuration for the collection of the feedback of
+// the users on the posted comments
+type FeedbackConfig struct {
+	// Interval is the time between collections, 0 disables the collection
+	Interval time.Duration `yaml:"interval"`
+	// MaxAge is the age of the oldest comments whose feedback is collected
+	MaxAge time.Duration `yaml:"max_age"`
+}
+
 func (c *lookoutdCommand) initConfig() (Config, error) {
+	conf, err := c.readConfig()
+	if err != nil {
+		return conf, err
+	}
+
+	c.logConfig(conf)
+
+	return conf, nil
+}
+
+// readConfig reads the configuration file, setting the defaults for the
+// missing values
+func (c *lookoutdCommand) readConfig() (Config, error) {
 	var conf Config
 	configData, err := ioutil.ReadFile(c.ConfigFile)
 	if err != nil {
@@ -136,11 +190,26 @@ func (c *lookoutdCommand) initConfig() (Config, error) {
 		BblfshParse:    2 * time.Minute,
 	}
 
+	conf.Retry = server.DefaultRetryPolicy
+	conf.CircuitBreaker = server.DefaultBreakerConfig
+	conf.ResponseCache = ResponseCacheConfig{TTL: 24 * time.Hour}
+	conf.Feedback = FeedbackConfig{MaxAge: 30 * 24 * time.Hour}
+
 	if err := yaml.Unmarshal([]byte(configData), &conf); err != nil {
 		return conf, fmt.Errorf("Can't parse configuration file: %s", err)
 	}
 
-	c.logConfig(conf)
+	if err := server.ValidateDependencies(conf.Analyzers); err != nil {
+		return conf, fmt.Errorf("Invalid analyzers configuration: %s", err)
+	}
+
+	if err := server.ValidateAutofix(conf.Analyzers); err != nil {
+		return conf, fmt.Errorf("Invalid analyzers configuration: %s", err)
+	}
+
+	if err := server.ValidateOutOfDiff(conf.Analyzers); err != nil {
+		return conf, fmt.Errorf("Invalid analyzers configuration: %s", err)
+	}
 
 	return conf, nil
 }
@@ -295,6 +364,20 @@ func (c *lookoutdCommand) initWatcher(conf Config) (lookout.Watcher, error) {
 	}
 }
 
+// initScheduler returns the watcher of the scheduled analysis, or nil if
+// there are no schedules
+func (c *lookoutdCommand) initScheduler(conf Config) (lookout.Watcher, error) {
+	if len(conf.Schedules) == 0 {
+		return nil, nil
+	}
+
+	if c.Provider != github.Provider {
+		return nil, fmt.Errorf("schedules are not supported by the provider %s", c.Provider)
+	}
+
+	return github.NewScheduler(c.pool, conf.Schedules)
+}
+
 func (c *lookoutdCommand) startHealthProbes() error {
 	livenessPath := "/health/liveness"
 	http.HandleFunc(livenessPath, func(w http.ResponseWriter, r *http.Request) {
@@ -321,6 +404,23 @@ func (c *lookoutdCommand) startHealthProbes() error {
 	return http.ListenAndServe(c.ProbesAddr, nil)
 }
 
+// startAnalyzersProbe adds an endpoint to the health probes HTTP server
+// with the state of the circuit breaker of each analyzer
+func (c *queueConsumerCommand) startAnalyzersProbe(srv *server.Server) {
+	analyzersPath := "/health/analyzers"
+	http.HandleFunc(analyzersPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := gojson.NewEncoder(w).Encode(srv.AnalyzersHealth()); err != nil {
+			log.Errorf(err, "can't encode analyzers health")
+		}
+	})
+
+	log.With(log.Fields{
+		"addr": c.ProbesAddr,
+		"path": analyzersPath,
+	}).Infof("listening to analyzers health HTTP requests")
+}
+
 func (c *queueConsumerCommand) initPoster(conf Config) (lookout.Poster, error) {
 	if c.DryRun {
 		return &server.LogPoster{log.DefaultLogger}, nil
@@ -336,7 +436,37 @@ func (c *queueConsumerCommand) initPoster(conf Config) (lookout.Poster, error) {
 	}
 }
 
-func (c *queueConsumerCommand) startAnalyzer(conf lookout.AnalyzerConfig) (lookout.AnalyzerClient, error) {
+// initFixer returns the Fixer of the analyzers with autofix, or nil if the
+// fixes can't be pushed
+func (c *queueConsumerCommand) initFixer(conf Config) (lookout.Fixer, error) {
+	if c.DryRun || c.Provider != github.Provider {
+		return nil, nil
+	}
+
+	if c.committer == nil {
+		return nil, fmt.Errorf("committer must be initialized with initDataHandler")
+	}
+
+	return github.NewAutofixer(c.pool, c.committer), nil
+}
+
+// analyzerConn is the connection to an analyzer
+type analyzerConn struct {
+	conf   lookout.AnalyzerConfig
+	conn   *grpc.ClientConn
+	client lookout.AnalyzerClient
+	stream lookout.AnalyzerStreamClient
+	// cancel stops logging the connection status changes
+	cancel context.CancelFunc
+}
+
+// Close closes the connection to the analyzer
+func (a *analyzerConn) Close() error {
+	a.cancel()
+	return a.conn.Close()
+}
+
+func (c *queueConsumerCommand) startAnalyzer(conf lookout.AnalyzerConfig) (*analyzerConn, error) {
 	if conf.Name == "" {
 		return nil, fmt.Errorf("missing 'name' in analyzer config")
 	}
@@ -349,9 +479,10 @@ func (c *queueConsumerCommand) startAnalyzer(conf lookout.AnalyzerConfig) (looko
 		return nil, fmt.Errorf("invalid address '%s' in config for analyzer %s: %s", conf.Addr, conf.Name, err)
 	}
 
-	ctx := context.Background()
+	ctx, cancel := context.WithCancel(context.Background())
 	conn, err := grpchelper.DialContext(ctx, addr)
 	if err != nil {
+		cancel()
 		return nil, fmt.Errorf("failed to create a client connection to address '%s' in config for analyzer %s: %s", conf.Addr, conf.Name, err)
 	}
 
@@ -360,7 +491,13 @@ func (c *queueConsumerCommand) startAnalyzer(conf lookout.AnalyzerConfig) (looko
 		"addr":     conf.Addr,
 	}), conn)
 
-	return lookout.NewAnalyzerClient(conn), nil
+	return &analyzerConn{
+		conf:   conf,
+		conn:   conn,
+		client: lookout.NewAnalyzerClient(conn),
+		stream: lookout.NewAnalyzerStreamClient(conn),
+		cancel: cancel,
+	}, nil
 }
 
 func (c *queueConsumerCommand) initDataHandler(conf Config) (*lookout.DataServerHandler, error) {
@@ -387,6 +524,7 @@ func (c *queueConsumerCommand) initDataHandler(conf Config) (*lookout.DataServer
 	lib := git.NewLibrary(osfs.New(c.Library))
 	sync := git.NewSyncer(lib, authProvider, conf.Timeout.GitFetch)
 	loader := git.NewLibraryCommitLoader(lib, sync)
+	c.committer = git.NewCommitter(lib, sync, authProvider)
 
 	gitService := git.NewService(loader)
 	enryService := enry.NewService(gitService, gitService)
@@ -401,7 +539,10 @@ func (c *queueConsumerCommand) initDataHandler(conf Config) (*lookout.DataServer
 	return srv, nil
 }
 
-func (c *queueConsumerCommand) initDataServer(srv *lookout.DataServerHandler) (startFunc, stopFunc) {
+func (c *queueConsumerCommand) initDataServer(
+	srv *lookout.DataServerHandler,
+	registry *server.Registry,
+) (startFunc, stopFunc) {
 	var grpcSrv *grpc.Server
 
 	start := func() error {
@@ -412,6 +553,10 @@ func (c *queueConsumerCommand) initDataServer(srv *lookout.DataServerHandler) (s
 		}
 
 		lookout.RegisterDataServer(grpcSrv, srv)
+		if registry != nil {
+			lookout.RegisterRegistryServer(grpcSrv, registry)
+		}
+
 		lis, err := pb.Listen(c.DataServer)
 		if err != nil {
 			return err
@@ -431,39 +576,103 @@ func (c *queueConsumerCommand) initDataServer(srv *lookout.DataServerHandler) (s
 	return start, stop
 }
 
+// initRegistry returns the registry of analyzers for the server, it is nil if
+// the registration is disabled. The registry runs until the context is
+// canceled.
+func (c *queueConsumerCommand) initRegistry(ctx context.Context, srv *server.Server) *server.Registry {
+	conf := c.config()
+	if conf.Registry.TTL <= 0 {
+		return nil
+	}
+
+	registry := server.NewRegistry(srv, server.RegistryOptions{
+		TTL:       conf.Registry.TTL,
+		Analyzers: conf.Registry.Analyzers,
+		Dial: func(conf lookout.AnalyzerConfig) (lookout.Analyzer, io.Closer, error) {
+			a, err := c.startAnalyzer(conf)
+			if err != nil {
+				return lookout.Analyzer{}, nil, err
+			}
+
+			return lookout.Analyzer{Client: a.client, Stream: a.stream}, a, nil
+		},
+	})
+
+	go registry.Run(ctx)
+
+	return registry
+}
+
+// initFeedbackCollector returns the collector of the feedback on the posted
+// comments, or nil if it is disabled
+func (c *queueConsumerCommand) initFeedbackCollector(conf Config, db *sql.DB) (*github.FeedbackCollector, error) {
+	if conf.Feedback.Interval == 0 {
+		return nil, nil
+	}
+
+	if c.Provider != github.Provider {
+		return nil, fmt.Errorf("feedback is not supported by the provider %s", c.Provider)
+	}
+
+	op := store.NewDBFeedbackOperator(
+		models.NewCommentFeedbackStore(db),
+		models.NewCommentStore(db),
+		models.NewReviewEventStore(db),
+	)
+
+	return github.NewFeedbackCollector(c.pool, op, conf.Feedback.Interval, conf.Feedback.MaxAge), nil
+}
+
 func (c *queueConsumerCommand) initDBOperators(db *sql.DB) (
-	*store.DBEventOperator, *store.DBCommentOperator, *store.DBOrganizationOperator) {
+	*store.DBEventOperator, *store.DBCommentOperator, *store.DBOrganizationOperator,
+	*store.DBAnalyzerRunOperator, *store.DBResponseCacheOperator) {
 	reviewStore := models.NewReviewEventStore(db)
 	reviewTargetStore := models.NewReviewTargetStore(db)
+	pushStore := models.NewPushEventStore(db)
 	eventOp := store.NewDBEventOperator(
 		reviewStore,
 		reviewTargetStore,
-		models.NewPushEventStore(db),
+		pushStore,
 	)
 	commentsOp := store.NewDBCommentOperator(
 		models.NewCommentStore(db),
 		reviewStore,
 		reviewTargetStore,
+		pushStore,
+		models.NewFilteredCommentStore(db),
 	)
 	organizationsOp := store.NewDBOrganizationOperator(
 		models.NewOrganizationStore(db),
 	)
 
-	return eventOp, commentsOp, organizationsOp
+	analyzerRunsOp := store.NewDBAnalyzerRunOperator(
+		models.NewAnalyzerRunStore(db),
+	)
+
+	responseCacheOp := store.NewDBResponseCacheOperator(
+		models.NewCachedResponseStore(db),
+	)
+
+	return eventOp, commentsOp, organizationsOp, analyzerRunsOp, responseCacheOp
 }
 
 func (c *queueConsumerCommand) initAnalyzers(conf Config) (map[string]lookout.Analyzer, error) {
+	c.analyzerConns = make(map[string]*analyzerConn)
+
 	analyzers := make(map[string]lookout.Analyzer)
 	for _, aConf := range conf.Analyzers {
 		if aConf.Disabled {
 			continue
 		}
-		client, err := c.startAnalyzer(aConf)
+		a, err := c.startAnalyzer(aConf)
 		if err != nil {
 			return nil, err
 		}
+
+		c.analyzerConns[aConf.Name] = a
 		analyzers[aConf.Name] = lookout.Analyzer{
-			Client: client,
+			Client: a.client,
+			Stream: a.stream,
 			Config: aConf,
 		}
 	}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"reflect"
+	"syscall"
+	"time"
+
+	"github.com/src-d/lookout"
+	"github.com/src-d/lookout/provider/github"
+	"github.com/src-d/lookout/server"
+	"github.com/src-d/lookout/util/ctxlog"
+
+	log "gopkg.in/src-d/go-log.v1"
+)
+
+// reloadablePoster is a lookout.Poster that can replace its configuration
+// while running
+type reloadablePoster interface {
+	Reload(conf github.ProviderConfig) error
+}
+
+// watchConfig reloads the configuration file when lookoutd receives a SIGHUP,
+// or when the file changes, until the context is canceled
+func (c *queueConsumerCommand) watchConfig(ctx context.Context, srv *server.Server, poster lookout.Poster) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var tick <-chan time.Time
+	if c.ConfigWatchInterval > 0 {
+		ticker := time.NewTicker(c.ConfigWatchInterval)
+		defer ticker.Stop()
+
+		tick = ticker.C
+	}
+
+	logger := ctxlog.Get(ctx).With(log.Fields{"config-file": c.ConfigFile})
+	last, err := os.Stat(c.ConfigFile)
+	if err != nil {
+		logger.Errorf(err, "can't stat the configuration file")
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			logger.Infof("SIGHUP received, reloading the configuration")
+		case <-tick:
+			fi, err := os.Stat(c.ConfigFile)
+			if err != nil {
+				logger.Errorf(err, "can't stat the configuration file")
+				continue
+			}
+
+			if last != nil && fi.ModTime().Equal(last.ModTime()) && fi.Size() == last.Size() {
+				continue
+			}
+
+			last = fi
+			logger.Infof("the configuration file changed, reloading it")
+		}
+
+		if err := c.reload(srv, poster); err != nil {
+			logger.Errorf(err, "can't reload the configuration, the previous one is kept")
+		}
+	}
+}
+
+// config returns the current configuration, it can be replaced by reload
+func (c *queueConsumerCommand) config() Config {
+	c.reloadMu.Lock()
+	defer c.reloadMu.Unlock()
+
+	return c.conf
+}
+
+// reload reads the configuration file again, and applies the analyzers, the
+// analyzer timeouts and the GitHub poster configuration. The removed
+// analyzers are closed once the events using them are processed.
+func (c *queueConsumerCommand) reload(srv *server.Server, poster lookout.Poster) error {
+	c.reloadMu.Lock()
+	defer c.reloadMu.Unlock()
+
+	conf, err := c.readConfig()
+	if err != nil {
+		return err
+	}
+
+	analyzers, started, removed, err := c.reloadAnalyzers(conf)
+	if err != nil {
+		return err
+	}
+
+	if p, ok := poster.(reloadablePoster); ok {
+		if err := p.Reload(conf.Providers.Github); err != nil {
+			closeAnalyzers(started)
+			return err
+		}
+	}
+
+	if restartRequired(c.conf, conf) {
+		log.Warningf("the configuration changes other than the analyzers, " +
+			"analyzer timeouts and GitHub comment footer, status target url and push results " +
+			"are not applied until lookoutd is restarted")
+	}
+
+	for _, a := range started {
+		c.analyzerConns[a.conf.Name] = a
+	}
+
+	for _, a := range removed {
+		if c.analyzerConns[a.conf.Name] == a {
+			delete(c.analyzerConns, a.conf.Name)
+		}
+	}
+
+	drained := srv.Reload(server.ReloadOptions{
+		Analyzers:     analyzers,
+		ReviewTimeout: conf.Timeout.AnalyzerReview,
+		PushTimeout:   conf.Timeout.AnalyzerPush,
+	})
+
+	go func() {
+		<-drained
+		closeAnalyzers(removed)
+	}()
+
+	c.conf = conf
+	return nil
+}
+
+// reloadAnalyzers returns the analyzers of the new configuration. The
+// connections to the analyzers with the same address are reused, started are
+// the new connections and removed the ones not used anymore. If an analyzer
+// can't be started, the new connections are closed and an error is returned.
+// It must be called holding reloadMu.
+func (c *queueConsumerCommand) reloadAnalyzers(conf Config) (
+	analyzers map[string]lookout.Analyzer,
+	started, removed []*analyzerConn,
+	err error,
+) {
+	analyzers = make(map[string]lookout.Analyzer)
+	for _, aConf := range conf.Analyzers {
+		if aConf.Disabled {
+			continue
+		}
+
+		a, ok := c.analyzerConns[aConf.Name]
+		if !ok || a.conf.Addr != aConf.Addr {
+			a, err = c.startAnalyzer(aConf)
+			if err != nil {
+				closeAnalyzers(started)
+				return nil, nil, nil, err
+			}
+
+			log.With(log.Fields{
+				"analyzer": aConf.Name,
+				"addr":     aConf.Addr,
+			}).Infof("analyzer added")
+			started = append(started, a)
+		}
+
+		analyzers[aConf.Name] = lookout.Analyzer{
+			Client: a.client,
+			Stream: a.stream,
+			Config: aConf,
+		}
+	}
+
+	for name, a := range c.analyzerConns {
+		if current, ok := analyzers[name]; ok && current.Client == a.client {
+			continue
+		}
+
+		log.With(log.Fields{
+			"analyzer": name,
+			"addr":     a.conf.Addr,
+		}).Infof("analyzer removed, it will be closed once its events are processed")
+		removed = append(removed, a)
+	}
+
+	return analyzers, started, removed, nil
+}
+
+func closeAnalyzers(conns []*analyzerConn) {
+	for _, a := range conns {
+		if err := a.Close(); err != nil {
+			log.With(log.Fields{"analyzer": a.conf.Name}).
+				Errorf(err, "can't close the analyzer connection")
+		}
+	}
+}
+
+// restartRequired returns true if the configurations differ in something
+// that is not applied by reload
+func restartRequired(old, new Config) bool {
+	for _, c := range []*Config{&old, &new} {
+		c.Analyzers = nil
+		c.Timeout.AnalyzerReview = 0
+		c.Timeout.AnalyzerPush = 0
+		c.Providers.Github.CommentFooter = ""
+		c.Providers.Github.StatusTargetURL = ""
+		c.Providers.Github.PushResults = nil
+	}
+
+	return !reflect.DeepEqual(old, new)
+}
@@ -46,7 +46,7 @@ func (c *ServeCommand) ExecuteContext(ctx context.Context, args []string) error
 		return fmt.Errorf("Can't connect to the DB: %s", err)
 	}
 
-	eventOp, commentsOp, organizationsOp := c.initDBOperators(db)
+	eventOp, commentsOp, organizationsOp, analyzerRunsOp, responseCacheOp := c.initDBOperators(db)
 
 	analyzers, err := c.initAnalyzers(c.conf)
 	if err != nil {
@@ -63,6 +63,21 @@ func (c *ServeCommand) ExecuteContext(ctx context.Context, args []string) error
 		return err
 	}
 
+	scheduler, err := c.initScheduler(c.conf)
+	if err != nil {
+		return err
+	}
+
+	collector, err := c.initFeedbackCollector(c.conf, db)
+	if err != nil {
+		return err
+	}
+
+	fixer, err := c.initFixer(c.conf)
+	if err != nil {
+		return err
+	}
+
 	qOpt := cli.QueueOptions{
 		Queue:  "mem-queue",
 		Broker: "memory://",
@@ -74,17 +89,34 @@ func (c *ServeCommand) ExecuteContext(ctx context.Context, args []string) error
 	}
 
 	server := server.NewServer(server.Options{
-		Poster:         poster,
-		FileGetter:     dataHandler.FileGetter,
-		Analyzers:      analyzers,
-		EventOp:        eventOp,
-		CommentOp:      commentsOp,
-		OrganizationOp: organizationsOp,
-		ReviewTimeout:  c.conf.Timeout.AnalyzerReview,
-		PushTimeout:    c.conf.Timeout.AnalyzerPush,
+		Poster:           poster,
+		FileGetter:       dataHandler.FileGetter,
+		ChangeGetter:     dataHandler.ChangeGetter,
+		Analyzers:        analyzers,
+		EventOp:          eventOp,
+		CommentOp:        commentsOp,
+		OrganizationOp:   organizationsOp,
+		AnalyzerRunOp:    analyzerRunsOp,
+		ResponseCacheOp:  responseCacheOp,
+		ResponseCacheTTL: c.conf.ResponseCache.TTL,
+		ReviewTimeout:    c.conf.Timeout.AnalyzerReview,
+		PushTimeout:      c.conf.Timeout.AnalyzerPush,
+		RetryPolicy:      c.conf.Retry,
+		Breaker:          c.conf.CircuitBreaker,
+		Fixer:            fixer,
 	})
 
-	startDataServer, stopDataServer := c.initDataServer(dataHandler)
+	c.startAnalyzersProbe(server)
+
+	go c.watchConfig(ctx, server, poster)
+
+	if collector != nil {
+		go collector.Run(ctx)
+	}
+
+	registry := c.initRegistry(ctx, server)
+
+	startDataServer, stopDataServer := c.initDataServer(dataHandler, registry)
 	go func() {
 		err := startDataServer()
 		if err != context.Canceled {
@@ -109,6 +141,16 @@ func (c *ServeCommand) ExecuteContext(ctx context.Context, args []string) error
 		stopCh <- err
 	}()
 
+	if scheduler != nil {
+		go func() {
+			err := c.runEventEnqueuer(ctx, qOpt, scheduler)
+			if err != context.Canceled {
+				ctxlog.Get(ctx).Errorf(err, "scheduler stopped")
+			}
+			stopCh <- err
+		}()
+	}
+
 	c.probeReadiness = true
 
 	select {
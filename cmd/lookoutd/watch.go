@@ -39,6 +39,11 @@ func (c *WatchCommand) ExecuteContext(ctx context.Context, args []string) error
 		return err
 	}
 
+	scheduler, err := c.initScheduler(c.conf)
+	if err != nil {
+		return err
+	}
+
 	err = c.InitQueue()
 	if err != nil {
 		return err
@@ -52,6 +57,16 @@ func (c *WatchCommand) ExecuteContext(ctx context.Context, args []string) error
 		stopCh <- err
 	}()
 
+	if scheduler != nil {
+		go func() {
+			err := c.runEventEnqueuer(ctx, c.QueueOptions, scheduler)
+			if err != context.Canceled {
+				ctxlog.Get(ctx).Errorf(err, "scheduler stopped")
+			}
+			stopCh <- err
+		}()
+	}
+
 	c.probeReadiness = true
 
 	select {
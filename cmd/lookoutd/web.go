@@ -87,8 +87,15 @@ func (c *WebCommand) Execute(args []string) error {
 		OrganizationOp: orgOp,
 	}
 
+	feedbackOp := store.NewDBFeedbackOperator(
+		models.NewCommentFeedbackStore(db),
+		models.NewCommentStore(db),
+		models.NewReviewEventStore(db),
+	)
+	feedback := web.Feedback{FeedbackOp: feedbackOp}
+
 	static := web.NewStatic("/build/public", c.ServerURL, c.FooterHTML)
-	server := web.NewHTTPServer(auth, &gh, static)
+	server := web.NewHTTPServer(auth, &gh, &feedback, static)
 	addr := fmt.Sprintf("%s:%d", c.Host, c.Port)
 
 	log.Infof("Starting http server on %s", addr)
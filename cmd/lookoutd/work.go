@@ -47,7 +47,7 @@ func (c *WorkCommand) ExecuteContext(ctx context.Context, args []string) error {
 		return fmt.Errorf("Can't connect to the DB: %s", err)
 	}
 
-	eventOp, commentsOp, organizationsOp := c.initDBOperators(db)
+	eventOp, commentsOp, organizationsOp, analyzerRunsOp, responseCacheOp := c.initDBOperators(db)
 
 	analyzers, err := c.initAnalyzers(c.conf)
 	if err != nil {
@@ -64,18 +64,45 @@ func (c *WorkCommand) ExecuteContext(ctx context.Context, args []string) error {
 		return err
 	}
 
+	collector, err := c.initFeedbackCollector(c.conf, db)
+	if err != nil {
+		return err
+	}
+
+	fixer, err := c.initFixer(c.conf)
+	if err != nil {
+		return err
+	}
+
 	server := server.NewServer(server.Options{
-		Poster:         poster,
-		FileGetter:     dataHandler.FileGetter,
-		Analyzers:      analyzers,
-		EventOp:        eventOp,
-		CommentOp:      commentsOp,
-		OrganizationOp: organizationsOp,
-		ReviewTimeout:  c.conf.Timeout.AnalyzerReview,
-		PushTimeout:    c.conf.Timeout.AnalyzerPush,
+		Poster:           poster,
+		FileGetter:       dataHandler.FileGetter,
+		ChangeGetter:     dataHandler.ChangeGetter,
+		Analyzers:        analyzers,
+		EventOp:          eventOp,
+		CommentOp:        commentsOp,
+		OrganizationOp:   organizationsOp,
+		AnalyzerRunOp:    analyzerRunsOp,
+		ResponseCacheOp:  responseCacheOp,
+		ResponseCacheTTL: c.conf.ResponseCache.TTL,
+		ReviewTimeout:    c.conf.Timeout.AnalyzerReview,
+		PushTimeout:      c.conf.Timeout.AnalyzerPush,
+		RetryPolicy:      c.conf.Retry,
+		Breaker:          c.conf.CircuitBreaker,
+		Fixer:            fixer,
 	})
 
-	startDataServer, stopDataServer := c.initDataServer(dataHandler)
+	c.startAnalyzersProbe(server)
+
+	go c.watchConfig(ctx, server, poster)
+
+	if collector != nil {
+		go collector.Run(ctx)
+	}
+
+	registry := c.initRegistry(ctx, server)
+
+	startDataServer, stopDataServer := c.initDataServer(dataHandler, registry)
 	go func() {
 		err := startDataServer()
 		if err != context.Canceled {
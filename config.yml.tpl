@@ -4,13 +4,28 @@ analyzers:
     addr: ipv4://localhost:9930
     disabled: false
     # feedback: url to link in the comment_footer. For example, to open a new GitHub issue
+    # cache: set to false for non-deterministic analyzers, to disable the cache of its responses
+    # min_confidence: comments with a lower confidence are not posted
+    # include: list of .gitignore path patterns of the analyzed files, all by default
+    # exclude: list of .gitignore path patterns of the ignored files
+    # timeout_review, timeout_push: override the analyzer timeouts for this analyzer
+    # max_comments: maximum number of comments to be posted, the rest are dropped
+    # depends_on: list of analyzers whose comments are sent to this one
+    # autofix: pull_request or push, to apply the fixes of the comments instead of posting them
+    # out_of_diff: summary, file or drop, how the comments outside the diff are posted, summary by default
     # settings: map with custom info that will be sent to the analyzer "as is"
 
 providers:
   github:
     comment_footer: "_{{if .Feedback}}If you have feedback about this comment made by the analyzer {{.Name}}, please, [tell us]({{.Feedback}}){{else}}Comment made by the analyzer {{.Name}}{{end}}._"
+    # Go template of the commit statuses target URL. Available fields: Analyzer
+    # (empty for the global status), Owner, Repository, PullRequest and Head
+    # status_target_url: "https://github.com/src-d/lookout"
     # The minimum watch interval to discover new pull requests and push events
     watch_min_interval: 2s
+    # Where to post the comments of push events: commit_comment, commit_status
+    # and/or issue. They are not posted by default
+    # push_results: [commit_status]
     # Authorization with GitHub App
     # See https://developer.github.com/apps/building-github-apps/authenticating-with-github-apps/
     # app_id: 1234
@@ -46,3 +61,54 @@ timeout:
   git_fetch: 20m
   # Timeout for Bblfsh to reply a Parse request
   bblfsh_parse: 2m
+
+# Events that fail because of a transient error (an analyzer is unavailable or
+# times out, GitHub API replies with a 5xx error...) are retried with an
+# exponential backoff. These are the default values. A max_attempts of 0 or 1
+# disables retries
+retry:
+  # Maximum number of times an event is processed
+  max_attempts: 5
+  # Delay before the first retry
+  initial_backoff: 1m
+  # Maximum delay between two attempts
+  max_backoff: 1h
+  # Factor the delay is multiplied by after each attempt
+  multiplier: 2
+
+# Each analyzer has a circuit breaker. After failure_threshold consecutive
+# failed requests the breaker opens, and the analyzer is skipped until the
+# probe_interval passes and a new request succeeds. These are the default
+# values. A failure_threshold of 0 disables the circuit breakers
+circuit_breaker:
+  # Number of consecutive failed requests that opens the breaker
+  failure_threshold: 5
+  # Time to wait before probing an analyzer with an open breaker
+  probe_interval: 1m
+
+# Analyzer responses are cached, and reused when an analyzer is called again
+# with the same version, revisions and settings. This is the default value.
+# A ttl of 0 disables the cache
+response_cache:
+  ttl: 24h
+
+# Analyzers can register themselves through the data server, instead of being
+# listed in analyzers. A registration is removed if the analyzer does not send
+# a heartbeat in ttl. The registration is disabled by default, with a ttl of 0.
+# Only the analyzers listed in analyzers are allowed to register
+registry:
+  ttl: 0
+  analyzers: []
+
+# Analyze the head of branches periodically, with a cron expression in UTC.
+# repositories defaults to all of them, and branches to the default branch
+# schedules:
+#   - cron: "0 3 * * *"
+#     repositories: [github.com/src-d/lookout]
+#     branches: [master]
+
+# Read periodically the reactions and replies to the posted comments, for the
+# comments posted in the last max_age. An interval of 0 disables it
+feedback:
+  interval: 0
+  max_age: 720h
@@ -74,6 +74,38 @@ type ReviewEvent struct {
 	// OrganizationID is the organization to which this event's repository
 	// belongs to
 	OrganizationID string
+	// Rerun is set when the analysis of the review is requested again, nil
+	// for the regular events
+	Rerun *Rerun
+}
+
+// Rerun is a request to analyze a review again, even if it was already
+// analyzed. The comments are posted even if they were posted before.
+type Rerun struct {
+	// ID identifies the request, like the comment of the rerun command
+	ID string
+	// Analyzer is the only analyzer to run again, empty means all of them
+	Analyzer string
+}
+
+// ID returns the EventID. The reruns of a review have their own ID, so they
+// are not skipped as already processed events.
+func (e *ReviewEvent) ID() EventID {
+	if e.Rerun == nil {
+		return e.ReviewEvent.ID()
+	}
+
+	return pb.ComputeEventID(e.Provider, e.InternalID, e.Head.Hash, "rerun", e.Rerun.ID)
+}
+
+// RerunOf returns the rerun request of the event, or nil if the event is not
+// a rerun
+func RerunOf(e Event) *Rerun {
+	if ev, ok := e.(*ReviewEvent); ok {
+		return ev.Rerun
+	}
+
+	return nil
 }
 
 // GetProvider returns the name of the provider that created this event
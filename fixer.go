@@ -0,0 +1,33 @@
+package lookout
+
+import "context"
+
+// Modes of the autofix of an analyzer
+const (
+	// PullRequestAutofix pushes the fixes to a new branch and opens a
+	// pull request with them against the head branch of the pull request
+	PullRequestAutofix = "pull_request"
+	// PushAutofix pushes the fixes to the head branch of the pull request
+	PushAutofix = "push"
+)
+
+// FixResult is the result of applying the fixes of the comments of an
+// analyzer
+type FixResult struct {
+	// Applied are the comments whose fix was applied
+	Applied []*Comment
+	// Conflicts are the comments whose fix could not be applied because it
+	// overlaps with another fix or does not match the files
+	Conflicts []*Comment
+	// URL is the address of the pull request or the commit with the fixes,
+	// empty if none was applied
+	URL string
+}
+
+// Fixer applies the fixes of the comments on top of the head of a pull
+// request and pushes them.
+type Fixer interface {
+	// Fix applies the fixes of the comments of an analyzer, in the given
+	// autofix mode, and pushes them. The comments without a fix are ignored.
+	Fix(ctx context.Context, e *ReviewEvent, mode string, analyzer string, comments []*Comment) (*FixResult, error)
+}
@@ -27,12 +27,64 @@ func (st AnalysisStatus) String() string {
 	return names[st]
 }
 
+// ReviewAction is the kind of review posted along with the comments
+type ReviewAction int
+
+const (
+	// CommentReviewAction posts the comments without approving or rejecting
+	// the changes
+	CommentReviewAction ReviewAction = iota
+	// RequestChangesReviewAction posts the comments requesting changes
+	RequestChangesReviewAction
+	// ApproveReviewAction posts the comments approving the changes
+	ApproveReviewAction
+)
+
+func (a ReviewAction) String() string {
+	names := [...]string{"comment", "request_changes", "approve"}
+	if a < CommentReviewAction || a > ApproveReviewAction {
+		return "unknown"
+	}
+
+	return names[a]
+}
+
+// Modes of posting the comments of an analyzer on lines outside the diff of a
+// pull request, that can't be posted as review comments
+const (
+	// SummaryOutOfDiff lists the comments, grouped by file, in a section of
+	// the review body
+	SummaryOutOfDiff = "summary"
+	// FileOutOfDiff posts the comments as file-level review comments, or in
+	// the review body for the files that are not part of the diff
+	FileOutOfDiff = "file"
+	// DropOutOfDiff does not post the comments
+	DropOutOfDiff = "drop"
+)
+
+// AnalyzerStatus is the status of the analysis made by a single analyzer
+type AnalyzerStatus struct {
+	// Analyzer is the name of the analyzer
+	Analyzer string
+	Status   AnalysisStatus
+	// Description is a short summary of the result, like the number of
+	// comments or the reason of the error
+	Description string
+}
+
 // Poster can post comments about an event.
 type Poster interface {
 	// Post posts comments about an event.
-	// poster should make sure comments weren't posted before if safe is true
-	Post(ctx context.Context, e Event, cs []AnalyzerComments, safe bool) error
+	// poster should make sure comments weren't posted before if safe is true.
+	// The action is the kind of review to post, providers without reviews
+	// can ignore it. Even if there are no comments, an approval should be
+	// posted, and so should the results of a push event.
+	Post(ctx context.Context, e Event, cs []AnalyzerComments, safe bool, action ReviewAction) error
 
 	// Status sends the current analysis status to the provider
 	Status(context.Context, Event, AnalysisStatus) error
+
+	// AnalyzerStatus sends the current status of the analysis made by a
+	// single analyzer to the provider
+	AnalyzerStatus(context.Context, Event, AnalyzerStatus) error
 }
@@ -17,3 +17,11 @@ func TestAnalysisStatusStringer(t *testing.T) {
 	assert.Equal(t, "unknown", AnalysisStatus(0).String())
 	assert.Equal(t, "unknown", AnalysisStatus(100).String())
 }
+
+func TestReviewActionStringer(t *testing.T) {
+	assert.Equal(t, "comment", CommentReviewAction.String())
+	assert.Equal(t, "request_changes", RequestChangesReviewAction.String())
+	assert.Equal(t, "approve", ApproveReviewAction.String())
+
+	assert.Equal(t, "unknown", ReviewAction(100).String())
+}
@@ -0,0 +1,178 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/src-d/lookout"
+	"github.com/src-d/lookout/service/git"
+	"github.com/src-d/lookout/util/ctxlog"
+
+	"github.com/google/go-github/github"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	log "gopkg.in/src-d/go-log.v1"
+)
+
+// autofixBranchPrefix is the prefix of the branches with the fixes of a pull
+// request, followed by its number and the analyzer name
+const autofixBranchPrefix = "lookout/autofix/"
+
+// autofixAuthor is the author of the commits with the fixes
+var autofixAuthor = object.Signature{
+	Name:  "lookout",
+	Email: "lookout@users.noreply.github.com",
+}
+
+// FixCommitter creates the commits with the fixes of comments and pushes them
+type FixCommitter interface {
+	Commit(
+		ctx context.Context,
+		head lookout.ReferencePointer,
+		comments []*lookout.Comment,
+		message string,
+		author object.Signature,
+	) (*git.FixCommit, error)
+	Push(
+		ctx context.Context,
+		repoInfo *lookout.RepositoryInfo,
+		hash plumbing.Hash,
+		branch string,
+		force bool,
+	) error
+}
+
+var _ FixCommitter = &git.Committer{}
+
+// Autofixer applies the fixes of the comments on a pull request, and pushes
+// them to its head branch or opens a follow-up pull request with them
+type Autofixer struct {
+	pool      *ClientPool
+	committer FixCommitter
+}
+
+var _ lookout.Fixer = &Autofixer{}
+
+// NewAutofixer returns a new Autofixer for the repositories of the pool
+func NewAutofixer(pool *ClientPool, committer FixCommitter) *Autofixer {
+	return &Autofixer{pool: pool, committer: committer}
+}
+
+// Fix implements the lookout.Fixer interface. The fixes are committed on top
+// of the head of the pull request. With the push mode they are pushed to its
+// head branch, with the pull_request mode they are pushed to a new branch and
+// a pull request against the head branch is opened, or updated if it exists.
+// Pull requests from forks are not supported.
+func (a *Autofixer) Fix(
+	ctx context.Context,
+	e *lookout.ReviewEvent,
+	mode string,
+	analyzer string,
+	comments []*lookout.Comment,
+) (*lookout.FixResult, error) {
+	if e.Provider != Provider {
+		return nil, ErrEventNotSupported.Wrap(
+			fmt.Errorf("unsupported provider: %s", e.Provider))
+	}
+
+	if mode != lookout.PushAutofix && mode != lookout.PullRequestAutofix {
+		return nil, fmt.Errorf("unknown autofix mode: %s", mode)
+	}
+
+	owner, repo, number, err := validatePR(e)
+	if err != nil {
+		return nil, err
+	}
+
+	// the branch of the pull request must be pushed to the base repository
+	repoInfo := e.Head.Repository()
+	if e.Source.Repository() == nil || e.Source.Repository().FullName != repoInfo.FullName {
+		return nil, ErrEventNotSupported.Wrap(
+			fmt.Errorf("pull requests from forks can't be fixed"))
+	}
+
+	client, ok := a.pool.Client(owner, repo)
+	if !ok {
+		return nil, fmt.Errorf("client for %s/%s doesn't exists", owner, repo)
+	}
+
+	message := fmt.Sprintf("Fix the comments of %s\n\nThe fixes were suggested by lookout on #%d.", analyzer, number)
+	commit, err := a.committer.Commit(ctx, e.Head, comments, message, autofixAuthor)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &lookout.FixResult{
+		Applied:   commit.Applied,
+		Conflicts: commit.Conflicts,
+	}
+
+	if commit.Hash == plumbing.ZeroHash {
+		return res, nil
+	}
+
+	headBranch := e.Source.ReferenceName.Short()
+	if mode == lookout.PushAutofix {
+		if err := a.committer.Push(ctx, repoInfo, commit.Hash, headBranch, false); err != nil {
+			return nil, err
+		}
+
+		res.URL = fmt.Sprintf("https://%s/%s/commit/%s", repoInfo.Host, repoInfo.FullName, commit.Hash)
+		return res, nil
+	}
+
+	branch := fmt.Sprintf("%s%d/%s", autofixBranchPrefix, number, analyzer)
+	if err := a.committer.Push(ctx, repoInfo, commit.Hash, branch, true); err != nil {
+		return nil, err
+	}
+
+	res.URL, err = a.fixesPullRequest(ctx, client, owner, repo, number, analyzer, branch, headBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// fixesPullRequest returns the url of the open pull request of the branch
+// with the fixes, creating it if it does not exist
+func (a *Autofixer) fixesPullRequest(
+	ctx context.Context,
+	client *Client,
+	owner, repo string,
+	number int,
+	analyzer, branch, base string,
+) (string, error) {
+	prs, resp, err := client.PullRequests.List(ctx, owner, repo, &github.PullRequestListOptions{
+		State: "open",
+		Head:  owner + ":" + branch,
+		Base:  base,
+	})
+	if err = handleAPIError(resp, err, "pull requests could not be listed"); err != nil {
+		return "", err
+	}
+
+	if len(prs) > 0 {
+		return prs[0].GetHTMLURL(), nil
+	}
+
+	title := fmt.Sprintf("Fix the comments of %s in #%d", analyzer, number)
+	body := fmt.Sprintf("The fixes of the comments of the analyzer %s on #%d. "+
+		"Merge this pull request to apply them to `%s`.", analyzer, number, base)
+	pr, _, err := client.PullRequests.Create(ctx, owner, repo, &github.NewPullRequest{
+		Title: &title,
+		Head:  &branch,
+		Base:  &base,
+		Body:  &body,
+	})
+	if err != nil {
+		return "", ErrGitHubAPI.Wrap(err, "pull request could not be created")
+	}
+
+	ctxlog.Get(ctx).With(log.Fields{
+		"github.pr": pr.GetNumber(),
+		"branch":    branch,
+	}).Infof("pull request with the fixes created")
+
+	return pr.GetHTMLURL(), nil
+}
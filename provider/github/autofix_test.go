@@ -0,0 +1,227 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/src-d/lookout"
+	"github.com/src-d/lookout/service/git"
+	"github.com/src-d/lookout/util/cache"
+
+	"github.com/google/go-github/github"
+	"github.com/gregjones/httpcache"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/lookout-sdk.v0/pb"
+)
+
+type AutofixTestSuite struct {
+	suite.Suite
+	mux    *http.ServeMux
+	server *httptest.Server
+	pool   *ClientPool
+}
+
+func (s *AutofixTestSuite) SetupTest() {
+	s.mux = http.NewServeMux()
+	s.server = httptest.NewServer(mockPermissions(s.mux))
+
+	cache := cache.NewValidableCache(httpcache.NewMemoryCache())
+	githubURL, _ := url.Parse(s.server.URL + "/")
+
+	s.pool = newTestPool(s.Suite, []string{"github.com/foo/bar"}, githubURL, cache, false)
+}
+
+func (s *AutofixTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+type pushed struct {
+	hash   plumbing.Hash
+	branch string
+	force  bool
+}
+
+type committerMock struct {
+	commit  *git.FixCommit
+	message string
+	author  object.Signature
+	pushed  []pushed
+}
+
+func (c *committerMock) Commit(
+	ctx context.Context,
+	head lookout.ReferencePointer,
+	comments []*lookout.Comment,
+	message string,
+	author object.Signature,
+) (*git.FixCommit, error) {
+	c.message = message
+	c.author = author
+	return c.commit, nil
+}
+
+func (c *committerMock) Push(
+	ctx context.Context,
+	repoInfo *lookout.RepositoryInfo,
+	hash plumbing.Hash,
+	branch string,
+	force bool,
+) error {
+	c.pushed = append(c.pushed, pushed{hash, branch, force})
+	return nil
+}
+
+func autofixEvent(sourceURL string) *lookout.ReviewEvent {
+	return &lookout.ReviewEvent{
+		ReviewEvent: pb.ReviewEvent{
+			Provider: Provider,
+			Source: lookout.ReferencePointer{
+				InternalRepositoryURL: sourceURL,
+				ReferenceName:         "refs/heads/feature",
+				Hash:                  hash2,
+			},
+			CommitRevision: lookout.CommitRevision{
+				Base: lookout.ReferencePointer{
+					InternalRepositoryURL: "https://github.com/foo/bar",
+					ReferenceName:         base1,
+					Hash:                  hash1,
+				},
+				Head: lookout.ReferencePointer{
+					InternalRepositoryURL: "https://github.com/foo/bar",
+					ReferenceName:         head1,
+					Hash:                  hash2,
+				}}}}
+}
+
+var (
+	appliedFix  = &lookout.Comment{File: "main.go", Line: 3, Fix: &lookout.Fix{StartLine: 3, Text: "fixed"}}
+	conflictFix = &lookout.Comment{File: "main.go", Line: 3, Fix: &lookout.Fix{StartLine: 3, Text: "other"}}
+	fixHash     = plumbing.NewHash("6ecf0ef2c2dffb796033e5a02219af86ec6584e5")
+)
+
+func (s *AutofixTestSuite) TestFixPullRequest() {
+	var listCalled, createCalled bool
+	s.mux.HandleFunc("/repos/foo/bar/pulls", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			listCalled = true
+			s.Equal("foo:lookout/autofix/42/style", r.URL.Query().Get("head"))
+			s.Equal("feature", r.URL.Query().Get("base"))
+			json.NewEncoder(w).Encode([]*github.PullRequest{})
+			return
+		}
+
+		createCalled = true
+		var pr github.NewPullRequest
+		s.NoError(json.NewDecoder(r.Body).Decode(&pr))
+		s.Equal("Fix the comments of style in #42", pr.GetTitle())
+		s.Equal("lookout/autofix/42/style", pr.GetHead())
+		s.Equal("feature", pr.GetBase())
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(&github.PullRequest{
+			Number:  intptr(43),
+			HTMLURL: strptr("https://github.com/foo/bar/pull/43"),
+		})
+	})
+
+	committer := &committerMock{commit: &git.FixCommit{
+		Hash:      fixHash,
+		Applied:   []*lookout.Comment{appliedFix},
+		Conflicts: []*lookout.Comment{conflictFix},
+	}}
+
+	a := NewAutofixer(s.pool, committer)
+	res, err := a.Fix(context.TODO(), autofixEvent("https://github.com/foo/bar"),
+		lookout.PullRequestAutofix, "style", []*lookout.Comment{appliedFix, conflictFix})
+	s.Require().NoError(err)
+
+	s.True(listCalled)
+	s.True(createCalled)
+	s.Equal(&lookout.FixResult{
+		Applied:   []*lookout.Comment{appliedFix},
+		Conflicts: []*lookout.Comment{conflictFix},
+		URL:       "https://github.com/foo/bar/pull/43",
+	}, res)
+
+	s.Equal([]pushed{{fixHash, "lookout/autofix/42/style", true}}, committer.pushed)
+	s.Equal(autofixAuthor, committer.author)
+	s.Contains(committer.message, "#42")
+}
+
+func (s *AutofixTestSuite) TestFixPullRequestExisting() {
+	s.mux.HandleFunc("/repos/foo/bar/pulls", func(w http.ResponseWriter, r *http.Request) {
+		s.Equal(http.MethodGet, r.Method)
+		json.NewEncoder(w).Encode([]*github.PullRequest{{
+			Number:  intptr(43),
+			HTMLURL: strptr("https://github.com/foo/bar/pull/43"),
+		}})
+	})
+
+	committer := &committerMock{commit: &git.FixCommit{
+		Hash:    fixHash,
+		Applied: []*lookout.Comment{appliedFix},
+	}}
+
+	a := NewAutofixer(s.pool, committer)
+	res, err := a.Fix(context.TODO(), autofixEvent("https://github.com/foo/bar"),
+		lookout.PullRequestAutofix, "style", []*lookout.Comment{appliedFix})
+	s.Require().NoError(err)
+	s.Equal("https://github.com/foo/bar/pull/43", res.URL)
+}
+
+func (s *AutofixTestSuite) TestFixPush() {
+	committer := &committerMock{commit: &git.FixCommit{
+		Hash:    fixHash,
+		Applied: []*lookout.Comment{appliedFix},
+	}}
+
+	a := NewAutofixer(s.pool, committer)
+	res, err := a.Fix(context.TODO(), autofixEvent("https://github.com/foo/bar"),
+		lookout.PushAutofix, "style", []*lookout.Comment{appliedFix})
+	s.Require().NoError(err)
+
+	s.Equal("https://github.com/foo/bar/commit/"+fixHash.String(), res.URL)
+	s.Equal([]pushed{{fixHash, "feature", false}}, committer.pushed)
+}
+
+func (s *AutofixTestSuite) TestFixNothingApplied() {
+	committer := &committerMock{commit: &git.FixCommit{
+		Conflicts: []*lookout.Comment{conflictFix},
+	}}
+
+	a := NewAutofixer(s.pool, committer)
+	res, err := a.Fix(context.TODO(), autofixEvent("https://github.com/foo/bar"),
+		lookout.PullRequestAutofix, "style", []*lookout.Comment{conflictFix})
+	s.Require().NoError(err)
+
+	s.Equal("", res.URL)
+	s.Equal([]*lookout.Comment{conflictFix}, res.Conflicts)
+	s.Len(committer.pushed, 0)
+}
+
+func (s *AutofixTestSuite) TestFixFork() {
+	committer := &committerMock{}
+
+	a := NewAutofixer(s.pool, committer)
+	_, err := a.Fix(context.TODO(), autofixEvent("https://github.com/fork/bar"),
+		lookout.PushAutofix, "style", []*lookout.Comment{appliedFix})
+	s.True(ErrEventNotSupported.Is(err))
+	s.Len(committer.pushed, 0)
+}
+
+func (s *AutofixTestSuite) TestFixUnknownMode() {
+	a := NewAutofixer(s.pool, &committerMock{})
+	_, err := a.Fix(context.TODO(), autofixEvent("https://github.com/foo/bar"),
+		"unknown", "style", []*lookout.Comment{appliedFix})
+	s.EqualError(err, "unknown autofix mode: unknown")
+}
+
+func TestAutofixTestSuite(t *testing.T) {
+	suite.Run(t, new(AutofixTestSuite))
+}
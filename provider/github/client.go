@@ -465,6 +465,10 @@ func handleAPIError(resp *github.Response, err error, msg string) error {
 			}
 		}
 
+		if isServerErrorResponse(resp) {
+			err = &serverError{err}
+		}
+
 		return ErrGitHubAPI.Wrap(err, msg)
 	}
 
@@ -472,10 +476,32 @@ func handleAPIError(resp *github.Response, err error, msg string) error {
 		return nil
 	}
 
-	return ErrGitHubAPI.Wrap(
-		fmt.Errorf("bad HTTP status: %d", resp.StatusCode),
-		msg,
-	)
+	err = fmt.Errorf("bad HTTP status: %d", resp.StatusCode)
+	if isServerErrorResponse(resp) {
+		err = &serverError{err}
+	}
+
+	return ErrGitHubAPI.Wrap(err, msg)
+}
+
+func isServerErrorResponse(resp *github.Response) bool {
+	return resp != nil && resp.Response != nil && resp.StatusCode >= 500
+}
+
+// serverError wraps an error caused by a 5xx response from GitHub API.
+// Such errors are temporary, the same request can succeed later.
+type serverError struct {
+	error
+}
+
+// Temporary returns true, signaling that the request can be retried
+func (e *serverError) Temporary() bool {
+	return true
+}
+
+// Cause returns the wrapped error
+func (e *serverError) Cause() error {
+	return e.error
 }
 
 // ValidateTokenPermissions checks that client has necessary permissions required by lookout
@@ -14,6 +14,7 @@ import (
 	"github.com/google/go-github/github"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-errors.v1"
 	"gopkg.in/src-d/lookout-sdk.v0/pb"
 )
 
@@ -180,6 +181,35 @@ func TestErrorResponseDoesNotPanic(t *testing.T) {
 	require.NotPanics(processAPIError(apiResponseErrWithoutEmbededResponse), "empty API error should not panic when stringed")
 }
 
+func TestAPIErrorTemporary(t *testing.T) {
+	require := require.New(t)
+
+	isTemporary := func(err error) bool {
+		require.True(ErrGitHubAPI.Is(err))
+		tErr, ok := err.(*errors.Error).Cause().(interface{ Temporary() bool })
+		return ok && tErr.Temporary()
+	}
+
+	response := func(code int) *github.Response {
+		return &github.Response{Response: &http.Response{StatusCode: code}}
+	}
+
+	require.True(isTemporary(handleAPIError(response(http.StatusBadGateway), nil, "")))
+	require.False(isTemporary(handleAPIError(response(http.StatusNotFound), nil, "")))
+
+	apiErr := &github.ErrorResponse{Response: &http.Response{
+		StatusCode: http.StatusServiceUnavailable,
+		Request:    &http.Request{},
+	}}
+	require.True(isTemporary(handleAPIError(response(http.StatusServiceUnavailable), apiErr, "")))
+
+	apiErr = &github.ErrorResponse{Response: &http.Response{
+		StatusCode: http.StatusUnprocessableEntity,
+		Request:    &http.Request{},
+	}}
+	require.False(isTemporary(handleAPIError(response(http.StatusUnprocessableEntity), apiErr, "")))
+}
+
 // parseTestRepositoryInfo is a convenience wrapper around pb.ParseRepositoryInfo
 // for testing
 func parseTestRepositoryInfo(input string) (*repositoryInfo, error) {
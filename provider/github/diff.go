@@ -22,6 +22,9 @@ var (
 	ErrFileNotFound = errors.NewKind("file not found")
 	// ErrBadPatch is returned when there was a problem parsing the diff
 	ErrBadPatch = errors.NewKind("diff patch could not be parsed")
+	// ErrRangeNotInHunk is returned when the lines of a range are not all
+	// in the same hunk of the patch diff
+	ErrRangeNotInHunk = errors.NewKind("line range is not in a single hunk of the diff")
 )
 
 type diffLines struct {
@@ -90,6 +93,41 @@ func (d *diffLines) ConvertLine(file string, line int, strict bool) (int, error)
 	return diffLine, nil
 }
 
+// ConvertRange takes a range of line numbers on the original file, both
+// inclusive, and returns the line number in the patch diff of the last one.
+// It will return ErrLineOutOfDiff if the first line falls outside of the diff,
+// and ErrRangeNotInHunk if the rest of the lines are not in the same hunk.
+// At least one of the lines must be an addition (+ lines in the diff),
+// otherwise ErrLineNotAddition will be returned.
+func (d *diffLines) ConvertRange(file string, start, end int) (int, error) {
+	parsedFile, err := d.parseFile(file)
+	if err != nil {
+		return 0, err
+	}
+
+	var diffLine int
+	var added bool
+	for line := start; line <= end; line++ {
+		diffLine, err = d.convertLine(parsedFile.ranges, line)
+		if ErrLineOutOfDiff.Is(err) && line > start {
+			// the hunks are separated by lines out of the diff
+			return 0, ErrRangeNotInHunk.New()
+		}
+
+		if err != nil {
+			return 0, err
+		}
+
+		added = added || parsedFile.linesAdded[diffLine]
+	}
+
+	if !added {
+		return 0, ErrLineNotAddition.New()
+	}
+
+	return diffLine, nil
+}
+
 func (d *diffLines) convertLine(ranges []*posRange, line int) (int, error) {
 	for _, r := range ranges {
 		if line >= r.AbsStart && line < r.AbsEnd {
@@ -412,6 +412,89 @@ func TestConvertLines(t *testing.T) {
 	}
 }
 
+func TestConvertRange(t *testing.T) {
+	filename := "some_file"
+
+	// only insert
+	strHunk1 := `@@ -5,6 +5,8 @@ header-line
+ context-line1
+ context-line2
+ context-line3
++new-line1
++new-line2
+ context-line4
+ context-line5
+ context-line6`
+	// only delete
+	strHunk2 := `@@ -20,8 +22,6 @@ header-line
+ context-line1
+ context-line2
+ context-line3
+-old-line1
+-old-line2
+ context-line4
+ context-line5
+ context-line6`
+	// delete and insert
+	strHunk3 := `@@ -35,7 +35,7 @@ header-line
+ context-line1
+ context-line2
+ context-line3
+-delete line
++insert line
+ context-line4
+ context-line5
+ context-line6`
+	patch := strHunk1 + "\n" + strHunk2 + "\n" + strHunk3
+
+	cc := &github.CommitsComparison{
+		Files: []github.CommitFile{
+			{
+				Filename: &filename,
+				Patch:    &patch,
+			},
+		},
+	}
+	dl := newDiffLines(cc)
+
+	rangeTestCases := []struct {
+		start, end, diffLine int
+		err                  error
+	}{
+		// range with the inserts of the first hunk
+		{7, 10, 6, nil},
+		// single inserted line
+		{8, 8, 4, nil},
+		// range around the delete and the insert of the 3rd hunk
+		{36, 39, 24, nil},
+		// only context lines
+		{10, 12, 0, ErrLineNotAddition.New()},
+		// only context lines around a delete
+		{23, 26, 0, ErrLineNotAddition.New()},
+		// first line out of range
+		{3, 8, 0, ErrLineOutOfDiff.New()},
+		// range across hunks
+		{9, 23, 0, ErrRangeNotInHunk.New()},
+		// last line out of range
+		{38, 45, 0, ErrRangeNotInHunk.New()},
+	}
+
+	for _, tc := range rangeTestCases {
+		t.Run(fmt.Sprintf("file lines %v-%v", tc.start, tc.end), func(t *testing.T) {
+			assert := assert.New(t)
+
+			diffLine, err := dl.ConvertRange(filename, tc.start, tc.end)
+			if tc.err != nil {
+				assert.Equal(0, diffLine)
+				assert.EqualError(err, tc.err.Error())
+			} else {
+				assert.Equal(tc.diffLine, diffLine)
+				assert.NoError(err)
+			}
+		})
+	}
+}
+
 func intPointer(v int) *int {
 	return &v
 }
@@ -0,0 +1,173 @@
+package github
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/src-d/lookout/store"
+	"github.com/src-d/lookout/store/models"
+	"github.com/src-d/lookout/util/ctxlog"
+
+	"github.com/google/go-github/github"
+	log "gopkg.in/src-d/go-log.v1"
+)
+
+// FeedbackCollector periodically reads the reactions and the replies to the
+// review comments posted by lookout, and saves them as the feedback of the
+// stored comments
+type FeedbackCollector struct {
+	pool     *ClientPool
+	op       store.FeedbackOperator
+	interval time.Duration
+	maxAge   time.Duration
+	now      func() time.Time
+}
+
+// NewFeedbackCollector returns a new FeedbackCollector that reads the feedback
+// every interval, for the comments posted in the last maxAge
+func NewFeedbackCollector(
+	pool *ClientPool,
+	op store.FeedbackOperator,
+	interval, maxAge time.Duration,
+) *FeedbackCollector {
+	return &FeedbackCollector{
+		pool:     pool,
+		op:       op,
+		interval: interval,
+		maxAge:   maxAge,
+		now:      time.Now,
+	}
+}
+
+// Run collects the feedback every interval until the context is canceled
+func (f *FeedbackCollector) Run(ctx context.Context) error {
+	ctxlog.Get(ctx).With(log.Fields{"interval": f.interval}).Infof("Starting feedback collector")
+
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		if err := f.Collect(ctx); err != nil {
+			ctxlog.Get(ctx).Errorf(err, "feedback could not be collected")
+		}
+	}
+}
+
+// Collect reads the feedback of the comments posted in the last maxAge and
+// saves it. API errors are logged, and the pull request is skipped.
+func (f *FeedbackCollector) Collect(ctx context.Context) error {
+	posted, err := f.op.Posted(ctx, f.now().Add(-f.maxAge))
+	if err != nil {
+		return err
+	}
+
+	for _, p := range posted {
+		if p.Provider != Provider {
+			continue
+		}
+
+		ctx, logger := ctxlog.WithLogFields(ctx, log.Fields{
+			"repository": p.Repository.FullName,
+			"github.pr":  p.Number,
+		})
+
+		client, ok := f.pool.Client(p.Repository.Owner, p.Repository.Name)
+		if !ok {
+			logger.Debugf("skipping feedback of a repository not watched")
+			continue
+		}
+
+		ghComments, err := listReviewComments(ctx, client, p.Repository.Owner, p.Repository.Name, int(p.Number))
+		if err != nil {
+			logger.Errorf(err, "feedback could not be read")
+			continue
+		}
+
+		for _, fb := range matchFeedback(p.Comments, ghComments) {
+			if err := f.op.Save(ctx, fb); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func listReviewComments(
+	ctx context.Context,
+	client *Client,
+	owner, repo string,
+	number int,
+) ([]*github.PullRequestComment, error) {
+	var comments []*github.PullRequestComment
+	opts := &github.PullRequestListCommentsOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	for {
+		page, resp, err := client.PullRequests.ListComments(ctx, owner, repo, number, opts)
+		if err = handleAPIError(resp, err, "review comments could not be listed"); err != nil {
+			return nil, err
+		}
+
+		comments = append(comments, page...)
+
+		if resp.NextPage == 0 {
+			return comments, nil
+		}
+
+		opts.Page = resp.NextPage
+	}
+}
+
+// matchFeedback returns the feedback of the stored comments that match a
+// review comment of the pull request. A review comment matches a stored one
+// on the same file if its body starts with the text of the stored comment,
+// the footer is not taken into account. Comments are matched in the order
+// they were posted.
+func matchFeedback(comments []*models.Comment, ghComments []*github.PullRequestComment) []*models.CommentFeedback {
+	replies := make(map[int64]int)
+	for _, gc := range ghComments {
+		if gc.GetInReplyTo() != 0 {
+			replies[gc.GetInReplyTo()]++
+		}
+	}
+
+	matched := make(map[int64]bool)
+	var result []*models.CommentFeedback
+	for _, c := range comments {
+		if c.Text == "" {
+			continue
+		}
+
+		for _, gc := range ghComments {
+			if matched[gc.GetID()] ||
+				gc.GetInReplyTo() != 0 ||
+				gc.GetPath() != c.File ||
+				!strings.HasPrefix(gc.GetBody(), c.Text) {
+				continue
+			}
+
+			matched[gc.GetID()] = true
+
+			fb := models.NewCommentFeedback(c, strconv.FormatInt(gc.GetID(), 10))
+			fb.ThumbsUp = gc.GetReactions().GetPlusOne()
+			fb.ThumbsDown = gc.GetReactions().GetMinusOne()
+			fb.Confused = gc.GetReactions().GetConfused()
+			fb.Replies = replies[gc.GetID()]
+
+			result = append(result, fb)
+			break
+		}
+	}
+
+	return result
+}
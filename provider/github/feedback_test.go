@@ -0,0 +1,163 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/src-d/lookout"
+	"github.com/src-d/lookout/store"
+	"github.com/src-d/lookout/store/models"
+	"github.com/src-d/lookout/util/cache"
+
+	"github.com/google/go-github/github"
+	"github.com/gregjones/httpcache"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/src-d/lookout-sdk.v0/pb"
+)
+
+type FeedbackTestSuite struct {
+	suite.Suite
+	mux    *http.ServeMux
+	server *httptest.Server
+	pool   *ClientPool
+}
+
+func (s *FeedbackTestSuite) SetupTest() {
+	s.mux = http.NewServeMux()
+	s.server = httptest.NewServer(mockPermissions(s.mux))
+
+	cache := cache.NewValidableCache(httpcache.NewMemoryCache())
+	githubURL, _ := url.Parse(s.server.URL + "/")
+
+	s.pool = newTestPool(s.Suite, []string{"github.com/foo/bar"}, githubURL, cache, false)
+}
+
+func (s *FeedbackTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+type feedbackOperatorMock struct {
+	since  time.Time
+	posted []*store.PostedComments
+	saved  []*models.CommentFeedback
+}
+
+var _ store.FeedbackOperator = &feedbackOperatorMock{}
+
+func (o *feedbackOperatorMock) Posted(ctx context.Context, since time.Time) ([]*store.PostedComments, error) {
+	o.since = since
+	return o.posted, nil
+}
+
+func (o *feedbackOperatorMock) Save(ctx context.Context, f *models.CommentFeedback) error {
+	o.saved = append(o.saved, f)
+	return nil
+}
+
+func (o *feedbackOperatorMock) Acceptance(ctx context.Context, org, analyzer string) ([]*store.Acceptance, error) {
+	return nil, nil
+}
+
+func storedComment(file string, line int32, text string) *models.Comment {
+	return models.NewComment(nil, &lookout.Comment{File: file, Line: line, Text: text})
+}
+
+func (s *FeedbackTestSuite) TestCollect() {
+	s.mux.HandleFunc("/repos/foo/bar/pulls/42/comments", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]*github.PullRequestComment{{
+			ID:        int64ptr(1),
+			Path:      strptr("main.go"),
+			Body:      strptr("unused variable\n\n<sub>posted by lookout</sub>"),
+			Reactions: &github.Reactions{PlusOne: intptr(2), Confused: intptr(1)},
+		}, {
+			ID:        int64ptr(2),
+			Path:      strptr("main.go"),
+			Body:      strptr("unused variable"),
+			Reactions: &github.Reactions{MinusOne: intptr(1)},
+		}, {
+			ID:        int64ptr(3),
+			Path:      strptr("main.go"),
+			Body:      strptr("it is used in the tests"),
+			InReplyTo: int64ptr(2),
+		}, {
+			ID:   int64ptr(4),
+			Path: strptr("README.md"),
+			Body: strptr("a comment by a user"),
+		}})
+	})
+
+	first := storedComment("main.go", 3, "unused variable")
+	second := storedComment("main.go", 10, "unused variable")
+	notPosted := storedComment("main.go", 20, "missing comment")
+	other := storedComment("other.go", 1, "unused variable")
+
+	op := &feedbackOperatorMock{posted: []*store.PostedComments{{
+		Provider:   Provider,
+		Repository: mustRepositoryInfo("github.com/foo/bar"),
+		Number:     42,
+		Comments:   []*models.Comment{first, second, notPosted, other},
+	}, {
+		Provider:   Provider,
+		Repository: mustRepositoryInfo("github.com/foo/unknown"),
+		Number:     1,
+		Comments:   []*models.Comment{storedComment("main.go", 1, "unused variable")},
+	}}}
+
+	now := time.Date(2019, 1, 17, 0, 0, 0, 0, time.UTC)
+	f := NewFeedbackCollector(s.pool, op, time.Hour, 24*time.Hour)
+	f.now = func() time.Time { return now }
+
+	s.NoError(f.Collect(context.Background()))
+	s.Equal(now.Add(-24*time.Hour), op.since)
+
+	s.Require().Len(op.saved, 2)
+
+	s.Equal(first, op.saved[0].Comment)
+	s.Equal("1", op.saved[0].InternalID)
+	s.Equal(2, op.saved[0].ThumbsUp)
+	s.Equal(0, op.saved[0].ThumbsDown)
+	s.Equal(1, op.saved[0].Confused)
+	s.Equal(0, op.saved[0].Replies)
+
+	s.Equal(second, op.saved[1].Comment)
+	s.Equal("2", op.saved[1].InternalID)
+	s.Equal(0, op.saved[1].ThumbsUp)
+	s.Equal(1, op.saved[1].ThumbsDown)
+	s.Equal(0, op.saved[1].Confused)
+	s.Equal(1, op.saved[1].Replies)
+}
+
+func (s *FeedbackTestSuite) TestCollectAPIError() {
+	s.mux.HandleFunc("/repos/foo/bar/pulls/42/comments", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	op := &feedbackOperatorMock{posted: []*store.PostedComments{{
+		Provider:   Provider,
+		Repository: mustRepositoryInfo("github.com/foo/bar"),
+		Number:     42,
+		Comments:   []*models.Comment{storedComment("main.go", 3, "unused variable")},
+	}}}
+
+	f := NewFeedbackCollector(s.pool, op, time.Hour, 24*time.Hour)
+	s.NoError(f.Collect(context.Background()))
+	s.Len(op.saved, 0)
+}
+
+func mustRepositoryInfo(input string) *lookout.RepositoryInfo {
+	r, err := pb.ParseRepositoryInfo(input)
+	if err != nil {
+		panic(err)
+	}
+
+	return r
+}
+
+func TestFeedbackTestSuite(t *testing.T) {
+	suite.Run(t, new(FeedbackTestSuite))
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"sync"
 	"text/template"
 
 	"github.com/src-d/lookout"
@@ -21,9 +22,12 @@ var (
 	// ErrEventNotSupported signals that this provider does not support the
 	// given event for a given operation.
 	ErrEventNotSupported = errors.NewKind("event not supported")
-	// errNoComments signals that the PullRequestReviewRequest was not created
+	// errNoComments signals that the review request was not created
 	// because it would not contain any comments
 	errNoComments = errors.NewKind("no comments to post")
+	// ErrParseStatusTargetURL signals an error parsing the status target URL
+	// template
+	ErrParseStatusTargetURL = errors.NewKind("error parsing status target url template: %s")
 )
 
 const (
@@ -33,34 +37,78 @@ const (
 
 // Poster posts comments as Pull Request Reviews.
 type Poster struct {
-	pool           *ClientPool
-	conf           ProviderConfig
-	footerTemplate *template.Template
+	pool *ClientPool
+
+	// mu guards the configuration, that can be reloaded
+	mu                sync.RWMutex
+	conf              ProviderConfig
+	footerTemplate    *template.Template
+	targetURLTemplate *template.Template
 }
 
 var _ lookout.Poster = &Poster{}
 
 // NewPoster creates a new poster for the GitHub API.
 func NewPoster(pool *ClientPool, conf ProviderConfig) (*Poster, error) {
+	p := &Poster{pool: pool}
+	if err := p.Reload(conf); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Reload replaces the configuration of the poster, the comment footer and the
+// status target URL templates, and the push result destinations. If the
+// configuration is not valid an error is returned, and the previous
+// configuration is kept.
+func (p *Poster) Reload(conf ProviderConfig) error {
 	tpl, err := newFooterTemplate(conf.CommentFooter)
 	if ErrEmptyTemplate.Is(err) {
 		log.DefaultLogger.Warningf("no footer template being used: %s", err)
 	} else if err != nil {
-		return nil, err
+		return err
 	}
 
-	return &Poster{
-		pool:           pool,
-		conf:           conf,
-		footerTemplate: tpl,
-	}, nil
+	if err := validatePushResults(conf.PushResults); err != nil {
+		return err
+	}
+
+	var targetURLTpl *template.Template
+	if conf.StatusTargetURL != "" {
+		targetURLTpl, err = template.New("status-target-url").Parse(conf.StatusTargetURL)
+		if err != nil {
+			return ErrParseStatusTargetURL.New(err)
+		}
+
+		targetURLTpl = targetURLTpl.Option("missingkey=error")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.conf = conf
+	p.footerTemplate = tpl
+	p.targetURLTemplate = targetURLTpl
+
+	return nil
+}
+
+func (p *Poster) templates() (footer, targetURL *template.Template) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.footerTemplate, p.targetURLTemplate
 }
 
-// Post posts comments as a Pull Request Review.
-// If the event is not a GitHub Pull Request, ErrEventNotSupported is returned.
+// Post posts comments as a Pull Request Review, the review event type is
+// defined by the given action. The comments of a push are posted to the
+// configured push result destinations.
+// If the event is not a GitHub Pull Request or push, ErrEventNotSupported is
+// returned.
 // If a GitHub API request fails, ErrGitHubAPI is returned.
 func (p *Poster) Post(ctx context.Context, e lookout.Event,
-	aCommentsList []lookout.AnalyzerComments, safe bool) error {
+	aCommentsList []lookout.AnalyzerComments, safe bool, action lookout.ReviewAction) error {
 	switch ev := e.(type) {
 	case *lookout.ReviewEvent:
 		if ev.Provider != Provider {
@@ -68,19 +116,23 @@ func (p *Poster) Post(ctx context.Context, e lookout.Event,
 				fmt.Errorf("unsupported provider: %s", ev.Provider))
 		}
 
-		return p.postPR(ctx, ev, aCommentsList, safe)
+		return p.postPR(ctx, ev, aCommentsList, safe, action)
 	case *lookout.PushEvent:
-		// Currently we don't post push comments anywhere
-		return nil
+		if ev.Provider != Provider {
+			return ErrEventNotSupported.Wrap(
+				fmt.Errorf("unsupported provider: %s", ev.Provider))
+		}
+
+		return p.postPush(ctx, ev, aCommentsList)
 	default:
 		return ErrEventNotSupported.Wrap(fmt.Errorf("unsupported event type %s", reflect.TypeOf(e)))
 	}
 }
 
 func (p *Poster) postPR(ctx context.Context, e *lookout.ReviewEvent,
-	aCommentsList []lookout.AnalyzerComments, safe bool) error {
+	aCommentsList []lookout.AnalyzerComments, safe bool, action lookout.ReviewAction) error {
 
-	owner, repo, pr, err := p.validatePR(e)
+	owner, repo, pr, err := validatePR(e)
 	if err != nil {
 		return err
 	}
@@ -100,7 +152,7 @@ func (p *Poster) postPR(ctx context.Context, e *lookout.ReviewEvent,
 	}
 
 	// get list of already posted comments from GH in safe mode
-	var postedComments []*github.PullRequestComment
+	var postedComments []*postedComment
 	if safe {
 		postedComments, err = getPostedComment(ctx, client, owner, repo, pr)
 		if err != nil {
@@ -109,7 +161,7 @@ func (p *Poster) postPR(ctx context.Context, e *lookout.ReviewEvent,
 	}
 
 	dl := newDiffLines(cc)
-	review, err := p.createReviewRequest(ctx, aCommentsList, dl, e.Head.Hash, postedComments)
+	review, err := p.createReviewRequest(ctx, aCommentsList, dl, e.Head.Hash, postedComments, action)
 	if errNoComments.Is(err) {
 		ctxlog.Get(ctx).Infof("skipping posting analysis, there are no comments")
 		return nil
@@ -121,7 +173,7 @@ func (p *Poster) postPR(ctx context.Context, e *lookout.ReviewEvent,
 	return createReview(ctx, client, owner, repo, pr, review)
 }
 
-func (p *Poster) validatePR(
+func validatePR(
 	e *lookout.ReviewEvent) (owner, repo string, pr int, err error) {
 
 	base := e.Base
@@ -152,6 +204,18 @@ var (
 	commentEvent        = "COMMENT"
 )
 
+// reviewEvent returns the github review event type for the action
+func reviewEvent(action lookout.ReviewAction) *string {
+	switch action {
+	case lookout.ApproveReviewAction:
+		return &approveEvent
+	case lookout.RequestChangesReviewAction:
+		return &requestChangesEvent
+	default:
+		return &commentEvent
+	}
+}
+
 // createReviewRequest creates new github pull request review request
 // postedComments is optional field
 func (p *Poster) createReviewRequest(
@@ -159,20 +223,29 @@ func (p *Poster) createReviewRequest(
 	aCommentsList []lookout.AnalyzerComments,
 	dl *diffLines,
 	commitID string,
-	postedComments []*github.PullRequestComment,
-) (*github.PullRequestReviewRequest, error) {
-	req := &github.PullRequestReviewRequest{
+	postedComments []*postedComment,
+	action lookout.ReviewAction,
+) (*reviewRequest, error) {
+	req := &reviewRequest{
 		CommitID: &commitID,
-		Event:    &commentEvent,
+		Event:    reviewEvent(action),
 	}
 
+	footerTemplate, _ := p.templates()
+
 	var bodyComments []string
 	for _, aComments := range aCommentsList {
 		ctx, _ := ctxlog.WithLogFields(ctx, log.Fields{
 			"analyzer": aComments.Config.Name,
 		})
 
-		forBody, ghComments := convertComments(ctx, aComments.Comments, dl)
+		forBody, ghComments, outOfDiff := convertComments(ctx, aComments.Comments, dl)
+		outOfDiffBody, outOfDiffComments := convertOutOfDiff(ctx, outOfDiff, dl, aComments.Config.OutOfDiff)
+		forBody = append(forBody, outOfDiffBody...)
+		ghComments = append(ghComments, outOfDiffComments...)
+		if aComments.Partial {
+			forBody = append([]string{partialNote(aComments.Config.Name)}, forBody...)
+		}
 
 		if len(postedComments) > 0 {
 			ghComments = filterPostedComments(ghComments, postedComments)
@@ -181,13 +254,13 @@ func (p *Poster) createReviewRequest(
 		ghComments = mergeComments(ghComments)
 
 		for i, c := range ghComments {
-			body := addFootnote(ctx, c.GetBody(), p.footerTemplate, &aComments.Config)
+			body := addFootnote(ctx, c.GetBody(), footerTemplate, &aComments.Config)
 			ghComments[i].Body = &body
 		}
 
 		bodyComments = append(
 			bodyComments,
-			addFootnote(ctx, strings.Join(forBody, "\n\n"), p.footerTemplate, &aComments.Config),
+			addFootnote(ctx, strings.Join(forBody, "\n\n"), footerTemplate, &aComments.Config),
 		)
 		req.Comments = append(req.Comments, ghComments...)
 	}
@@ -195,14 +268,23 @@ func (p *Poster) createReviewRequest(
 	body := strings.Join(bodyComments, "\n\n")
 	req.Body = &body
 
-	if *req.Body == "" && len(req.Comments) == 0 {
+	// an approval is posted even without comments
+	if *req.Body == "" && len(req.Comments) == 0 && action != lookout.ApproveReviewAction {
 		return nil, errNoComments.New()
 	}
 
 	return req, nil
 }
 
-// Status sets the Pull Request global status, visible from the GitHub UI
+// partialNote returns the text added to the review body for the analyzers
+// with partial results
+func partialNote(analyzer string) string {
+	return fmt.Sprintf("_The analyzer %s timed out, its comments are partial results._", analyzer)
+}
+
+// Status sets the Pull Request global status, visible from the GitHub UI.
+// For a push, the status of its head commit is set if the commit_status push
+// result destination is configured.
 // If a GitHub API request fails, ErrGitHubAPI is returned.
 func (p *Poster) Status(ctx context.Context, e lookout.Event, status lookout.AnalysisStatus) error {
 	switch ev := e.(type) {
@@ -214,8 +296,36 @@ func (p *Poster) Status(ctx context.Context, e lookout.Event, status lookout.Ana
 
 		return p.statusPR(ctx, ev, status)
 	case *lookout.PushEvent:
-		// Currently we don't post push comments anywhere
-		return nil
+		if ev.Provider != Provider {
+			return ErrEventNotSupported.Wrap(
+				fmt.Errorf("unsupported provider: %s", ev.Provider))
+		}
+
+		return p.statusPush(ctx, ev, status)
+	default:
+		return ErrEventNotSupported.Wrap(fmt.Errorf("unsupported event type %s", reflect.TypeOf(e)))
+	}
+}
+
+// AnalyzerStatus sets the Pull Request status of a single analyzer, using
+// its own context: lookout/<analyzer name>.
+// If a GitHub API request fails, ErrGitHubAPI is returned.
+func (p *Poster) AnalyzerStatus(ctx context.Context, e lookout.Event, st lookout.AnalyzerStatus) error {
+	switch ev := e.(type) {
+	case *lookout.ReviewEvent:
+		if ev.Provider != Provider {
+			return ErrEventNotSupported.Wrap(
+				fmt.Errorf("unsupported provider: %s", ev.Provider))
+		}
+
+		return p.analyzerStatusPR(ctx, ev, st)
+	case *lookout.PushEvent:
+		if ev.Provider != Provider {
+			return ErrEventNotSupported.Wrap(
+				fmt.Errorf("unsupported provider: %s", ev.Provider))
+		}
+
+		return p.analyzerStatusPush(ctx, ev, st)
 	default:
 		return ErrEventNotSupported.Wrap(fmt.Errorf("unsupported event type %s", reflect.TypeOf(e)))
 	}
@@ -248,17 +358,73 @@ func statusStrings(s lookout.AnalysisStatus) (string, string, error) {
 }
 
 func (p *Poster) statusPR(ctx context.Context, e *lookout.ReviewEvent, status lookout.AnalysisStatus) error {
-	owner, repo, _, err := p.validatePR(e)
+	data, err := p.prStatusTarget(e)
 	if err != nil {
 		return err
 	}
 
+	return p.status(ctx, data, status)
+}
+
+func (p *Poster) analyzerStatusPR(ctx context.Context, e *lookout.ReviewEvent, st lookout.AnalyzerStatus) error {
+	data, err := p.prStatusTarget(e)
+	if err != nil {
+		return err
+	}
+
+	return p.analyzerStatus(ctx, data, st)
+}
+
+func (p *Poster) prStatusTarget(e *lookout.ReviewEvent) (statusTargetData, error) {
+	owner, repo, pr, err := validatePR(e)
+	if err != nil {
+		return statusTargetData{}, err
+	}
+
+	return statusTargetData{
+		Owner:       owner,
+		Repository:  repo,
+		PullRequest: pr,
+		Head:        e.CommitRevision.Head.Hash,
+	}, nil
+}
+
+func (p *Poster) status(ctx context.Context, data statusTargetData, status lookout.AnalysisStatus) error {
 	statusStr, description, err := statusStrings(status)
 	if err != nil {
 		return err
 	}
-	targetURL := statusTargetURL
+
+	return p.createStatus(ctx, data, statusStr, description)
+}
+
+func (p *Poster) analyzerStatus(ctx context.Context, data statusTargetData, st lookout.AnalyzerStatus) error {
+	statusStr, description, err := statusStrings(st.Status)
+	if err != nil {
+		return err
+	}
+
+	if st.Description != "" {
+		description = st.Description
+	}
+
+	data.Analyzer = st.Analyzer
+	return p.createStatus(ctx, data, statusStr, description)
+}
+
+// createStatus creates the commit status of data.Head. An empty analyzer
+// name means the global status.
+func (p *Poster) createStatus(
+	ctx context.Context,
+	data statusTargetData,
+	statusStr, description string,
+) error {
 	context := statusContext
+	if data.Analyzer != "" {
+		context = statusContext + "/" + data.Analyzer
+	}
+
+	targetURL := p.targetURL(ctx, data)
 
 	repoStatus := &github.RepoStatus{
 		State:       &statusStr,
@@ -267,12 +433,12 @@ func (p *Poster) statusPR(ctx context.Context, e *lookout.ReviewEvent, status lo
 		Context:     &context,
 	}
 
-	client, err := p.getClient(owner, repo)
+	client, err := p.getClient(data.Owner, data.Repository)
 	if err != nil {
 		return err
 	}
 
-	_, _, err = client.Repositories.CreateStatus(ctx, owner, repo, e.CommitRevision.Head.Hash, repoStatus)
+	_, _, err = client.Repositories.CreateStatus(ctx, data.Owner, data.Repository, data.Head, repoStatus)
 	if err != nil {
 		return ErrGitHubAPI.Wrap(err, "commit status could not be pushed")
 	}
@@ -280,6 +446,35 @@ func (p *Poster) statusPR(ctx context.Context, e *lookout.ReviewEvent, status lo
 	return nil
 }
 
+// statusTargetData is the data available to the status target URL template
+type statusTargetData struct {
+	// Analyzer is the analyzer name, empty for the global status
+	Analyzer   string
+	Owner      string
+	Repository string
+	// PullRequest is the pull request number, zero for a push
+	PullRequest int
+	// Head is the hash of the analyzed commit
+	Head string
+}
+
+// targetURL returns the status target URL from the configured template, or
+// the default one if there is no template or it fails
+func (p *Poster) targetURL(ctx context.Context, data statusTargetData) string {
+	_, tpl := p.templates()
+	if tpl == nil {
+		return statusTargetURL
+	}
+
+	var url strings.Builder
+	if err := tpl.Execute(&url, data); err != nil {
+		ctxlog.Get(ctx).Warningf("status target url could not be generated: %s", err)
+		return statusTargetURL
+	}
+
+	return url.String()
+}
+
 func (p *Poster) getClient(username, repository string) (*Client, error) {
 	client, ok := p.pool.Client(username, repository)
 	if !ok {
@@ -151,15 +151,15 @@ func (s *PosterTestSuite) TestPostOK() {
 		body, err := ioutil.ReadAll(r.Body)
 		s.NoError(err)
 
-		expected, _ := json.Marshal(&github.PullRequestReviewRequest{
+		expected, _ := json.Marshal(&reviewRequest{
 			CommitID: &mockEvent.Head.Hash,
 			Body:     strptr("Global comment\n\nAnother global comment"),
 			Event:    strptr(commentEvent),
-			Comments: []*github.DraftReviewComment{&github.DraftReviewComment{
+			Comments: []*draftReviewComment{&draftReviewComment{
 				Path:     strptr("main.go"),
 				Body:     strptr("File comment"),
 				Position: intptr(1),
-			}, &github.DraftReviewComment{
+			}, &draftReviewComment{
 				Path:     strptr("main.go"),
 				Position: intptr(3),
 				Body:     strptr("Line comment"),
@@ -171,12 +171,74 @@ func (s *PosterTestSuite) TestPostOK() {
 	})
 
 	p := &Poster{pool: s.pool}
-	err := p.Post(context.Background(), mockEvent, mockAnalyzerComments, false)
+	err := p.Post(context.Background(), mockEvent, mockAnalyzerComments, false, lookout.CommentReviewAction)
 	s.NoError(err)
 
 	s.True(createReviewsCalled)
 }
 
+func (s *PosterTestSuite) TestPostFileOutOfDiff() {
+	compareCalled := false
+	s.compareHandle(&compareCalled)
+
+	createReviewsCalled := false
+	s.mux.HandleFunc("/repos/foo/bar/pulls/42/reviews", func(w http.ResponseWriter, r *http.Request) {
+		s.False(createReviewsCalled)
+		createReviewsCalled = true
+
+		body, err := ioutil.ReadAll(r.Body)
+		s.NoError(err)
+
+		// the file-level comments are not part of the review
+		expected, _ := json.Marshal(&reviewRequest{
+			CommitID: &mockEvent.Head.Hash,
+			Body:     strptr(""),
+			Event:    strptr(commentEvent),
+			Comments: []*draftReviewComment{&draftReviewComment{
+				Path:     strptr("main.go"),
+				Position: intptr(3),
+				Body:     strptr("Line comment"),
+			}}})
+		s.JSONEq(string(expected), string(body))
+
+		json.NewEncoder(w).Encode(&github.PullRequestReview{ID: int64ptr(1)})
+	})
+
+	createCommentCalled := false
+	s.mux.HandleFunc("/repos/foo/bar/pulls/42/comments", func(w http.ResponseWriter, r *http.Request) {
+		s.Equal(http.MethodPost, r.Method)
+		s.False(createCommentCalled)
+		createCommentCalled = true
+
+		body, err := ioutil.ReadAll(r.Body)
+		s.NoError(err)
+
+		expected, _ := json.Marshal(&fileCommentRequest{
+			CommitID:    &mockEvent.Head.Hash,
+			Path:        strptr("main.go"),
+			Body:        strptr("Unused function\n\n_Line 20_"),
+			SubjectType: strptr("file"),
+		})
+		s.JSONEq(string(expected), string(body))
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(&github.PullRequestComment{ID: int64ptr(2)})
+	})
+
+	p := &Poster{pool: s.pool}
+	err := p.Post(context.Background(), mockEvent, []lookout.AnalyzerComments{{
+		Config: lookout.AnalyzerConfig{Name: "mock", OutOfDiff: lookout.FileOutOfDiff},
+		Comments: []*lookout.Comment{
+			{File: "main.go", Line: 5, Text: "Line comment"},
+			{File: "main.go", Line: 20, Text: "Unused function"},
+		},
+	}}, false, lookout.CommentReviewAction)
+	s.NoError(err)
+
+	s.True(createReviewsCalled)
+	s.True(createCommentCalled)
+}
+
 func (s *PosterTestSuite) TestPostFooter() {
 	compareCalled := false
 	s.compareHandle(&compareCalled)
@@ -189,15 +251,15 @@ func (s *PosterTestSuite) TestPostFooter() {
 		body, err := ioutil.ReadAll(r.Body)
 		s.NoError(err)
 
-		expected, _ := json.Marshal(&github.PullRequestReviewRequest{
+		expected, _ := json.Marshal(&reviewRequest{
 			CommitID: &mockEvent.Head.Hash,
 			Body:     strptr("Global comment\n\nAnother global comment" + footnoteSeparator + "To post feedback go to https://foo.bar/feedback"),
 			Event:    strptr(commentEvent),
-			Comments: []*github.DraftReviewComment{&github.DraftReviewComment{
+			Comments: []*draftReviewComment{&draftReviewComment{
 				Path:     strptr("main.go"),
 				Body:     strptr("File comment" + footnoteSeparator + "To post feedback go to https://foo.bar/feedback"),
 				Position: intptr(1),
-			}, &github.DraftReviewComment{
+			}, &draftReviewComment{
 				Path:     strptr("main.go"),
 				Position: intptr(3),
 				Body:     strptr("Line comment" + footnoteSeparator + "To post feedback go to https://foo.bar/feedback"),
@@ -216,7 +278,55 @@ func (s *PosterTestSuite) TestPostFooter() {
 		pool:           s.pool,
 		footerTemplate: footerTpl,
 	}
-	err := p.Post(context.Background(), mockEvent, aComments, false)
+	err := p.Post(context.Background(), mockEvent, aComments, false, lookout.CommentReviewAction)
+	s.NoError(err)
+
+	s.True(createReviewsCalled)
+}
+
+func (s *PosterTestSuite) TestPostRange() {
+	compareCalled := false
+	s.compareHandle(&compareCalled)
+
+	createReviewsCalled := false
+	s.mux.HandleFunc("/repos/foo/bar/pulls/42/reviews", func(w http.ResponseWriter, r *http.Request) {
+		s.False(createReviewsCalled)
+		createReviewsCalled = true
+
+		body, err := ioutil.ReadAll(r.Body)
+		s.NoError(err)
+
+		s.JSONEq(`{
+			"commit_id": "`+mockEvent.Head.Hash+`",
+			"body": "",
+			"event": "COMMENT",
+			"comments": [{
+				"path": "main.go",
+				"body": "Range comment",
+				"start_line": 4,
+				"line": 6,
+				"start_side": "RIGHT",
+				"side": "RIGHT"
+			}, {
+				"path": "main.go",
+				"body": "Range across the end of the diff",
+				"position": 9
+			}]
+		}`, string(body))
+
+		resp := &github.Response{Response: &http.Response{StatusCode: 200}}
+		json.NewEncoder(w).Encode(resp)
+	})
+
+	p := &Poster{pool: s.pool}
+	err := p.Post(context.Background(), mockEvent, []lookout.AnalyzerComments{
+		{
+			Comments: []*lookout.Comment{
+				{File: "main.go", Line: 4, EndLine: 6, Text: "Range comment"},
+				{File: "main.go", Line: 11, EndLine: 14, Text: "Range across the end of the diff"},
+			},
+		},
+	}, false, lookout.CommentReviewAction)
 	s.NoError(err)
 
 	s.True(createReviewsCalled)
@@ -225,7 +335,7 @@ func (s *PosterTestSuite) TestPostFooter() {
 func (s *PosterTestSuite) TestPostBadProvider() {
 	p := &Poster{pool: s.pool}
 
-	err := p.Post(context.Background(), badProviderEvent, mockAnalyzerComments, false)
+	err := p.Post(context.Background(), badProviderEvent, mockAnalyzerComments, false, lookout.CommentReviewAction)
 	s.True(ErrEventNotSupported.Is(err))
 	s.Equal("event not supported: unsupported provider: badprovider", err.Error())
 }
@@ -233,7 +343,7 @@ func (s *PosterTestSuite) TestPostBadProvider() {
 func (s *PosterTestSuite) TestPostBadReferenceNoRepository() {
 	p := &Poster{pool: s.pool}
 
-	err := p.Post(context.Background(), noRepoEvent, mockAnalyzerComments, false)
+	err := p.Post(context.Background(), noRepoEvent, mockAnalyzerComments, false, lookout.CommentReviewAction)
 	s.True(ErrEventNotSupported.Is(err))
 	s.Equal("event not supported: nil repository", err.Error())
 }
@@ -241,7 +351,7 @@ func (s *PosterTestSuite) TestPostBadReferenceNoRepository() {
 func (s *PosterTestSuite) TestPostBadReference() {
 	p := &Poster{pool: s.pool}
 
-	err := p.Post(context.Background(), badReferenceEvent, mockAnalyzerComments, false)
+	err := p.Post(context.Background(), badReferenceEvent, mockAnalyzerComments, false, lookout.CommentReviewAction)
 	s.True(ErrEventNotSupported.Is(err))
 	s.Equal("event not supported: bad PR: BAD", err.Error())
 }
@@ -255,7 +365,7 @@ func (s *PosterTestSuite) TestPostHttpError() {
 	})
 
 	p := &Poster{pool: s.pool}
-	err := p.Post(context.Background(), mockEvent, mockAnalyzerComments, false)
+	err := p.Post(context.Background(), mockEvent, mockAnalyzerComments, false, lookout.CommentReviewAction)
 	s.IsType(ErrGitHubAPI.New(), err)
 }
 
@@ -273,7 +383,7 @@ func (s *PosterTestSuite) TestPostHttpTimeout() {
 	defer cancel()
 
 	p := &Poster{pool: s.pool}
-	err := p.Post(ctx, mockEvent, mockAnalyzerComments, false)
+	err := p.Post(ctx, mockEvent, mockAnalyzerComments, false, lookout.CommentReviewAction)
 	s.IsType(ErrGitHubAPI.New(), err)
 }
 
@@ -286,7 +396,7 @@ func (s *PosterTestSuite) TestPostHttpJSONErr() {
 	})
 
 	p := &Poster{pool: s.pool}
-	err := p.Post(context.Background(), mockEvent, mockAnalyzerComments, false)
+	err := p.Post(context.Background(), mockEvent, mockAnalyzerComments, false, lookout.CommentReviewAction)
 	s.IsType(ErrGitHubAPI.New(), err)
 }
 
@@ -301,12 +411,62 @@ func (s *PosterTestSuite) TestPostNoComments() {
 	})
 
 	p := &Poster{pool: s.pool}
-	err := p.Post(context.Background(), mockEvent, []lookout.AnalyzerComments{}, false)
+	err := p.Post(context.Background(), mockEvent, []lookout.AnalyzerComments{}, false, lookout.CommentReviewAction)
 	s.NoError(err)
 
 	s.False(createReviewsCalled)
 }
 
+func (s *PosterTestSuite) TestPostRequestChanges() {
+	compareCalled := false
+	s.compareHandle(&compareCalled)
+
+	createReviewsCalled := false
+	s.mux.HandleFunc("/repos/foo/bar/pulls/42/reviews", func(w http.ResponseWriter, r *http.Request) {
+		s.False(createReviewsCalled)
+		createReviewsCalled = true
+
+		var req reviewRequest
+		s.NoError(json.NewDecoder(r.Body).Decode(&req))
+		s.Equal(requestChangesEvent, req.GetEvent())
+		s.Len(req.Comments, 2)
+
+		resp := &github.Response{Response: &http.Response{StatusCode: 200}}
+		json.NewEncoder(w).Encode(resp)
+	})
+
+	p := &Poster{pool: s.pool}
+	err := p.Post(context.Background(), mockEvent, mockAnalyzerComments, false, lookout.RequestChangesReviewAction)
+	s.NoError(err)
+
+	s.True(createReviewsCalled)
+}
+
+func (s *PosterTestSuite) TestPostApproveNoComments() {
+	compareCalled := false
+	s.compareHandle(&compareCalled)
+
+	createReviewsCalled := false
+	s.mux.HandleFunc("/repos/foo/bar/pulls/42/reviews", func(w http.ResponseWriter, r *http.Request) {
+		s.False(createReviewsCalled)
+		createReviewsCalled = true
+
+		var req reviewRequest
+		s.NoError(json.NewDecoder(r.Body).Decode(&req))
+		s.Equal(approveEvent, req.GetEvent())
+		s.Len(req.Comments, 0)
+
+		resp := &github.Response{Response: &http.Response{StatusCode: 200}}
+		json.NewEncoder(w).Encode(resp)
+	})
+
+	p := &Poster{pool: s.pool}
+	err := p.Post(context.Background(), mockEvent, []lookout.AnalyzerComments{}, false, lookout.ApproveReviewAction)
+	s.NoError(err)
+
+	s.True(createReviewsCalled)
+}
+
 func (s *PosterTestSuite) TestPostOnlyBodyComments() {
 	compareCalled := false
 	s.compareHandle(&compareCalled)
@@ -324,7 +484,7 @@ func (s *PosterTestSuite) TestPostOnlyBodyComments() {
 				{Text: "body comment"},
 			},
 		},
-	}, false)
+	}, false, lookout.CommentReviewAction)
 	s.NoError(err)
 
 	s.True(createReviewsCalled)
@@ -398,11 +558,11 @@ func (s *PosterTestSuite) TestPostSafeOK() {
 			body, err := ioutil.ReadAll(r.Body)
 			s.NoError(err)
 
-			expected, _ := json.Marshal(&github.PullRequestReviewRequest{
+			expected, _ := json.Marshal(&reviewRequest{
 				CommitID: &mockEvent.Head.Hash,
 				Body:     strptr("Global comment"),
 				Event:    strptr(commentEvent),
-				Comments: []*github.DraftReviewComment{
+				Comments: []*draftReviewComment{
 					{
 						Path:     strptr("main.go"),
 						Position: intptr(3),
@@ -417,7 +577,7 @@ func (s *PosterTestSuite) TestPostSafeOK() {
 	})
 
 	p := &Poster{pool: s.pool}
-	err := p.Post(context.Background(), mockEvent, inputAnalyzerComments, true)
+	err := p.Post(context.Background(), mockEvent, inputAnalyzerComments, true, lookout.CommentReviewAction)
 	s.NoError(err)
 
 	s.True(createReviewsCalled)
@@ -459,6 +619,42 @@ func (s *PosterTestSuite) TestStatusOK() {
 	s.True(createStatusCalled)
 }
 
+func (s *PosterTestSuite) TestAnalyzerStatusOK() {
+	createStatusCalled := false
+
+	s.mux.HandleFunc("/repos/foo/bar/statuses/02801e1a27a0a906d59530aeb81f4cd137f2c717", func(w http.ResponseWriter, r *http.Request) {
+		s.False(createStatusCalled)
+		createStatusCalled = true
+
+		body, err := ioutil.ReadAll(r.Body)
+		s.NoError(err)
+
+		expected, _ := json.Marshal(&github.RepoStatus{
+			State:       strptr("failure"),
+			TargetURL:   strptr("https://lookout.example.com/foo/bar/42/style"),
+			Description: strptr("The analysis produced 2 comments"),
+			Context:     strptr("lookout/style"),
+		})
+		s.JSONEq(string(expected), string(body))
+
+		json.NewEncoder(w).Encode(&github.RepoStatus{ID: int64ptr(1234)})
+	})
+
+	p, err := NewPoster(s.pool, ProviderConfig{
+		StatusTargetURL: "https://lookout.example.com/{{.Owner}}/{{.Repository}}/{{.PullRequest}}/{{.Analyzer}}",
+	})
+	s.NoError(err)
+
+	err = p.AnalyzerStatus(context.Background(), mockEvent, lookout.AnalyzerStatus{
+		Analyzer:    "style",
+		Status:      lookout.FailureAnalysisStatus,
+		Description: "The analysis produced 2 comments",
+	})
+	s.NoError(err)
+
+	s.True(createStatusCalled)
+}
+
 func (s *PosterTestSuite) TestStatusBadProvider() {
 	p := &Poster{pool: s.pool}
 	err := p.Status(context.Background(), badProviderEvent, lookout.PendingAnalysisStatus)
@@ -550,3 +746,43 @@ func (s *PosterTestSuite) TestCouldNotParseFooterTemplate() {
 	s.Nil(posterWithWrongTemplate, "NewPoster must fail when parsing a wrong template config")
 	s.True(ErrParseTemplate.Is(err), "Error should be 'ErrParseTemplate'")
 }
+
+func (s *PosterTestSuite) TestCouldNotParseStatusTargetURLTemplate() {
+	p, err := NewPoster(nil, ProviderConfig{StatusTargetURL: "https://example.com/{{{parseerror"})
+	s.Nil(p)
+	s.True(ErrParseStatusTargetURL.Is(err))
+
+	// a template failing on execution uses the default url
+	p, err = NewPoster(nil, ProviderConfig{StatusTargetURL: "https://example.com/{{.Unknown}}"})
+	s.NoError(err)
+	s.Equal(statusTargetURL, p.targetURL(context.TODO(), statusTargetData{}))
+}
+
+func (s *PosterTestSuite) TestReload() {
+	p, err := NewPoster(nil, ProviderConfig{
+		CommentFooter:   "old footer {{.Name}}",
+		StatusTargetURL: "https://example.com/old",
+	})
+	s.NoError(err)
+
+	conf := &lookout.AnalyzerConfig{Name: "mock"}
+	footer, _ := p.templates()
+	s.Equal("comment"+footnoteSeparator+"old footer mock", addFootnote(context.TODO(), "comment", footer, conf))
+
+	s.NoError(p.Reload(ProviderConfig{
+		CommentFooter:   "new footer {{.Name}}",
+		StatusTargetURL: "https://example.com/{{.Analyzer}}",
+	}))
+
+	footer, _ = p.templates()
+	s.Equal("comment"+footnoteSeparator+"new footer mock", addFootnote(context.TODO(), "comment", footer, conf))
+	s.Equal("https://example.com/mock",
+		p.targetURL(context.TODO(), statusTargetData{Analyzer: "mock"}))
+
+	// an invalid configuration keeps the previous one
+	err = p.Reload(ProviderConfig{CommentFooter: "{{{parseerror"})
+	s.True(ErrParseTemplate.Is(err))
+
+	footer, _ = p.templates()
+	s.Equal("comment"+footnoteSeparator+"new footer mock", addFootnote(context.TODO(), "comment", footer, conf))
+}
@@ -0,0 +1,322 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/src-d/lookout"
+	"github.com/src-d/lookout/util/ctxlog"
+
+	"github.com/google/go-github/github"
+	"gopkg.in/src-d/go-errors.v1"
+	log "gopkg.in/src-d/go-log.v1"
+)
+
+// Destinations of the comments of push events
+const (
+	// CommitCommentPushResult posts the comments of each analyzer as a
+	// comment on the head commit of the push
+	CommitCommentPushResult = "commit_comment"
+	// CommitStatusPushResult sets the commit statuses of the head commit of
+	// the push
+	CommitStatusPushResult = "commit_status"
+	// IssuePushResult keeps an issue per branch updated with the comments of
+	// the last push
+	IssuePushResult = "issue"
+)
+
+// trackingIssueLabel is the label of the issues with the comments of the
+// pushes to a branch
+const trackingIssueLabel = "lookout"
+
+// ErrUnknownPushResult signals an unknown push result destination in the
+// configuration
+var ErrUnknownPushResult = errors.NewKind("unknown push result destination: %s")
+
+func validatePushResults(results []string) error {
+	for _, r := range results {
+		switch r {
+		case CommitCommentPushResult, CommitStatusPushResult, IssuePushResult:
+		default:
+			return ErrUnknownPushResult.New(r)
+		}
+	}
+
+	return nil
+}
+
+// pushResult returns true if the push result destination is configured
+func (p *Poster) pushResult(dest string) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for _, r := range p.conf.PushResults {
+		if r == dest {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *Poster) pushRepository(e *lookout.PushEvent) (owner, repo string, err error) {
+	owner, err = extractOwner(e.Head)
+	if err != nil {
+		return "", "", ErrEventNotSupported.Wrap(err)
+	}
+
+	repo, err = extractRepo(e.Head)
+	if err != nil {
+		return "", "", ErrEventNotSupported.Wrap(err)
+	}
+
+	return owner, repo, nil
+}
+
+func (p *Poster) postPush(ctx context.Context, e *lookout.PushEvent,
+	aCommentsList []lookout.AnalyzerComments) error {
+	commitComment := p.pushResult(CommitCommentPushResult)
+	issue := p.pushResult(IssuePushResult)
+	if !commitComment && !issue {
+		return nil
+	}
+
+	owner, repo, err := p.pushRepository(e)
+	if err != nil {
+		return err
+	}
+
+	client, err := p.getClient(owner, repo)
+	if err != nil {
+		return err
+	}
+
+	if commitComment {
+		if err := p.createCommitComments(ctx, client, owner, repo, e, aCommentsList); err != nil {
+			return err
+		}
+	}
+
+	if issue {
+		return p.updateTrackingIssue(ctx, client, owner, repo, e, aCommentsList)
+	}
+
+	return nil
+}
+
+// createCommitComments posts the comments of each analyzer as a comment on
+// the head commit of the push
+func (p *Poster) createCommitComments(
+	ctx context.Context,
+	client *Client,
+	owner, repo string,
+	e *lookout.PushEvent,
+	aCommentsList []lookout.AnalyzerComments,
+) error {
+	footerTemplate, _ := p.templates()
+	for _, aComments := range aCommentsList {
+		ctx, _ := ctxlog.WithLogFields(ctx, log.Fields{
+			"analyzer": aComments.Config.Name,
+		})
+
+		body := addFootnote(ctx, findings(aComments), footerTemplate, &aComments.Config)
+		_, _, err := client.Repositories.CreateComment(ctx, owner, repo, e.Head.Hash,
+			&github.RepositoryComment{Body: &body})
+		if err != nil {
+			return ErrGitHubAPI.Wrap(err, "commit comment could not be posted")
+		}
+	}
+
+	return nil
+}
+
+// updateTrackingIssue creates or updates the issue of the pushed branch with
+// the comments of the push. A closed issue is reopened. If there are no
+// comments the issue is closed, and it is not created if there is none.
+func (p *Poster) updateTrackingIssue(
+	ctx context.Context,
+	client *Client,
+	owner, repo string,
+	e *lookout.PushEvent,
+	aCommentsList []lookout.AnalyzerComments,
+) error {
+	branch := e.Head.ReferenceName.Short()
+	title := trackingIssueTitle(branch)
+
+	if len(aCommentsList) == 0 {
+		return closeTrackingIssue(ctx, client, owner, repo, e, title)
+	}
+
+	footerTemplate, _ := p.templates()
+	parts := []string{fmt.Sprintf("Findings of the analysis of the commit %s:", e.Head.Hash)}
+	for _, aComments := range aCommentsList {
+		ctx, _ := ctxlog.WithLogFields(ctx, log.Fields{
+			"analyzer": aComments.Config.Name,
+		})
+
+		parts = append(parts, fmt.Sprintf("### %s\n\n%s", aComments.Config.Name,
+			addFootnote(ctx, findings(aComments), footerTemplate, &aComments.Config)))
+	}
+
+	body := strings.Join(parts, "\n\n")
+
+	issue, err := findTrackingIssue(ctx, client, owner, repo, title)
+	if err != nil {
+		return err
+	}
+
+	if issue == nil {
+		_, _, err = client.Issues.Create(ctx, owner, repo, &github.IssueRequest{
+			Title:  &title,
+			Body:   &body,
+			Labels: &[]string{trackingIssueLabel},
+		})
+		if err != nil {
+			return ErrGitHubAPI.Wrap(err, "tracking issue could not be created")
+		}
+
+		return nil
+	}
+
+	state := "open"
+	_, _, err = client.Issues.Edit(ctx, owner, repo, issue.GetNumber(), &github.IssueRequest{
+		Body:  &body,
+		State: &state,
+	})
+	if err != nil {
+		return ErrGitHubAPI.Wrap(err, "tracking issue could not be updated")
+	}
+
+	return nil
+}
+
+// closeTrackingIssue replaces the findings of the tracking issue with a note
+// about the push without findings and closes it
+func closeTrackingIssue(
+	ctx context.Context,
+	client *Client,
+	owner, repo string,
+	e *lookout.PushEvent,
+	title string,
+) error {
+	issue, err := findTrackingIssue(ctx, client, owner, repo, title)
+	if err != nil {
+		return err
+	}
+
+	if issue == nil {
+		return nil
+	}
+
+	body := fmt.Sprintf("No findings in the analysis of the commit %s.", e.Head.Hash)
+	state := "closed"
+	_, _, err = client.Issues.Edit(ctx, owner, repo, issue.GetNumber(), &github.IssueRequest{
+		Body:  &body,
+		State: &state,
+	})
+	if err != nil {
+		return ErrGitHubAPI.Wrap(err, "tracking issue could not be closed")
+	}
+
+	return nil
+}
+
+func trackingIssueTitle(branch string) string {
+	return fmt.Sprintf("Lookout findings on branch %s", branch)
+}
+
+// findTrackingIssue returns the issue with the given title and the lookout
+// label, or nil if there is none
+func findTrackingIssue(ctx context.Context, client *Client, owner, repo, title string) (*github.Issue, error) {
+	opts := &github.IssueListByRepoOptions{
+		State:       "all",
+		Labels:      []string{trackingIssueLabel},
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	for {
+		issues, resp, err := client.Issues.ListByRepo(ctx, owner, repo, opts)
+		if err = handleAPIError(resp, err, "issues could not be listed"); err != nil {
+			return nil, err
+		}
+
+		for _, issue := range issues {
+			if issue.GetTitle() == title && !issue.IsPullRequest() {
+				return issue, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			return nil, nil
+		}
+
+		opts.Page = resp.NextPage
+	}
+}
+
+// findings returns the comments of an analyzer as a markdown list, with the
+// file and line they refer to
+func findings(aComments lookout.AnalyzerComments) string {
+	var b strings.Builder
+	if aComments.Partial {
+		b.WriteString(partialNote(aComments.Config.Name))
+		b.WriteString("\n\n")
+	}
+
+	for i, c := range aComments.Comments {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+
+		b.WriteString("- ")
+		switch {
+		case c.File != "" && c.Line > 0:
+			fmt.Fprintf(&b, "`%s:%d`: ", c.File, c.Line)
+		case c.File != "":
+			fmt.Fprintf(&b, "`%s`: ", c.File)
+		}
+
+		// the following lines of the text are kept in the list item
+		b.WriteString(strings.Replace(c.Text, "\n", "\n  ", -1))
+	}
+
+	return b.String()
+}
+
+// statusPush sets the global status of the head commit of the push
+func (p *Poster) statusPush(ctx context.Context, e *lookout.PushEvent, status lookout.AnalysisStatus) error {
+	if !p.pushResult(CommitStatusPushResult) {
+		return nil
+	}
+
+	owner, repo, err := p.pushRepository(e)
+	if err != nil {
+		return err
+	}
+
+	return p.status(ctx, statusTargetData{
+		Owner:      owner,
+		Repository: repo,
+		Head:       e.Head.Hash,
+	}, status)
+}
+
+// analyzerStatusPush sets the status of an analyzer of the head commit of
+// the push
+func (p *Poster) analyzerStatusPush(ctx context.Context, e *lookout.PushEvent, st lookout.AnalyzerStatus) error {
+	if !p.pushResult(CommitStatusPushResult) {
+		return nil
+	}
+
+	owner, repo, err := p.pushRepository(e)
+	if err != nil {
+		return err
+	}
+
+	return p.analyzerStatus(ctx, statusTargetData{
+		Owner:      owner,
+		Repository: repo,
+		Head:       e.Head.Hash,
+	}, st)
+}
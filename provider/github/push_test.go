@@ -0,0 +1,242 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"gopkg.in/src-d/lookout-sdk.v0/pb"
+
+	"github.com/google/go-github/github"
+	"github.com/src-d/lookout"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+)
+
+var mockPushEvent = &lookout.PushEvent{
+	PushEvent: pb.PushEvent{
+		Provider: Provider,
+		CommitRevision: lookout.CommitRevision{
+			Base: lookout.ReferencePointer{
+				InternalRepositoryURL: "https://github.com/foo/bar",
+				ReferenceName:         plumbing.ReferenceName("refs/heads/master"),
+				Hash:                  hash1,
+			},
+			Head: lookout.ReferencePointer{
+				InternalRepositoryURL: "https://github.com/foo/bar",
+				ReferenceName:         plumbing.ReferenceName("refs/heads/master"),
+				Hash:                  hash2,
+			}}}}
+
+var mockPushFindings = "- Global comment\n" +
+	"- `main.go`: File comment\n" +
+	"- `main.go:5`: Line comment\n" +
+	"- Another global comment"
+
+func (s *PosterTestSuite) TestPostPushNoResults() {
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		s.Fail("unexpected request", r.URL.Path)
+	})
+
+	p := &Poster{pool: s.pool}
+	s.NoError(p.Post(context.Background(), mockPushEvent, mockAnalyzerComments,
+		false, lookout.CommentReviewAction))
+	s.NoError(p.Status(context.Background(), mockPushEvent, lookout.SuccessAnalysisStatus))
+}
+
+func (s *PosterTestSuite) TestPostPushCommitComment() {
+	createCommentCalled := false
+	s.mux.HandleFunc("/repos/foo/bar/commits/"+hash2+"/comments", func(w http.ResponseWriter, r *http.Request) {
+		s.False(createCommentCalled)
+		createCommentCalled = true
+
+		body, err := ioutil.ReadAll(r.Body)
+		s.NoError(err)
+
+		expected, _ := json.Marshal(&github.RepositoryComment{
+			Body: strptr(mockPushFindings),
+		})
+		s.JSONEq(string(expected), string(body))
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(&github.RepositoryComment{ID: int64ptr(1)})
+	})
+
+	p, err := NewPoster(s.pool, ProviderConfig{
+		PushResults: []string{CommitCommentPushResult},
+	})
+	s.NoError(err)
+
+	s.NoError(p.Post(context.Background(), mockPushEvent, mockAnalyzerComments,
+		false, lookout.CommentReviewAction))
+	s.True(createCommentCalled)
+}
+
+func (s *PosterTestSuite) TestPostPushIssueCreate() {
+	createIssueCalled := false
+	s.mux.HandleFunc("/repos/foo/bar/issues", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			s.Equal("lookout", r.URL.Query().Get("labels"))
+			s.Equal("all", r.URL.Query().Get("state"))
+			json.NewEncoder(w).Encode([]*github.Issue{
+				{Number: intptr(1), Title: strptr("Another issue")},
+			})
+			return
+		}
+
+		s.False(createIssueCalled)
+		createIssueCalled = true
+
+		body, err := ioutil.ReadAll(r.Body)
+		s.NoError(err)
+
+		expected, _ := json.Marshal(&github.IssueRequest{
+			Title: strptr("Lookout findings on branch master"),
+			Body: strptr("Findings of the analysis of the commit " + hash2 + ":\n\n" +
+				"### mock\n\n" + mockPushFindings),
+			Labels: &[]string{"lookout"},
+		})
+		s.JSONEq(string(expected), string(body))
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(&github.Issue{Number: intptr(2)})
+	})
+
+	p, err := NewPoster(s.pool, ProviderConfig{
+		PushResults: []string{IssuePushResult},
+	})
+	s.NoError(err)
+
+	s.NoError(p.Post(context.Background(), mockPushEvent, mockAnalyzerComments,
+		false, lookout.CommentReviewAction))
+	s.True(createIssueCalled)
+}
+
+func (s *PosterTestSuite) TestPostPushIssueUpdate() {
+	s.mux.HandleFunc("/repos/foo/bar/issues", func(w http.ResponseWriter, r *http.Request) {
+		s.Equal(http.MethodGet, r.Method)
+		json.NewEncoder(w).Encode([]*github.Issue{
+			{Number: intptr(7), Title: strptr("Lookout findings on branch master")},
+		})
+	})
+
+	editIssueCalled := false
+	s.mux.HandleFunc("/repos/foo/bar/issues/7", func(w http.ResponseWriter, r *http.Request) {
+		s.Equal(http.MethodPatch, r.Method)
+		s.False(editIssueCalled)
+		editIssueCalled = true
+
+		body, err := ioutil.ReadAll(r.Body)
+		s.NoError(err)
+
+		expected, _ := json.Marshal(&github.IssueRequest{
+			Body: strptr("Findings of the analysis of the commit " + hash2 + ":\n\n" +
+				"### mock\n\n" + mockPushFindings),
+			State: strptr("open"),
+		})
+		s.JSONEq(string(expected), string(body))
+
+		json.NewEncoder(w).Encode(&github.Issue{Number: intptr(7)})
+	})
+
+	p, err := NewPoster(s.pool, ProviderConfig{
+		PushResults: []string{IssuePushResult},
+	})
+	s.NoError(err)
+
+	s.NoError(p.Post(context.Background(), mockPushEvent, mockAnalyzerComments,
+		false, lookout.CommentReviewAction))
+	s.True(editIssueCalled)
+}
+
+func (s *PosterTestSuite) TestPostPushIssueNoFindings() {
+	s.mux.HandleFunc("/repos/foo/bar/issues", func(w http.ResponseWriter, r *http.Request) {
+		s.Equal(http.MethodGet, r.Method)
+		json.NewEncoder(w).Encode([]*github.Issue{
+			{Number: intptr(7), Title: strptr("Lookout findings on branch master")},
+		})
+	})
+
+	editIssueCalled := false
+	s.mux.HandleFunc("/repos/foo/bar/issues/7", func(w http.ResponseWriter, r *http.Request) {
+		s.Equal(http.MethodPatch, r.Method)
+		s.False(editIssueCalled)
+		editIssueCalled = true
+
+		body, err := ioutil.ReadAll(r.Body)
+		s.NoError(err)
+
+		expected, _ := json.Marshal(&github.IssueRequest{
+			Body:  strptr("No findings in the analysis of the commit " + hash2 + "."),
+			State: strptr("closed"),
+		})
+		s.JSONEq(string(expected), string(body))
+
+		json.NewEncoder(w).Encode(&github.Issue{Number: intptr(7)})
+	})
+
+	p, err := NewPoster(s.pool, ProviderConfig{
+		PushResults: []string{IssuePushResult},
+	})
+	s.NoError(err)
+
+	s.NoError(p.Post(context.Background(), mockPushEvent, nil,
+		false, lookout.CommentReviewAction))
+	s.True(editIssueCalled)
+}
+
+func (s *PosterTestSuite) TestPostPushNoFindingsNoIssue() {
+	s.mux.HandleFunc("/repos/foo/bar/issues", func(w http.ResponseWriter, r *http.Request) {
+		s.Equal(http.MethodGet, r.Method)
+		json.NewEncoder(w).Encode([]*github.Issue{})
+	})
+
+	p, err := NewPoster(s.pool, ProviderConfig{
+		PushResults: []string{CommitCommentPushResult, IssuePushResult},
+	})
+	s.NoError(err)
+
+	s.NoError(p.Post(context.Background(), mockPushEvent, nil,
+		false, lookout.CommentReviewAction))
+}
+
+func (s *PosterTestSuite) TestAnalyzerStatusPush() {
+	createStatusCalled := false
+	s.mux.HandleFunc("/repos/foo/bar/statuses/"+hash2, func(w http.ResponseWriter, r *http.Request) {
+		s.False(createStatusCalled)
+		createStatusCalled = true
+
+		body, err := ioutil.ReadAll(r.Body)
+		s.NoError(err)
+
+		expected, _ := json.Marshal(&github.RepoStatus{
+			State:       strptr("success"),
+			TargetURL:   strptr("https://lookout.example.com/foo/bar/0/style"),
+			Description: strptr("The analysis produced no comments"),
+			Context:     strptr("lookout/style"),
+		})
+		s.JSONEq(string(expected), string(body))
+
+		json.NewEncoder(w).Encode(&github.RepoStatus{ID: int64ptr(1234)})
+	})
+
+	p, err := NewPoster(s.pool, ProviderConfig{
+		StatusTargetURL: "https://lookout.example.com/{{.Owner}}/{{.Repository}}/{{.PullRequest}}/{{.Analyzer}}",
+		PushResults:     []string{CommitStatusPushResult},
+	})
+	s.NoError(err)
+
+	s.NoError(p.AnalyzerStatus(context.Background(), mockPushEvent, lookout.AnalyzerStatus{
+		Analyzer:    "style",
+		Status:      lookout.SuccessAnalysisStatus,
+		Description: "The analysis produced no comments",
+	}))
+	s.True(createStatusCalled)
+}
+
+func (s *PosterTestSuite) TestUnknownPushResult() {
+	_, err := NewPoster(s.pool, ProviderConfig{
+		PushResults: []string{"email"},
+	})
+	s.True(ErrUnknownPushResult.Is(err))
+}
@@ -0,0 +1,104 @@
+package github
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+
+	"github.com/src-d/lookout"
+	"github.com/src-d/lookout/util/ctxlog"
+
+	"github.com/google/go-github/github"
+	log "gopkg.in/src-d/go-log.v1"
+)
+
+// rerunCommand matches a line of a comment with the command to analyze a pull
+// request again, like "@lookout rerun", or "@lookout rerun style" to run only
+// the style analyzer
+var rerunCommand = regexp.MustCompile(`(?im)^\s*@lookout\s+rerun(?:\s+([\w.-]+))?\s*$`)
+
+// parseRerunCommand returns true if the comment contains a rerun command, and
+// the analyzer it requests, empty for all of them
+func parseRerunCommand(body string) (analyzer string, ok bool) {
+	m := rerunCommand.FindStringSubmatch(body)
+	if m == nil {
+		return "", false
+	}
+
+	return m[1], true
+}
+
+// castIssueCommentEvent returns the rerun event requested by a comment on a
+// pull request, or nil if the comment is not a rerun command from a user with
+// write access to the repository
+func (w *Watcher) castIssueCommentEvent(
+	ctx context.Context,
+	client *Client,
+	r *repositoryInfo,
+	e *github.Event,
+) (lookout.Event, error) {
+	payload, err := e.ParsePayload()
+	if err != nil {
+		return nil, ErrParsingEventPayload.New(err)
+	}
+
+	ic := payload.(*github.IssueCommentEvent)
+	if ic.GetAction() != "created" || !ic.GetIssue().IsPullRequest() {
+		return nil, nil
+	}
+
+	analyzer, ok := parseRerunCommand(ic.GetComment().GetBody())
+	if !ok {
+		return nil, nil
+	}
+
+	// the events list is read again when it changes, the commands already
+	// handled are not checked again
+	commentID := ic.GetComment().GetID()
+	if _, ok := w.commands.Get(commentID); ok {
+		return nil, nil
+	}
+
+	user := ic.GetComment().GetUser().GetLogin()
+	number := ic.GetIssue().GetNumber()
+	ctx, logger := ctxlog.WithLogFields(ctx, log.Fields{
+		"github.pr": number,
+		"user":      user,
+	})
+
+	ctx, cancel := context.WithTimeout(ctx, RequestTimeout)
+	defer cancel()
+
+	perm, resp, err := client.Repositories.GetPermissionLevel(ctx, r.Owner, r.Name, user)
+	if err = handleAPIError(resp, err, "user permission could not be read"); err != nil {
+		return nil, err
+	}
+
+	if p := perm.GetPermission(); p != "admin" && p != "write" {
+		logger.Infof("rerun command ignored, the user does not have write access")
+		w.commands.Add(commentID, nil)
+		return nil, nil
+	}
+
+	pr, resp, err := client.PullRequests.Get(ctx, r.Owner, r.Name, number)
+	if err = handleAPIError(resp, err, "pull request could not be read"); err != nil {
+		return nil, err
+	}
+
+	w.commands.Add(commentID, nil)
+
+	if pr.GetState() != "open" {
+		logger.Infof("rerun command ignored, the pull request is not open")
+		return nil, nil
+	}
+
+	logger.With(log.Fields{"analyzer": analyzer}).Infof("rerun requested")
+
+	ev := castPullRequest(ctx, r, pr)
+	ev.Rerun = &lookout.Rerun{
+		ID:       strconv.FormatInt(commentID, 10),
+		Analyzer: analyzer,
+	}
+
+	return ev, nil
+}
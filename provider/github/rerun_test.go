@@ -0,0 +1,222 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/src-d/lookout"
+
+	"github.com/google/go-github/github"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRerunCommand(t *testing.T) {
+	cases := []struct {
+		body     string
+		analyzer string
+		ok       bool
+	}{
+		{"@lookout rerun", "", true},
+		{"  @lookout   rerun  ", "", true},
+		{"@Lookout Rerun", "", true},
+		{"@lookout rerun style", "style", true},
+		{"@lookout rerun go-style.v2", "go-style.v2", true},
+		{"Flaky analysis\n\n@lookout rerun style\n", "style", true},
+		{"please @lookout rerun", "", false},
+		{"@lookout rerun style now", "", false},
+		{"@lookout rerunning", "", false},
+		{"@lookout", "", false},
+		{"", "", false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.body, func(t *testing.T) {
+			analyzer, ok := parseRerunCommand(c.body)
+			require.Equal(t, c.ok, ok)
+			require.Equal(t, c.analyzer, analyzer)
+		})
+	}
+}
+
+func issueCommentEvent(commentID int64, user, body string) *github.Event {
+	payload, _ := json.Marshal(&github.IssueCommentEvent{
+		Action: strptr("created"),
+		Issue: &github.Issue{
+			Number:           intptr(42),
+			PullRequestLinks: &github.PullRequestLinks{URL: strptr("https://api.github.com/repos/mock/test/pulls/42")},
+		},
+		Comment: &github.IssueComment{
+			ID:   int64ptr(commentID),
+			Body: strptr(body),
+			User: &github.User{Login: strptr(user)},
+		},
+	})
+
+	raw := json.RawMessage(payload)
+	return &github.Event{
+		Type:       strptr("IssueCommentEvent"),
+		RawPayload: &raw,
+	}
+}
+
+func (s *WatcherTestSuite) handleRerunPR(calls *int) {
+	s.mux.HandleFunc("/repos/mock/test/pulls/42", func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		json.NewEncoder(w).Encode(&github.PullRequest{
+			ID:     int64ptr(5),
+			Number: intptr(42),
+			State:  strptr("open"),
+			Head: &github.PullRequestBranch{
+				SHA: strptr(hash2),
+				Ref: strptr("feature"),
+				Repo: &github.Repository{
+					CloneURL: strptr("https://github.com/mock/test.git"),
+				},
+			},
+			Base: &github.PullRequestBranch{
+				SHA: strptr(hash1),
+				Ref: strptr("master"),
+				Repo: &github.Repository{
+					CloneURL: strptr("https://github.com/mock/test.git"),
+				},
+			},
+		})
+	})
+}
+
+func (s *WatcherTestSuite) handlePermission(user, permission string) {
+	s.mux.HandleFunc("/repos/mock/test/collaborators/"+user+"/permission", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(&github.RepositoryPermissionLevel{Permission: strptr(permission)})
+	})
+}
+
+func (s *WatcherTestSuite) rerunWatcher() (*Watcher, *Client, *repositoryInfo) {
+	w := s.newWatcher([]string{"github.com/mock/test"})
+	client, ok := w.pool.Client("mock", "test")
+	s.Require().True(ok)
+
+	return w, client, w.pool.ReposByClient(client)[0]
+}
+
+func (s *WatcherTestSuite) TestRerunCommand() {
+	var prCalls int
+	s.handleRerunPR(&prCalls)
+	s.handlePermission("maintainer", "admin")
+
+	w, client, repo := s.rerunWatcher()
+	e, err := w.handleEvent(context.Background(), client, repo,
+		issueCommentEvent(100, "maintainer", "@lookout rerun style"))
+	s.Require().NoError(err)
+	s.Require().NotNil(e)
+
+	ev, ok := e.(*lookout.ReviewEvent)
+	s.Require().True(ok)
+	s.Equal(&lookout.Rerun{ID: "100", Analyzer: "style"}, ev.Rerun)
+	s.Equal(hash2, ev.Head.Hash)
+	s.Equal(uint32(42), ev.Number)
+
+	// the rerun has its own id, different from the one of the pull request
+	regular := castPullRequest(context.Background(), repo, &github.PullRequest{
+		ID:     int64ptr(5),
+		Number: intptr(42),
+		Head:   &github.PullRequestBranch{SHA: strptr(hash2)},
+	})
+	s.Equal(regular.InternalID, ev.InternalID)
+	s.NotEqual(regular.ID(), ev.ID())
+
+	// the command is handled only once
+	e, err = w.handleEvent(context.Background(), client, repo,
+		issueCommentEvent(100, "maintainer", "@lookout rerun style"))
+	s.NoError(err)
+	s.Nil(e)
+	s.Equal(1, prCalls)
+
+	// a new command is a new rerun
+	e, err = w.handleEvent(context.Background(), client, repo,
+		issueCommentEvent(101, "maintainer", "@lookout rerun"))
+	s.NoError(err)
+	s.Require().NotNil(e)
+	s.Equal(&lookout.Rerun{ID: "101"}, e.(*lookout.ReviewEvent).Rerun)
+	s.NotEqual(ev.ID(), e.ID())
+}
+
+func (s *WatcherTestSuite) TestRerunCommandNoCommand() {
+	var prCalls int
+	s.handleRerunPR(&prCalls)
+
+	w, client, repo := s.rerunWatcher()
+	e, err := w.handleEvent(context.Background(), client, repo,
+		issueCommentEvent(100, "maintainer", "LGTM"))
+	s.NoError(err)
+	s.Nil(e)
+	s.Equal(0, prCalls)
+}
+
+func (s *WatcherTestSuite) TestRerunCommandReadAccess() {
+	var prCalls int
+	s.handleRerunPR(&prCalls)
+
+	s.handlePermission("reader", "read")
+	s.handlePermission("writer", "write")
+
+	w, client, repo := s.rerunWatcher()
+	e, err := w.handleEvent(context.Background(), client, repo,
+		issueCommentEvent(100, "reader", "@lookout rerun"))
+	s.NoError(err)
+	s.Nil(e)
+	s.Equal(0, prCalls)
+
+	e, err = w.handleEvent(context.Background(), client, repo,
+		issueCommentEvent(101, "writer", "@lookout rerun"))
+	s.NoError(err)
+	s.NotNil(e)
+	s.Equal(1, prCalls)
+}
+
+func (s *WatcherTestSuite) TestRerunCommandAPIError() {
+	var prCalls int
+	s.handleRerunPR(&prCalls)
+
+	var fail bool
+	s.mux.HandleFunc("/repos/mock/test/collaborators/maintainer/permission", func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		json.NewEncoder(w).Encode(&github.RepositoryPermissionLevel{Permission: strptr("admin")})
+	})
+
+	w, client, repo := s.rerunWatcher()
+
+	key := "https://api.github.com/repos/mock/test/events"
+	reqURL, _ := url.Parse(key)
+	resp := &github.Response{Response: &http.Response{Request: &http.Request{URL: reqURL}}}
+	events := []*github.Event{issueCommentEvent(100, "maintainer", "@lookout rerun")}
+
+	var handled []lookout.Event
+	cb := func(ctx context.Context, e lookout.Event) error {
+		handled = append(handled, e)
+		return nil
+	}
+
+	// the events list is not cached if the command could not be handled
+	fail = true
+	s.cache.Set(key, []byte("events"))
+	s.NoError(w.handleEvents(context.Background(), client, cb, repo, resp, events))
+	s.Empty(handled)
+	_, cached := s.cache.Get(key)
+	s.False(cached)
+
+	// so the command is handled the next time the list is read
+	fail = false
+	s.cache.Set(key, []byte("events"))
+	s.NoError(w.handleEvents(context.Background(), client, cb, repo, resp, events))
+	s.Len(handled, 1)
+	s.Equal(1, prCalls)
+	_, cached = s.cache.Get(key)
+	s.True(cached)
+}
@@ -2,6 +2,7 @@ package github
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"strings"
 	"text/template"
@@ -30,6 +31,124 @@ const commentsSeparator = "\n<!-- lookout comment separator -->\n---\n"
 // comment can contain footer with link to the analyzer
 const footnoteSeparator = "\n<!-- lookout footnote separator -->\n"
 
+// rightSide is the side of the diff of the new version of the file
+var rightSide = "RIGHT"
+
+// fileSubject is the subject type of the review comments on a whole file
+var fileSubject = "file"
+
+// postedComment is a github.PullRequestComment with its subject type, not
+// supported by go-github
+type postedComment struct {
+	github.PullRequestComment
+	SubjectType *string `json:"subject_type,omitempty"`
+}
+
+// isFile returns true if the comment is on the whole file
+func (c *postedComment) isFile() bool {
+	return c.SubjectType != nil && *c.SubjectType == fileSubject
+}
+
+// reviewRequest is a github.PullRequestReviewRequest with comments that can
+// span several lines, not supported by go-github
+type reviewRequest struct {
+	CommitID *string               `json:"commit_id,omitempty"`
+	Body     *string               `json:"body,omitempty"`
+	Event    *string               `json:"event,omitempty"`
+	Comments []*draftReviewComment `json:"comments,omitempty"`
+}
+
+// GetBody returns the Body field if it's non-nil, zero value otherwise.
+func (r *reviewRequest) GetBody() string {
+	if r == nil || r.Body == nil {
+		return ""
+	}
+
+	return *r.Body
+}
+
+// GetEvent returns the Event field if it's non-nil, zero value otherwise.
+func (r *reviewRequest) GetEvent() string {
+	if r == nil || r.Event == nil {
+		return ""
+	}
+
+	return *r.Event
+}
+
+// draftReviewComment is a github.DraftReviewComment that can span several
+// lines. Single-line comments are anchored with Position, multi-line ones with
+// StartLine and Line, the first and last lines of the new version of the
+// file. File-level comments only set SubjectType, they can't be part of a
+// review and are posted apart.
+type draftReviewComment struct {
+	Path        *string `json:"path,omitempty"`
+	Position    *int    `json:"position,omitempty"`
+	Body        *string `json:"body,omitempty"`
+	StartLine   *int    `json:"start_line,omitempty"`
+	Line        *int    `json:"line,omitempty"`
+	StartSide   *string `json:"start_side,omitempty"`
+	Side        *string `json:"side,omitempty"`
+	SubjectType *string `json:"subject_type,omitempty"`
+
+	// endPosition is the position in the diff of Line, GitHub reports it as
+	// the position of the posted multi-line comments
+	endPosition int
+}
+
+// GetPath returns the Path field if it's non-nil, zero value otherwise.
+func (c *draftReviewComment) GetPath() string {
+	if c == nil || c.Path == nil {
+		return ""
+	}
+
+	return *c.Path
+}
+
+// GetPosition returns the Position field if it's non-nil, zero value
+// otherwise.
+func (c *draftReviewComment) GetPosition() int {
+	if c == nil || c.Position == nil {
+		return 0
+	}
+
+	return *c.Position
+}
+
+// GetBody returns the Body field if it's non-nil, zero value otherwise.
+func (c *draftReviewComment) GetBody() string {
+	if c == nil || c.Body == nil {
+		return ""
+	}
+
+	return *c.Body
+}
+
+// GetStartLine returns the StartLine field if it's non-nil, zero value
+// otherwise.
+func (c *draftReviewComment) GetStartLine() int {
+	if c == nil || c.StartLine == nil {
+		return 0
+	}
+
+	return *c.StartLine
+}
+
+// isFile returns true if the comment is on the whole file
+func (c *draftReviewComment) isFile() bool {
+	return c.SubjectType != nil && *c.SubjectType == fileSubject
+}
+
+// diffPosition returns the position of the comment in the diff, the one of
+// its last line for multi-line comments, and zero for file-level comments
+func (c *draftReviewComment) diffPosition() int {
+	if c.Position != nil {
+		return *c.Position
+	}
+
+	return c.endPosition
+}
+
 var (
 	ErrEmptyTemplate = errors.NewKind("empty footer template")
 	ErrOldTemplate   = errors.NewKind("old footer template: '%%s' placeholder is no longer supported: '%s'")
@@ -37,17 +156,86 @@ var (
 	ErrTemplateError = errors.NewKind("error generating the footer: %s")
 )
 
+// fileCommentRequest is a request to create a file-level review comment, not
+// supported by go-github
+type fileCommentRequest struct {
+	CommitID    *string `json:"commit_id,omitempty"`
+	Path        *string `json:"path,omitempty"`
+	Body        *string `json:"body,omitempty"`
+	SubjectType *string `json:"subject_type,omitempty"`
+}
+
 // createReview creates pull request review on github using multiple http calls
-// in case of too many comments
+// in case of too many comments. The file-level comments are posted after the
+// review, one by one.
 func createReview(
 	ctx context.Context,
 	client *Client,
 	owner, repo string, number int,
-	req *github.PullRequestReviewRequest,
+	req *reviewRequest,
+) error {
+	var lineComments, fileComments []*draftReviewComment
+	for _, c := range req.Comments {
+		if c.isFile() {
+			fileComments = append(fileComments, c)
+		} else {
+			lineComments = append(lineComments, c)
+		}
+	}
+
+	review := *req
+	review.Comments = lineComments
+
+	// a review of comments needs a body or comments
+	if review.GetBody() != "" || len(review.Comments) > 0 || review.GetEvent() != commentEvent {
+		if err := postReview(ctx, client, owner, repo, number, &review); err != nil {
+			return err
+		}
+	}
+
+	for i, c := range fileComments {
+		// need to wait between requests to avoid "was submitted too quickly"
+		// error, postReview waits after the review
+		if i > 0 {
+			time.Sleep(time.Second)
+		}
+
+		u := fmt.Sprintf("repos/%v/%v/pulls/%d/comments", owner, repo, number)
+		r, err := client.NewRequest("POST", u, &fileCommentRequest{
+			CommitID:    req.CommitID,
+			Path:        c.Path,
+			Body:        c.Body,
+			SubjectType: c.SubjectType,
+		})
+		if err != nil {
+			return err
+		}
+
+		if _, err := client.Do(ctx, r, new(github.PullRequestComment)); err != nil {
+			return ErrGitHubAPI.Wrap(err, "file comment could not be posted")
+		}
+	}
+
+	return nil
+}
+
+func postReview(
+	ctx context.Context,
+	client *Client,
+	owner, repo string, number int,
+	req *reviewRequest,
 ) error {
 	requests := splitReviewRequest(req, batchReviewComments)
 	for i, req := range requests {
-		_, resp, err := client.PullRequests.CreateReview(ctx, owner, repo, number, req)
+		// the same request as client.PullRequests.CreateReview, with the
+		// multi-line comments
+		u := fmt.Sprintf("repos/%v/%v/pulls/%d/reviews", owner, repo, number)
+		r, err := client.NewRequest("POST", u, req)
+		if err != nil {
+			return err
+		}
+
+		resp, err := client.Do(ctx, r, new(github.PullRequestReview))
 
 		if err = handleAPIError(resp, err, "review could not be pushed"); err != nil {
 			return err
@@ -62,8 +250,8 @@ func createReview(
 	return nil
 }
 
-func filterPostedComments(comments []*github.DraftReviewComment, posted []*github.PullRequestComment) []*github.DraftReviewComment {
-	var filtered []*github.DraftReviewComment
+func filterPostedComments(comments []*draftReviewComment, posted []*postedComment) []*draftReviewComment {
+	var filtered []*draftReviewComment
 
 	for _, comment := range comments {
 		var filterOut bool
@@ -72,7 +260,12 @@ func filterPostedComments(comments []*github.DraftReviewComment, posted []*githu
 				continue
 			}
 
-			if comment.GetPosition() != pc.GetPosition() {
+			// the comments on outdated lines have no position either
+			if comment.isFile() != pc.isFile() {
+				continue
+			}
+
+			if comment.diffPosition() != pc.GetPosition() {
 				continue
 			}
 
@@ -99,8 +292,8 @@ func filterPostedComments(comments []*github.DraftReviewComment, posted []*githu
 	return filtered
 }
 
-func getPostedComment(ctx context.Context, client *Client, owner, repo string, number int) ([]*github.PullRequestComment, error) {
-	var result []*github.PullRequestComment
+func getPostedComment(ctx context.Context, client *Client, owner, repo string, number int) ([]*postedComment, error) {
+	var result []*postedComment
 
 	listReviewsOpts := &github.ListOptions{
 		PerPage: 100,
@@ -126,7 +319,17 @@ func getPostedComment(ctx context.Context, client *Client, owner, repo string, n
 		}
 
 		for {
-			comments, resp, err := client.PullRequests.ListReviewComments(ctx, owner, repo, number, review.GetID(), listCommentsOpts)
+			// the same request as client.PullRequests.ListReviewComments,
+			// with the subject type of the comments
+			u := fmt.Sprintf("repos/%v/%v/pulls/%d/reviews/%d/comments?page=%d&per_page=%d",
+				owner, repo, number, review.GetID(), listCommentsOpts.Page, listCommentsOpts.PerPage)
+			r, err := client.NewRequest("GET", u, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			var comments []*postedComment
+			resp, err := client.Do(ctx, r, &comments)
 			if handleAPIError(resp, err, "review comments could not be listed") != nil {
 				return nil, err
 			}
@@ -143,10 +346,10 @@ func getPostedComment(ctx context.Context, client *Client, owner, repo string, n
 	return result, nil
 }
 
-func mergeComments(comments []*github.DraftReviewComment) []*github.DraftReviewComment {
-	var mergedComments []*github.DraftReviewComment
+func mergeComments(comments []*draftReviewComment) []*draftReviewComment {
+	var mergedComments []*draftReviewComment
 
-	// sort by path and position
+	// sort by path, subject type, position and first line
 	sort.Slice(comments, func(i, j int) bool {
 		if comments[i].GetPath() < comments[j].GetPath() {
 			return true
@@ -154,18 +357,29 @@ func mergeComments(comments []*github.DraftReviewComment) []*github.DraftReviewC
 		if comments[i].GetPath() > comments[j].GetPath() {
 			return false
 		}
-		if comments[i].GetPosition() < comments[j].GetPosition() {
+		if comments[i].isFile() != comments[j].isFile() {
+			return comments[i].isFile()
+		}
+		if comments[i].diffPosition() < comments[j].diffPosition() {
+			return true
+		}
+		if comments[i].diffPosition() > comments[j].diffPosition() {
+			return false
+		}
+		if comments[i].GetStartLine() < comments[j].GetStartLine() {
 			return true
 		}
 
 		return false
 	})
 
-	var lastComment *github.DraftReviewComment
+	var lastComment *draftReviewComment
 	for _, comment := range comments {
 		if lastComment != nil &&
 			lastComment.GetPath() == comment.GetPath() &&
-			lastComment.GetPosition() == comment.GetPosition() {
+			lastComment.isFile() == comment.isFile() &&
+			lastComment.diffPosition() == comment.diffPosition() &&
+			lastComment.GetStartLine() == comment.GetStartLine() {
 
 			mergedBody := lastComment.GetBody() + commentsSeparator + comment.GetBody()
 			lastComment.Body = &mergedBody
@@ -180,20 +394,20 @@ func mergeComments(comments []*github.DraftReviewComment) []*github.DraftReviewC
 }
 
 // splitReviewRequest transforms a review into a list of reviews with not more than N comments in each
-func splitReviewRequest(review *github.PullRequestReviewRequest, n int) []*github.PullRequestReviewRequest {
+func splitReviewRequest(review *reviewRequest, n int) []*reviewRequest {
 	if len(review.Comments) <= n {
-		return []*github.PullRequestReviewRequest{review}
+		return []*reviewRequest{review}
 	}
 
-	var result []*github.PullRequestReviewRequest
+	var result []*reviewRequest
 	comments := review.Comments
-	// set body only to the last review
+	// set body and event only to the last review
 	emptyBody := ""
 
 	for len(comments) > n {
-		result = append(result, &github.PullRequestReviewRequest{
+		result = append(result, &reviewRequest{
 			CommitID: review.CommitID,
-			Event:    review.Event,
+			Event:    &commentEvent,
 			Body:     &emptyBody,
 			Comments: comments[:n],
 		})
@@ -202,15 +416,16 @@ func splitReviewRequest(review *github.PullRequestReviewRequest, n int) []*githu
 	}
 
 	if len(comments) > 0 {
-		result = append(result, &github.PullRequestReviewRequest{
+		result = append(result, &reviewRequest{
 			CommitID: review.CommitID,
-			Event:    review.Event,
+			Event:    &commentEvent,
 			Body:     &emptyBody,
 			Comments: comments,
 		})
 	}
 
 	result[len(result)-1].Body = review.Body
+	result[len(result)-1].Event = review.Event
 
 	return result
 }
@@ -269,10 +484,14 @@ func removeFootnote(text string) string {
 	return strings.SplitN(text, footnoteSeparator, 2)[0]
 }
 
-// convertComments transforms []*lookout.Comment to []*github.DraftReviewComment and list of string for body
-func convertComments(ctx context.Context, cs []*lookout.Comment, dl *diffLines) ([]string, []*github.DraftReviewComment) {
+// convertComments transforms []*lookout.Comment to []*draftReviewComment and list of string for body.
+// The comments on lines outside the diff, or on files not part of it, are
+// returned apart.
+func convertComments(ctx context.Context, cs []*lookout.Comment, dl *diffLines) (
+	[]string, []*draftReviewComment, []*lookout.Comment) {
 	var bodyComments []string
-	var comments []*github.DraftReviewComment
+	var comments []*draftReviewComment
+	var outOfDiff []*lookout.Comment
 
 	for _, c := range cs {
 		if c.File == "" {
@@ -280,9 +499,14 @@ func convertComments(ctx context.Context, cs []*lookout.Comment, dl *diffLines)
 			continue
 		}
 
+		if comment, ok := fixComment(ctx, c, dl); ok {
+			comments = append(comments, comment)
+			continue
+		}
+
 		if c.Line < 1 {
 			line := 1
-			comment := &github.DraftReviewComment{
+			comment := &draftReviewComment{
 				Path:     &c.File,
 				Position: &line,
 				Body:     &c.Text,
@@ -291,20 +515,28 @@ func convertComments(ctx context.Context, cs []*lookout.Comment, dl *diffLines)
 			continue
 		}
 
+		if comment, ok := rangeComment(ctx, c, dl); ok {
+			comments = append(comments, comment)
+			continue
+		}
+
 		logger := convertLineLogger(ctx, c)
 		line, err := dl.ConvertLine(c.File, int(c.Line), true)
 		if ErrLineOutOfDiff.Is(err) {
-			logger.Debugf("skipping comment out the diff range")
+			logger.Debugf("comment out of the diff range")
+			outOfDiff = append(outOfDiff, c)
 			continue
 		}
 
 		if ErrLineNotAddition.Is(err) {
-			logger.Debugf("skipping comment not on an added line (+ in diff)")
+			logger.Debugf("comment not on an added line (+ in diff)")
+			outOfDiff = append(outOfDiff, c)
 			continue
 		}
 
 		if ErrFileNotFound.Is(err) {
-			logger.Warningf("skipping comment on a file not part of the diff")
+			logger.Debugf("comment on a file not part of the diff")
+			outOfDiff = append(outOfDiff, c)
 			continue
 		}
 
@@ -322,7 +554,7 @@ func convertComments(ctx context.Context, cs []*lookout.Comment, dl *diffLines)
 			continue
 		}
 
-		comment := &github.DraftReviewComment{
+		comment := &draftReviewComment{
 			Path:     &c.File,
 			Position: &line,
 			Body:     &c.Text,
@@ -331,7 +563,192 @@ func convertComments(ctx context.Context, cs []*lookout.Comment, dl *diffLines)
 		comments = append(comments, comment)
 	}
 
-	return bodyComments, comments
+	return bodyComments, comments, outOfDiff
+}
+
+// convertOutOfDiff returns the text for the review body and the file-level
+// comments with the comments outside the diff, posted in the given mode
+func convertOutOfDiff(
+	ctx context.Context,
+	cs []*lookout.Comment,
+	dl *diffLines,
+	mode string,
+) ([]string, []*draftReviewComment) {
+	if len(cs) == 0 {
+		return nil, nil
+	}
+
+	logger := ctxlog.Get(ctx).With(log.Fields{
+		"out_of_diff": mode,
+		"comments":    len(cs),
+	})
+
+	var comments []*draftReviewComment
+	switch mode {
+	case lookout.DropOutOfDiff:
+		logger.Debugf("skipping comments outside the diff")
+		return nil, nil
+	case lookout.FileOutOfDiff:
+		var notFound []*lookout.Comment
+		for _, c := range cs {
+			// the files without patch, like renames, can't be commented
+			if _, err := dl.filePatch(c.File); err != nil {
+				notFound = append(notFound, c)
+				continue
+			}
+
+			// the text goes first, the feedback is matched by its prefix
+			body := fmt.Sprintf("%s\n\n_%s_", c.Text, linesLabel(c))
+			comments = append(comments, &draftReviewComment{
+				Path:        &c.File,
+				Body:        &body,
+				SubjectType: &fileSubject,
+			})
+		}
+
+		cs = notFound
+	}
+
+	logger.Debugf("comments outside the diff added to the review")
+
+	summary := outOfDiffSummary(cs)
+	if summary == "" {
+		return nil, comments
+	}
+
+	return []string{summary}, comments
+}
+
+// outOfDiffSummary returns the collapsible section of the review body that
+// lists the comments, grouped by file
+func outOfDiffSummary(cs []*lookout.Comment) string {
+	if len(cs) == 0 {
+		return ""
+	}
+
+	sorted := make([]*lookout.Comment, len(cs))
+	copy(sorted, cs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].File != sorted[j].File {
+			return sorted[i].File < sorted[j].File
+		}
+
+		return sorted[i].Line < sorted[j].Line
+	})
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<details>\n<summary>Findings outside the diff (%d)</summary>\n", len(sorted))
+	for i, c := range sorted {
+		if i == 0 || c.File != sorted[i-1].File {
+			fmt.Fprintf(&b, "\n**%s**\n\n", c.File)
+		}
+
+		text := strings.Replace(c.Text, "\n", "\n  ", -1)
+		fmt.Fprintf(&b, "- %s: %s\n", linesLabel(c), text)
+	}
+
+	b.WriteString("\n</details>")
+	return b.String()
+}
+
+// linesLabel returns the lines of the file the comment refers to
+func linesLabel(c *lookout.Comment) string {
+	if c.EndLine > c.Line {
+		return fmt.Sprintf("Lines %d-%d", c.Line, c.EndLine)
+	}
+
+	return fmt.Sprintf("Line %d", c.Line)
+}
+
+// rangeComment returns the multi-line comment on the lines from Line to
+// EndLine. It returns false if the comment is on a single line, or if the
+// range can't be commented: GitHub only accepts ranges in the same hunk of the
+// diff. Then the comment is anchored to its first line.
+func rangeComment(ctx context.Context, c *lookout.Comment, dl *diffLines) (*draftReviewComment, bool) {
+	if c.EndLine <= c.Line {
+		return nil, false
+	}
+
+	comment, err := newRangeComment(c.File, int(c.Line), int(c.EndLine), c.Text, dl)
+	if err != nil {
+		convertLineLogger(ctx, c).Debugf("comment anchored to its first line, its range can't be commented: %s", err)
+		return nil, false
+	}
+
+	return comment, true
+}
+
+func newRangeComment(path string, start, end int, body string, dl *diffLines) (*draftReviewComment, error) {
+	position, err := dl.ConvertRange(path, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return &draftReviewComment{
+		Path:        &path,
+		Body:        &body,
+		StartLine:   &start,
+		Line:        &end,
+		StartSide:   &rightSide,
+		Side:        &rightSide,
+		endPosition: position,
+	}, nil
+}
+
+// fixComment returns the comment with the fix as a suggestion, on the lines
+// replaced by the fix. It returns false if the comment has no fix, or if it
+// can't be posted as a suggestion: GitHub only applies the suggestions of one
+// added line of the diff, or of a range of lines in the same hunk.
+func fixComment(ctx context.Context, c *lookout.Comment, dl *diffLines) (*draftReviewComment, bool) {
+	f := c.Fix
+	if f == nil || f.StartLine < 1 {
+		return nil, false
+	}
+
+	logger := convertLineLogger(ctx, c).With(log.Fields{
+		"fix.start_line": f.StartLine,
+		"fix.end_line":   f.EndLine,
+	})
+
+	body := c.Text + "\n\n" + suggestionBlock(f.Text)
+	if f.EndLine > f.StartLine {
+		comment, err := newRangeComment(c.File, int(f.StartLine), int(f.EndLine), body, dl)
+		if err != nil {
+			logger.Debugf("fix not suggested, its lines can't be commented: %s", err)
+			return nil, false
+		}
+
+		return comment, true
+	}
+
+	line, err := dl.ConvertLine(c.File, int(f.StartLine), true)
+	if err != nil {
+		logger.Debugf("fix not suggested, its line is not an added line of the diff: %s", err)
+		return nil, false
+	}
+
+	return &draftReviewComment{
+		Path:     &c.File,
+		Position: &line,
+		Body:     &body,
+	}, true
+}
+
+// suggestionBlock returns the markdown of a suggested change, that replaces
+// the commented line with text
+func suggestionBlock(text string) string {
+	// the fence must be longer than any backtick sequence of the text
+	fence := "```"
+	for strings.Contains(text, fence) {
+		fence += "`"
+	}
+
+	text = strings.TrimSuffix(text, "\n")
+	if text == "" {
+		return fence + "suggestion\n" + fence
+	}
+
+	return fence + "suggestion\n" + text + "\n" + fence
 }
 
 func convertLineLogger(ctx context.Context, c *lookout.Comment) log.Logger {
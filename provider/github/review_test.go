@@ -12,7 +12,7 @@ import (
 func TestMergeComments(t *testing.T) {
 	require := require.New(t)
 
-	input := []*github.DraftReviewComment{
+	input := []*draftReviewComment{
 		{
 			Path:     strptr("file1"),
 			Position: intptr(1),
@@ -42,10 +42,41 @@ func TestMergeComments(t *testing.T) {
 	require.Equal("comment 2_1", output[2].GetBody())
 }
 
+func TestMergeCommentsRange(t *testing.T) {
+	require := require.New(t)
+
+	input := []*draftReviewComment{
+		{
+			Path:        strptr("file1"),
+			StartLine:   intptr(3),
+			Line:        intptr(4),
+			Body:        strptr("range 3-4"),
+			endPosition: 2,
+		},
+		{
+			Path:     strptr("file1"),
+			Position: intptr(2),
+			Body:     strptr("single line 4"),
+		},
+		{
+			Path:        strptr("file1"),
+			StartLine:   intptr(3),
+			Line:        intptr(4),
+			Body:        strptr("another range 3-4"),
+			endPosition: 2,
+		},
+	}
+	output := mergeComments(input)
+
+	require.Len(output, 2)
+	require.Equal("single line 4", output[0].GetBody())
+	require.Equal("range 3-4"+commentsSeparator+"another range 3-4", output[1].GetBody())
+}
+
 func TestFilterPostedComments(t *testing.T) {
 	require := require.New(t)
 
-	input := []*github.DraftReviewComment{
+	input := []*draftReviewComment{
 		{
 			Path:     strptr("file1"),
 			Position: intptr(1),
@@ -61,23 +92,46 @@ func TestFilterPostedComments(t *testing.T) {
 			Position: intptr(2),
 			Body:     strptr("merged filter out"),
 		},
-	}
-	posted := []*github.PullRequestComment{
 		{
+			Path:        strptr("file1"),
+			Body:        strptr("file filter out"),
+			SubjectType: &fileSubject,
+		},
+		{
+			Path:        strptr("file1"),
+			Body:        strptr("file should stay"),
+			SubjectType: &fileSubject,
+		},
+	}
+	posted := []*postedComment{
+		{PullRequestComment: github.PullRequestComment{
 			Path:     strptr("file1"),
 			Position: intptr(1),
 			Body:     strptr("regular filter out"),
-		},
-		{
+		}},
+		{PullRequestComment: github.PullRequestComment{
 			Path:     strptr("file1"),
 			Position: intptr(2),
 			Body:     strptr("merged filter out" + commentsSeparator + "another comment"),
+		}},
+		{
+			PullRequestComment: github.PullRequestComment{
+				Path: strptr("file1"),
+				Body: strptr("file filter out"),
+			},
+			SubjectType: &fileSubject,
 		},
+		// an outdated line comment has no position
+		{PullRequestComment: github.PullRequestComment{
+			Path: strptr("file1"),
+			Body: strptr("file should stay"),
+		}},
 	}
 	output := filterPostedComments(input, posted)
 
-	require.Len(output, 1)
+	require.Len(output, 2)
 	require.Equal("should stay", output[0].GetBody())
+	require.Equal("file should stay", output[1].GetBody())
 }
 
 func TestSplitReviewRequest(t *testing.T) {
@@ -85,20 +139,20 @@ func TestSplitReviewRequest(t *testing.T) {
 
 	n := 2
 
-	rw := &github.PullRequestReviewRequest{
+	rw := &reviewRequest{
 		Event: strptr(commentEvent),
 		Body:  strptr("body"),
 	}
 
-	rw.Comments = []*github.DraftReviewComment{
+	rw.Comments = []*draftReviewComment{
 		{Body: strptr("comment1")},
 	}
 
 	r := splitReviewRequest(rw, n)
 	require.Len(r, 1)
-	require.Equal([]*github.PullRequestReviewRequest{rw}, r)
+	require.Equal([]*reviewRequest{rw}, r)
 
-	rw.Comments = []*github.DraftReviewComment{
+	rw.Comments = []*draftReviewComment{
 		{Body: strptr("comment1")},
 		{Body: strptr("comment2")},
 		{Body: strptr("comment3")},
@@ -106,11 +160,11 @@ func TestSplitReviewRequest(t *testing.T) {
 
 	r = splitReviewRequest(rw, n)
 	require.Len(r, 2)
-	require.Equal([]*github.PullRequestReviewRequest{
+	require.Equal([]*reviewRequest{
 		{
 			Event: strptr(commentEvent),
 			Body:  strptr(""),
-			Comments: []*github.DraftReviewComment{
+			Comments: []*draftReviewComment{
 				{Body: strptr("comment1")},
 				{Body: strptr("comment2")},
 			},
@@ -118,13 +172,13 @@ func TestSplitReviewRequest(t *testing.T) {
 		{
 			Event: strptr(commentEvent),
 			Body:  strptr("body"),
-			Comments: []*github.DraftReviewComment{
+			Comments: []*draftReviewComment{
 				{Body: strptr("comment3")},
 			},
 		},
 	}, r)
 
-	rw.Comments = []*github.DraftReviewComment{
+	rw.Comments = []*draftReviewComment{
 		{Body: strptr("comment1")},
 		{Body: strptr("comment2")},
 		{Body: strptr("comment3")},
@@ -135,6 +189,14 @@ func TestSplitReviewRequest(t *testing.T) {
 
 	r = splitReviewRequest(rw, n)
 	require.Len(r, 3)
+
+	// only the last review approves or requests changes
+	rw.Event = strptr(requestChangesEvent)
+	r = splitReviewRequest(rw, n)
+	require.Len(r, 3)
+	require.Equal(commentEvent, r[0].GetEvent())
+	require.Equal(commentEvent, r[1].GetEvent())
+	require.Equal(requestChangesEvent, r[2].GetEvent())
 }
 
 func TestConvertCommentsOutOfRange(t *testing.T) {
@@ -166,16 +228,18 @@ func TestConvertCommentsOutOfRange(t *testing.T) {
 			Text: "out of range comment after",
 		}}
 
-	bodyComments, ghComments := convertComments(context.TODO(), input, dl)
+	bodyComments, ghComments, outOfDiff := convertComments(context.TODO(), input, dl)
 
 	require.Len(bodyComments, 1)
 	require.Len(ghComments, 1)
 
-	require.Equal([]*github.DraftReviewComment{&github.DraftReviewComment{
+	require.Equal([]*draftReviewComment{&draftReviewComment{
 		Path:     strptr("main.go"),
 		Position: intptr(1),
 		Body:     strptr("Line comment"),
 	}}, ghComments)
+
+	require.Equal([]*lookout.Comment{input[0], input[3]}, outOfDiff)
 }
 
 func TestConvertCommentsWrongFile(t *testing.T) {
@@ -205,7 +269,7 @@ func TestConvertCommentsWrongFile(t *testing.T) {
 			Text: "Line comment",
 		}}
 
-	bodyComments, ghComments := convertComments(context.TODO(), input, dl)
+	bodyComments, ghComments, outOfDiff := convertComments(context.TODO(), input, dl)
 
 	require.Len(bodyComments, 2)
 	require.Len(ghComments, 2)
@@ -215,15 +279,153 @@ func TestConvertCommentsWrongFile(t *testing.T) {
 		"Another global comment",
 	}, bodyComments)
 
-	require.Equal([]*github.DraftReviewComment{&github.DraftReviewComment{
+	require.Equal([]*draftReviewComment{&draftReviewComment{
 		Path:     strptr("main.go"),
 		Body:     strptr("File comment"),
 		Position: intptr(1),
-	}, &github.DraftReviewComment{
+	}, &draftReviewComment{
 		Path:     strptr("main.go"),
 		Position: intptr(3),
 		Body:     strptr("Line comment"),
 	}}, ghComments)
+
+	require.Equal([]*lookout.Comment{input[4]}, outOfDiff)
+}
+
+func TestConvertCommentsFix(t *testing.T) {
+	require := require.New(t)
+
+	dl := newDiffLines(&github.CommitsComparison{
+		Files: []github.CommitFile{github.CommitFile{
+			Filename: strptr("main.go"),
+			Patch:    strptr(mockedPatch),
+		}}})
+
+	input := []*lookout.Comment{
+		&lookout.Comment{
+			File: "main.go",
+			Line: 4,
+			Text: "Fixed line",
+			Fix:  &lookout.Fix{StartLine: 4, Text: "fixed()"},
+		}, &lookout.Comment{
+			File: "main.go",
+			Text: "File comment with a fix on another line",
+			Fix:  &lookout.Fix{StartLine: 6, EndLine: 6, Text: "a()\nb()\n"},
+		}, &lookout.Comment{
+			File: "main.go",
+			Line: 7,
+			Text: "Removed line",
+			Fix:  &lookout.Fix{StartLine: 7},
+		}, &lookout.Comment{
+			File: "main.go",
+			Line: 8,
+			Text: "Fix with backticks",
+			Fix:  &lookout.Fix{StartLine: 8, Text: "// ```go"},
+		}, &lookout.Comment{
+			File: "main.go",
+			Line: 9,
+			Text: "Fix of several lines",
+			Fix:  &lookout.Fix{StartLine: 9, EndLine: 10, Text: "fixed()"},
+		}, &lookout.Comment{
+			File: "main.go",
+			Line: 10,
+			Text: "Fix out of the diff",
+			Fix:  &lookout.Fix{StartLine: 1, Text: "fixed()"},
+		}, &lookout.Comment{
+			Text: "Global comment",
+			Fix:  &lookout.Fix{StartLine: 4, Text: "fixed()"},
+		}}
+
+	bodyComments, ghComments, outOfDiff := convertComments(context.TODO(), input, dl)
+
+	require.Equal([]string{"Global comment"}, bodyComments)
+	require.Equal([]*draftReviewComment{&draftReviewComment{
+		Path:     strptr("main.go"),
+		Position: intptr(2),
+		Body:     strptr("Fixed line\n\n```suggestion\nfixed()\n```"),
+	}, &draftReviewComment{
+		Path:     strptr("main.go"),
+		Position: intptr(4),
+		Body:     strptr("File comment with a fix on another line\n\n```suggestion\na()\nb()\n```"),
+	}, &draftReviewComment{
+		Path:     strptr("main.go"),
+		Position: intptr(5),
+		Body:     strptr("Removed line\n\n```suggestion\n```"),
+	}, &draftReviewComment{
+		Path:     strptr("main.go"),
+		Position: intptr(6),
+		Body:     strptr("Fix with backticks\n\n````suggestion\n// ```go\n````"),
+	}, &draftReviewComment{
+		Path:        strptr("main.go"),
+		Body:        strptr("Fix of several lines\n\n```suggestion\nfixed()\n```"),
+		StartLine:   intptr(9),
+		Line:        intptr(10),
+		StartSide:   strptr("RIGHT"),
+		Side:        strptr("RIGHT"),
+		endPosition: 8,
+	}, &draftReviewComment{
+		Path:     strptr("main.go"),
+		Position: intptr(8),
+		Body:     strptr("Fix out of the diff"),
+	}}, ghComments)
+
+	require.Len(outOfDiff, 0)
+}
+
+func TestConvertCommentsRange(t *testing.T) {
+	require := require.New(t)
+
+	dl := newDiffLines(&github.CommitsComparison{
+		Files: []github.CommitFile{github.CommitFile{
+			Filename: strptr("main.go"),
+			Patch:    strptr(mockedPatch),
+		}}})
+
+	input := []*lookout.Comment{
+		&lookout.Comment{
+			File:    "main.go",
+			Line:    4,
+			EndLine: 6,
+			Text:    "Range comment",
+		}, &lookout.Comment{
+			File:    "main.go",
+			Line:    11,
+			EndLine: 14,
+			Text:    "Range out of the diff",
+		}, &lookout.Comment{
+			File:    "main.go",
+			Line:    5,
+			EndLine: 5,
+			Text:    "Single line range",
+		}, &lookout.Comment{
+			File:    "main.go",
+			Line:    1,
+			EndLine: 4,
+			Text:    "Range starting out of the diff",
+		}}
+
+	bodyComments, ghComments, outOfDiff := convertComments(context.TODO(), input, dl)
+
+	require.Len(bodyComments, 0)
+	require.Equal([]*draftReviewComment{&draftReviewComment{
+		Path:        strptr("main.go"),
+		Body:        strptr("Range comment"),
+		StartLine:   intptr(4),
+		Line:        intptr(6),
+		StartSide:   strptr("RIGHT"),
+		Side:        strptr("RIGHT"),
+		endPosition: 4,
+	}, &draftReviewComment{
+		Path:     strptr("main.go"),
+		Position: intptr(9),
+		Body:     strptr("Range out of the diff"),
+	}, &draftReviewComment{
+		Path:     strptr("main.go"),
+		Position: intptr(3),
+		Body:     strptr("Single line range"),
+	}}, ghComments)
+
+	require.Equal([]*lookout.Comment{input[3]}, outOfDiff)
 }
 
 func TestCouldNotExecuteFooterTemplate(t *testing.T) {
@@ -234,3 +436,122 @@ func TestCouldNotExecuteFooterTemplate(t *testing.T) {
 	commentsWrongTemplate := addFootnote(context.TODO(), "comments", unkonwnDataTemplate, nil)
 	require.Equal("comments", commentsWrongTemplate)
 }
+
+func TestCreateReviewRequestPartial(t *testing.T) {
+	require := require.New(t)
+
+	dl := newDiffLines(&github.CommitsComparison{
+		Files: []github.CommitFile{github.CommitFile{
+			Filename: strptr("main.go"),
+			Patch:    strptr(mockedPatch),
+		}}})
+
+	p := &Poster{}
+	req, err := p.createReviewRequest(context.TODO(), []lookout.AnalyzerComments{{
+		Config:  lookout.AnalyzerConfig{Name: "mock"},
+		Partial: true,
+		Comments: []*lookout.Comment{
+			&lookout.Comment{
+				Text: "Global comment",
+			}},
+	}}, dl, "commit", nil, lookout.CommentReviewAction)
+	require.NoError(err)
+
+	require.Equal(
+		"_The analyzer mock timed out, its comments are partial results._\n\nGlobal comment",
+		req.GetBody())
+}
+
+func TestOutOfDiffSummary(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal("", outOfDiffSummary(nil))
+
+	summary := outOfDiffSummary([]*lookout.Comment{
+		{File: "main.go", Line: 20, Text: "Unused function"},
+		{File: "lib.go", Line: 3, EndLine: 5, Text: "Long\nexplanation"},
+		{File: "main.go", Line: 1, Text: "Missing license"},
+	})
+
+	require.Equal(`<details>
+<summary>Findings outside the diff (3)</summary>
+
+**lib.go**
+
+- Lines 3-5: Long
+  explanation
+
+**main.go**
+
+- Line 1: Missing license
+- Line 20: Unused function
+
+</details>`, summary)
+}
+
+func TestCreateReviewRequestOutOfDiff(t *testing.T) {
+	dl := newDiffLines(&github.CommitsComparison{
+		Files: []github.CommitFile{github.CommitFile{
+			Filename: strptr("main.go"),
+			Patch:    strptr(mockedPatch),
+		}, github.CommitFile{
+			Filename: strptr("renamed.go"),
+		}}})
+
+	comments := []*lookout.Comment{
+		{File: "main.go", Line: 5, Text: "Line comment"},
+		{File: "main.go", Line: 20, Text: "Unused function"},
+		{File: "renamed.go", Line: 4, Text: "Renamed file"},
+	}
+
+	summary := func(cs ...*lookout.Comment) string {
+		return outOfDiffSummary(cs)
+	}
+
+	testCases := []struct {
+		mode     string
+		body     string
+		comments []*draftReviewComment
+	}{{
+		mode: "",
+		body: summary(comments[1], comments[2]),
+		comments: []*draftReviewComment{
+			{Path: strptr("main.go"), Position: intptr(3), Body: strptr("Line comment")},
+		},
+	}, {
+		mode: lookout.SummaryOutOfDiff,
+		body: summary(comments[1], comments[2]),
+		comments: []*draftReviewComment{
+			{Path: strptr("main.go"), Position: intptr(3), Body: strptr("Line comment")},
+		},
+	}, {
+		mode: lookout.FileOutOfDiff,
+		body: summary(comments[2]),
+		comments: []*draftReviewComment{
+			{Path: strptr("main.go"), Body: strptr("Unused function\n\n_Line 20_"), SubjectType: strptr("file")},
+			{Path: strptr("main.go"), Position: intptr(3), Body: strptr("Line comment")},
+		},
+	}, {
+		mode: lookout.DropOutOfDiff,
+		body: "",
+		comments: []*draftReviewComment{
+			{Path: strptr("main.go"), Position: intptr(3), Body: strptr("Line comment")},
+		},
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.mode, func(t *testing.T) {
+			require := require.New(t)
+
+			p := &Poster{}
+			req, err := p.createReviewRequest(context.TODO(), []lookout.AnalyzerComments{{
+				Config:   lookout.AnalyzerConfig{Name: "mock", OutOfDiff: tc.mode},
+				Comments: comments,
+			}}, dl, "commit", nil, lookout.CommentReviewAction)
+			require.NoError(err)
+
+			require.Equal(tc.body, req.GetBody())
+			require.Equal(tc.comments, req.Comments)
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/src-d/lookout"
+	"github.com/src-d/lookout/util/cron"
+	"github.com/src-d/lookout/util/ctxlog"
+
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	log "gopkg.in/src-d/go-log.v1"
+)
+
+// ScheduleConfig defines a scheduled analysis of the watched repositories
+type ScheduleConfig struct {
+	// Cron is the cron expression of the analysis times, in UTC
+	Cron string `yaml:"cron"`
+	// Repositories are the repositories to analyze, like
+	// github.com/src-d/lookout. Empty means all the watched repositories.
+	Repositories []string `yaml:"repositories"`
+	// Branches are the branches to analyze. Empty means the default branch
+	// of each repository.
+	Branches []string `yaml:"branches"`
+}
+
+type schedule struct {
+	ScheduleConfig
+	cron *cron.Schedule
+}
+
+// Scheduler is a lookout.Watcher that triggers push events for the current
+// head of the branches of the repositories at scheduled times, even if
+// nothing was pushed
+type Scheduler struct {
+	pool      *ClientPool
+	schedules []*schedule
+	now       func() time.Time
+}
+
+var _ lookout.Watcher = &Scheduler{}
+
+// NewScheduler returns a new Scheduler for the repositories in the pool
+func NewScheduler(pool *ClientPool, conf []ScheduleConfig) (*Scheduler, error) {
+	s := &Scheduler{pool: pool, now: time.Now}
+	for _, c := range conf {
+		cs, err := cron.Parse(c.Cron)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule %q: %s", c.Cron, err)
+		}
+
+		s.schedules = append(s.schedules, &schedule{ScheduleConfig: c, cron: cs})
+	}
+
+	return s, nil
+}
+
+// Watch waits for the scheduled times, and triggers the EventHandler with a
+// push event for each scheduled branch. It stops when the context is
+// canceled or the EventHandler returns an error.
+func (s *Scheduler) Watch(ctx context.Context, cb lookout.EventHandler) error {
+	ctxlog.Get(ctx).With(log.Fields{"schedules": len(s.schedules)}).Infof("Starting scheduler")
+
+	for {
+		at, due := s.next(s.now().UTC())
+		if len(due) == 0 {
+			ctxlog.Get(ctx).Warningf("scheduler stopped, there are no more scheduled times")
+			<-ctx.Done()
+			return ctx.Err()
+		}
+
+		timer := time.NewTimer(at.Sub(s.now()))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		for _, sch := range due {
+			if err := s.run(ctx, sch, at, cb); err != nil {
+				if lookout.NoErrStopWatcher.Is(err) {
+					return nil
+				}
+
+				return err
+			}
+		}
+	}
+}
+
+// next returns the next scheduled time after t, and the schedules due then
+func (s *Scheduler) next(t time.Time) (time.Time, []*schedule) {
+	var at time.Time
+	var due []*schedule
+	for _, sch := range s.schedules {
+		next := sch.cron.Next(t)
+		switch {
+		case next.IsZero():
+		case at.IsZero() || next.Before(at):
+			at = next
+			due = []*schedule{sch}
+		case next.Equal(at):
+			due = append(due, sch)
+		}
+	}
+
+	return at, due
+}
+
+// run triggers the events of a schedule. API errors are logged, and the
+// repository is skipped.
+func (s *Scheduler) run(ctx context.Context, sch *schedule, at time.Time, cb lookout.EventHandler) error {
+	for client, repos := range s.pool.Clients() {
+		for _, repo := range repos {
+			if !sch.includes(repo) {
+				continue
+			}
+
+			ctx, logger := ctxlog.WithLogFields(ctx, log.Fields{
+				"repository": repo.FullName,
+				"schedule":   sch.Cron,
+			})
+
+			events, err := scheduledEvents(ctx, client, repo, sch.Branches, at)
+			if err != nil {
+				logger.Errorf(err, "scheduled analysis failed")
+				continue
+			}
+
+			for _, e := range events {
+				if err := cb(ctx, e); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (sch *schedule) includes(repo *repositoryInfo) bool {
+	if len(sch.Repositories) == 0 {
+		return true
+	}
+
+	for _, r := range sch.Repositories {
+		if r == repo.FullName || r == repo.Host+"/"+repo.FullName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// scheduledEvents returns the push events for the current head of the
+// branches of the repository. The events have no base hash, so the changes
+// are all the files of the head.
+func scheduledEvents(
+	ctx context.Context,
+	client *Client,
+	repo *repositoryInfo,
+	branches []string,
+	at time.Time,
+) ([]lookout.Event, error) {
+	if len(branches) == 0 {
+		r, resp, err := client.Repositories.Get(ctx, repo.Owner, repo.Name)
+		if err = handleAPIError(resp, err, "repository could not be read"); err != nil {
+			return nil, err
+		}
+
+		branches = []string{r.GetDefaultBranch()}
+	}
+
+	var events []lookout.Event
+	for _, branch := range branches {
+		b, resp, err := client.Repositories.GetBranch(ctx, repo.Owner, repo.Name, branch)
+		if err = handleAPIError(resp, err, "branch could not be read"); err != nil {
+			return nil, err
+		}
+
+		ref := plumbing.NewBranchReferenceName(branch)
+
+		pe := &lookout.PushEvent{}
+		pe.Provider = Provider
+		pe.InternalID = fmt.Sprintf("schedule/%s/%s/%d", repo.FullName, branch, at.Unix())
+		pe.CreatedAt = at
+		pe.Head = lookout.ReferencePointer{
+			InternalRepositoryURL: repo.CloneURL,
+			ReferenceName:         ref,
+			Hash:                  b.GetCommit().GetSHA(),
+		}
+		pe.Base = lookout.ReferencePointer{
+			InternalRepositoryURL: repo.CloneURL,
+			ReferenceName:         ref,
+		}
+		pe.OrganizationID = repo.OrganizationID
+
+		events = append(events, pe)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,172 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/src-d/lookout"
+	"github.com/src-d/lookout/util/cache"
+
+	"github.com/google/go-github/github"
+	"github.com/gregjones/httpcache"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+)
+
+type SchedulerTestSuite struct {
+	suite.Suite
+	mux    *http.ServeMux
+	server *httptest.Server
+	pool   *ClientPool
+}
+
+func (s *SchedulerTestSuite) SetupTest() {
+	s.mux = http.NewServeMux()
+	s.server = httptest.NewServer(mockPermissions(s.mux))
+
+	cache := cache.NewValidableCache(httpcache.NewMemoryCache())
+	githubURL, _ := url.Parse(s.server.URL + "/")
+
+	repoURLs := []string{"github.com/foo/bar", "github.com/foo/baz"}
+	s.pool = newTestPool(s.Suite, repoURLs, githubURL, cache, false)
+}
+
+func (s *SchedulerTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *SchedulerTestSuite) handleBranch(repo, branch, sha string) {
+	s.mux.HandleFunc("/repos/foo/"+repo+"/branches/"+branch, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(&github.Branch{
+			Name:   strptr(branch),
+			Commit: &github.RepositoryCommit{SHA: strptr(sha)},
+		})
+	})
+}
+
+func (s *SchedulerTestSuite) TestDefaultBranch() {
+	s.mux.HandleFunc("/repos/foo/bar", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(&github.Repository{DefaultBranch: strptr("develop")})
+	})
+	s.handleBranch("bar", "develop", hash1)
+
+	sch, err := NewScheduler(s.pool, []ScheduleConfig{{
+		Cron:         "@daily",
+		Repositories: []string{"github.com/foo/bar"},
+	}})
+	s.Require().NoError(err)
+
+	at := time.Date(2019, 1, 17, 0, 0, 0, 0, time.UTC)
+	var events []lookout.Event
+	err = sch.run(context.Background(), sch.schedules[0], at,
+		func(ctx context.Context, e lookout.Event) error {
+			events = append(events, e)
+			return nil
+		})
+	s.NoError(err)
+	s.Require().Len(events, 1)
+
+	pe, ok := events[0].(*lookout.PushEvent)
+	s.Require().True(ok)
+	s.Equal(Provider, pe.Provider)
+	s.Equal(at, pe.CreatedAt)
+	s.Equal(lookout.ReferencePointer{
+		InternalRepositoryURL: "https://github.com/foo/bar.git",
+		ReferenceName:         plumbing.ReferenceName("refs/heads/develop"),
+		Hash:                  hash1,
+	}, pe.Head)
+	s.Equal(lookout.ReferencePointer{
+		InternalRepositoryURL: "https://github.com/foo/bar.git",
+		ReferenceName:         plumbing.ReferenceName("refs/heads/develop"),
+	}, pe.Base)
+
+	// a later activation is a different event
+	err = sch.run(context.Background(), sch.schedules[0], at.Add(24*time.Hour),
+		func(ctx context.Context, e lookout.Event) error {
+			events = append(events, e)
+			return nil
+		})
+	s.NoError(err)
+	s.Require().Len(events, 2)
+	s.NotEqual(events[0].ID(), events[1].ID())
+}
+
+func (s *SchedulerTestSuite) TestBranches() {
+	s.handleBranch("bar", "master", hash1)
+	s.handleBranch("bar", "release", hash2)
+	s.handleBranch("baz", "master", hash2)
+	s.handleBranch("baz", "release", hash1)
+
+	sch, err := NewScheduler(s.pool, []ScheduleConfig{{
+		Cron:     "0 3 * * *",
+		Branches: []string{"master", "release"},
+	}})
+	s.Require().NoError(err)
+
+	heads := make(map[string]string)
+	err = sch.run(context.Background(), sch.schedules[0], time.Now(),
+		func(ctx context.Context, e lookout.Event) error {
+			pe := e.(*lookout.PushEvent)
+			heads[pe.Head.InternalRepositoryURL+"@"+pe.Head.ReferenceName.Short()] = pe.Head.Hash
+			return nil
+		})
+	s.NoError(err)
+	s.Equal(map[string]string{
+		"https://github.com/foo/bar.git@master":  hash1,
+		"https://github.com/foo/bar.git@release": hash2,
+		"https://github.com/foo/baz.git@master":  hash2,
+		"https://github.com/foo/baz.git@release": hash1,
+	}, heads)
+}
+
+func (s *SchedulerTestSuite) TestAPIError() {
+	s.handleBranch("baz", "master", hash2)
+
+	sch, err := NewScheduler(s.pool, []ScheduleConfig{{
+		Cron:     "@hourly",
+		Branches: []string{"master"},
+	}})
+	s.Require().NoError(err)
+
+	var events []lookout.Event
+	err = sch.run(context.Background(), sch.schedules[0], time.Now(),
+		func(ctx context.Context, e lookout.Event) error {
+			events = append(events, e)
+			return nil
+		})
+	s.NoError(err)
+	s.Require().Len(events, 1)
+	s.Equal("https://github.com/foo/baz.git", events[0].Revision().Head.InternalRepositoryURL)
+}
+
+func (s *SchedulerTestSuite) TestNext() {
+	sch, err := NewScheduler(s.pool, []ScheduleConfig{
+		{Cron: "0 3 * * *"},
+		{Cron: "0 */6 * * *"},
+		{Cron: "0 0 30 2 *"},
+	})
+	s.Require().NoError(err)
+
+	t := time.Date(2019, 1, 16, 1, 0, 0, 0, time.UTC)
+	at, due := sch.next(t)
+	s.Equal(time.Date(2019, 1, 16, 3, 0, 0, 0, time.UTC), at)
+	s.Equal([]*schedule{sch.schedules[0]}, due)
+
+	at, due = sch.next(at)
+	s.Equal(time.Date(2019, 1, 16, 6, 0, 0, 0, time.UTC), at)
+	s.Equal([]*schedule{sch.schedules[1]}, due)
+}
+
+func (s *SchedulerTestSuite) TestInvalidCron() {
+	_, err := NewScheduler(s.pool, []ScheduleConfig{{Cron: "every day"}})
+	s.Error(err)
+}
+
+func TestSchedulerTestSuite(t *testing.T) {
+	suite.Run(t, new(SchedulerTestSuite))
+}
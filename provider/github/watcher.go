@@ -10,6 +10,7 @@ import (
 	"github.com/src-d/lookout/util/ctxlog"
 
 	"github.com/google/go-github/github"
+	lru "github.com/hashicorp/golang-lru"
 	errors "gopkg.in/src-d/go-errors.v1"
 	log "gopkg.in/src-d/go-log.v1"
 )
@@ -19,10 +20,14 @@ const Provider = "github"
 // ProviderConfig represents the yml config
 type ProviderConfig struct {
 	CommentFooter            string `yaml:"comment_footer"`
+	StatusTargetURL          string `yaml:"status_target_url"`
 	PrivateKey               string `yaml:"private_key"`
 	AppID                    int    `yaml:"app_id"`
 	InstallationSyncInterval string `yaml:"installation_sync_interval"`
 	WatchMinInterval         string `yaml:"watch_min_interval"`
+	// PushResults are the destinations of the comments of push events:
+	// commit_comment, commit_status or issue. Empty means they are not posted.
+	PushResults []string `yaml:"push_results"`
 }
 
 // don't call github more often than
@@ -42,15 +47,26 @@ type Watcher struct {
 	// maps clients to functions that stop watching the client
 	stopFuncs               map[*Client]func()
 	lastErrPR, lastErrEvent map[*repositoryInfo]*errThrottlerState
+	// commands keeps the ids of the comments with commands already handled
+	commands *lru.Cache
 }
 
+// commandsCacheSize is the number of handled comment commands remembered
+const commandsCacheSize = 10000
+
 // NewWatcher returns a new
 func NewWatcher(pool *ClientPool) (*Watcher, error) {
+	commands, err := lru.New(commandsCacheSize)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Watcher{
 		pool:         pool,
 		stopFuncs:    make(map[*Client]func()),
 		lastErrPR:    make(map[*repositoryInfo]*errThrottlerState),
 		lastErrEvent: make(map[*repositoryInfo]*errThrottlerState),
+		commands:     commands,
 	}, nil
 }
 
@@ -293,10 +309,14 @@ func (w *Watcher) handleEvents(
 
 	ctx, logger := ctxlog.WithLogFields(ctx, log.Fields{"repository": r.CloneURL})
 
+	// if a request to handle an event failed, the events list is not cached
+	// so the event is handled again the next time it is read
+	var retry bool
 	for _, e := range events {
-		event, err := w.handleEvent(r, e)
+		event, err := w.handleEvent(ctx, client, r, e)
 		if err != nil {
 			logger.Errorf(err, "error handling event")
+			retry = retry || ErrGitHubAPI.Is(err)
 			continue
 		}
 
@@ -309,12 +329,27 @@ func (w *Watcher) handleEvents(
 		}
 	}
 
+	if retry {
+		logger.Debugf("request to %s not cached, some events failed", resp.Request.URL)
+		return nil
+	}
+
 	logger.Debugf("request to %s cached", resp.Request.URL)
 
 	return client.Validate(resp.Request.URL.String())
 }
 
-func (w *Watcher) handleEvent(r *repositoryInfo, e *github.Event) (lookout.Event, error) {
+func (w *Watcher) handleEvent(
+	ctx context.Context,
+	client *Client,
+	r *repositoryInfo,
+	e *github.Event,
+) (lookout.Event, error) {
+	// the comments on pull requests can contain commands for lookout
+	if e.GetType() == "IssueCommentEvent" {
+		return w.castIssueCommentEvent(ctx, client, r, e)
+	}
+
 	return castEvent(r, e)
 }
 
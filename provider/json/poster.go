@@ -26,13 +26,17 @@ func NewPoster(w io.Writer) *Poster {
 	}
 }
 
-// Post prints json comments to stdout
+// Post prints json comments to stdout, the review action is ignored
 func (p *Poster) Post(ctx context.Context, e lookout.Event,
-	aCommentsList []lookout.AnalyzerComments, safe bool) error {
+	aCommentsList []lookout.AnalyzerComments, safe bool, action lookout.ReviewAction) error {
 
 	for _, a := range aCommentsList {
 		for _, c := range a.Comments {
-			if err := p.enc.Encode(commentToPrint{AnalyzerName: a.Config.Name, Comment: c}); err != nil {
+			if err := p.enc.Encode(commentToPrint{
+				AnalyzerName: a.Config.Name,
+				Partial:      a.Partial,
+				Comment:      c,
+			}); err != nil {
 				return err
 			}
 		}
@@ -49,7 +53,21 @@ func (p *Poster) Status(ctx context.Context, e lookout.Event,
 	return nil
 }
 
+// AnalyzerStatus prints the new analyzer status to the log
+func (p *Poster) AnalyzerStatus(ctx context.Context, e lookout.Event,
+	st lookout.AnalyzerStatus) error {
+
+	ctxlog.Get(ctx).With(log.Fields{
+		"analyzer":    st.Analyzer,
+		"status":      st.Status,
+		"description": st.Description,
+	}).Infof("New analyzer status")
+	return nil
+}
+
 type commentToPrint struct {
 	AnalyzerName string `json:"analyzer-name"`
+	// Partial is true if the analyzer timed out before finishing
+	Partial bool `json:"partial,omitempty"`
 	*lookout.Comment
 }
@@ -60,7 +60,7 @@ func TestPoster_Post_OK(t *testing.T) {
 		Comments: cs,
 	}}
 
-	err := p.Post(context.Background(), ev, aCommentsList, false)
+	err := p.Post(context.Background(), ev, aCommentsList, false, lookout.CommentReviewAction)
 	require.NoError(err)
 
 	expected := `{"analyzer-name":"mock","text":"This is a global comment"}
@@ -71,3 +71,36 @@ func TestPoster_Post_OK(t *testing.T) {
 
 	require.Equal(expected, b.String())
 }
+
+func TestPoster_Post_Fix(t *testing.T) {
+	require := require.New(t)
+
+	var b bytes.Buffer
+
+	p := NewPoster(&b)
+	cs := []*lookout.Comment{&lookout.Comment{
+		File: "main.go",
+		Line: 5,
+		Text: "This is a line comment with a fix",
+		Fix: &lookout.Fix{
+			StartLine: 5,
+			EndLine:   6,
+			Text:      "fixed()",
+		},
+	}}
+
+	aCommentsList := []lookout.AnalyzerComments{lookout.AnalyzerComments{
+		Config: lookout.AnalyzerConfig{
+			Name: "mock",
+		},
+		Comments: cs,
+	}}
+
+	err := p.Post(context.Background(), &lookout.ReviewEvent{}, aCommentsList, false, lookout.CommentReviewAction)
+	require.NoError(err)
+
+	expected := `{"analyzer-name":"mock","file":"main.go","line":5,"text":"This is a line comment with a fix","fix":{"start_line":5,"end_line":6,"text":"fixed()"}}
+`
+
+	require.Equal(expected, b.String())
+}
@@ -135,6 +135,21 @@ func RunEventDequeuer(
 
 			jobCtx, _ := ctxlog.WithLogFields(ctx, qJob.LogFields)
 			err = eventHandler(jobCtx, event)
+			if retryErr, ok := err.(*lookout.RetryEventError); ok {
+				ctxlog.Get(jobCtx).With(log.Fields{
+					"delay": retryErr.Delay,
+				}).Warningf("queue job will be retried: %s", retryErr)
+
+				if err := republishDelayed(q, qJob, retryErr.Delay); err != nil {
+					ctxlog.Get(jobCtx).Errorf(err, "queue job republish failed")
+					consumedJob.Reject(true)
+					return
+				}
+
+				consumedJob.Ack()
+				return
+			}
+
 			if err != nil {
 				ctxlog.Get(jobCtx).Errorf(err, "error handling the queue job")
 				consumedJob.Reject(true)
@@ -145,3 +160,16 @@ func RunEventDequeuer(
 		}(consumedJob)
 	}
 }
+
+func republishDelayed(q queue.Queue, qJob QueueJob, delay time.Duration) error {
+	j, err := queue.NewJob()
+	if err != nil {
+		return err
+	}
+
+	if err := j.Encode(qJob); err != nil {
+		return err
+	}
+
+	return q.PublishDelayed(j, delay)
+}
@@ -189,6 +189,38 @@ func (s *EventEnqueuerTestSuite) TestWithCache() {
 	nextEOF(t, iter)
 }
 
+func (s *EventEnqueuerTestSuite) TestWithCacheRerun() {
+	// A rerun of an enqueued event is enqueued too, with its request
+
+	t := s.T()
+	q := initQueue(t, "memoryfinite://")
+
+	rerun := mockEventA
+	rerun.Rerun = &lookout.Rerun{ID: "1", Analyzer: "style"}
+
+	handler := lookout.CachedHandler(EventEnqueuer(context.TODO(), q))
+	handler(context.TODO(), &mockEventA)
+	handler(context.TODO(), &rerun)
+
+	advertisedWindow := 0 // ignored by memory brokers
+	iter, err := q.Consume(advertisedWindow)
+	assert.NoError(t, err)
+
+	nextOK(t, iter)
+
+	retrievedJob, err := iter.Next()
+	require.NoError(t, err)
+
+	var qJob QueueJob
+	require.NoError(t, retrievedJob.Decode(&qJob))
+	qEv, err := qJob.Event()
+	require.NoError(t, err)
+	require.Equal(t, rerun.Rerun, qEv.(*lookout.ReviewEvent).Rerun)
+	require.Equal(t, rerun.ID(), qEv.ID())
+
+	nextEOF(t, iter)
+}
+
 func TestEventEnqueuerTestSuite(t *testing.T) {
 	suite.Run(t, new(EventEnqueuerTestSuite))
 }
@@ -289,6 +321,32 @@ func (s *EventDequeuerTestSuite) TestSimple() {
 	assert.Equal(t, 2, calls)
 }
 
+func (s *EventDequeuerTestSuite) TestRetry() {
+	t := s.T()
+	q := initQueue(t, "memory://")
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	var calls int32
+	handler := func(context.Context, lookout.Event) error {
+		defer wg.Done()
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return &lookout.RetryEventError{Delay: 10 * time.Millisecond}
+		}
+
+		return nil
+	}
+
+	go RunEventDequeuer(context.TODO(), q, handler, 1)
+
+	enq := EventEnqueuer(context.TODO(), q)
+	enq(context.TODO(), &mockEventA)
+
+	wg.Wait()
+	assert.EqualValues(t, 2, atomic.LoadInt32(&calls))
+}
+
 func (s *EventDequeuerTestSuite) TestConcurrent() {
 	t := s.T()
 	testCases := []int{1, 2, 13, 150}
@@ -0,0 +1,79 @@
+package lookout
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	log "gopkg.in/src-d/go-log.v1"
+	"gopkg.in/src-d/lookout-sdk.v0/pb"
+)
+
+// AnalyzerRegistration describes an analyzer replica announced to the server
+type AnalyzerRegistration = pb.AnalyzerRegistration
+
+// RegistrationResponse is the reply of the server to a registration
+type RegistrationResponse = pb.RegistrationResponse
+
+type RegistryClient = pb.RegistryClient
+type RegistryServer = pb.RegistryServer
+
+func RegisterRegistryServer(s *grpc.Server, srv RegistryServer) {
+	pb.RegisterRegistryServer(s, srv)
+}
+
+func NewRegistryClient(conn *grpc.ClientConn) RegistryClient {
+	return pb.NewRegistryClient(conn)
+}
+
+// registrationRetryInterval is the time to wait to register again after a
+// failed registration, and the minimum time between heartbeats
+var registrationRetryInterval = 5 * time.Second
+
+// KeepRegistered registers the analyzer replica in the server, and sends the
+// heartbeats that keep it registered until the context is canceled. Then the
+// replica is unregistered.
+func KeepRegistered(ctx context.Context, client RegistryClient, reg *AnalyzerRegistration) error {
+	logger := log.With(log.Fields{
+		"analyzer": reg.Name,
+		"addr":     reg.Addr,
+	})
+
+	registered := false
+	for {
+		wait := registrationRetryInterval
+		resp, err := client.Register(ctx, reg)
+		switch {
+		case ctx.Err() != nil:
+		case err != nil:
+			logger.Errorf(err, "analyzer registration failed")
+		default:
+			if !registered {
+				logger.Infof("analyzer registered")
+			}
+
+			registered = true
+			// register again well before the registration expires, so a
+			// failed heartbeat can be retried, but not in a busy loop if the
+			// server returns a short ttl
+			wait = resp.Ttl / 3
+			if wait < registrationRetryInterval {
+				wait = registrationRetryInterval
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if !registered {
+				return nil
+			}
+
+			unregisterCtx, cancel := context.WithTimeout(context.Background(), registrationRetryInterval)
+			defer cancel()
+
+			_, err := client.Unregister(unregisterCtx, reg)
+			return err
+		case <-time.After(wait):
+		}
+	}
+}
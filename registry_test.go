@@ -0,0 +1,89 @@
+package lookout
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+type registryClientMock struct {
+	sync.Mutex
+	registers   int
+	unregisters int
+	fail        int
+	ttl         time.Duration
+}
+
+func (c *registryClientMock) Register(ctx context.Context, in *AnalyzerRegistration, opts ...grpc.CallOption) (*RegistrationResponse, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.registers++
+	if c.registers <= c.fail {
+		return nil, errors.New("unavailable")
+	}
+
+	return &RegistrationResponse{Ttl: c.ttl}, nil
+}
+
+func (c *registryClientMock) Unregister(ctx context.Context, in *AnalyzerRegistration, opts ...grpc.CallOption) (*RegistrationResponse, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.unregisters++
+	return &RegistrationResponse{}, nil
+}
+
+func TestKeepRegistered(t *testing.T) {
+	require := require.New(t)
+
+	defer func(d time.Duration) { registrationRetryInterval = d }(registrationRetryInterval)
+	registrationRetryInterval = 10 * time.Millisecond
+
+	client := &registryClientMock{fail: 1, ttl: 30 * time.Millisecond}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	err := KeepRegistered(ctx, client, &AnalyzerRegistration{
+		Name: "analyzer",
+		Addr: "ipv4://localhost:9930",
+	})
+	require.NoError(err)
+
+	client.Lock()
+	defer client.Unlock()
+
+	// the first registration fails and is retried, then the heartbeats are
+	// sent every 10ms
+	require.True(client.registers > 3, "registers: %d", client.registers)
+	require.Equal(1, client.unregisters)
+}
+
+func TestKeepRegisteredNoTTL(t *testing.T) {
+	require := require.New(t)
+
+	defer func(d time.Duration) { registrationRetryInterval = d }(registrationRetryInterval)
+	registrationRetryInterval = 10 * time.Millisecond
+
+	client := &registryClientMock{}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	err := KeepRegistered(ctx, client, &AnalyzerRegistration{
+		Name: "analyzer",
+		Addr: "ipv4://localhost:9930",
+	})
+	require.NoError(err)
+
+	client.Lock()
+	defer client.Unlock()
+
+	// the heartbeats are sent at least 10ms apart
+	require.True(client.registers <= 11, "registers: %d", client.registers)
+	require.Equal(1, client.unregisters)
+}
@@ -0,0 +1,163 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/src-d/lookout"
+	"github.com/src-d/lookout/util/ctxlog"
+
+	log "gopkg.in/src-d/go-log.v1"
+)
+
+// ValidateAutofix checks that the autofix mode of the analyzers is known
+func ValidateAutofix(analyzers []lookout.AnalyzerConfig) error {
+	for _, a := range analyzers {
+		switch a.Autofix {
+		case "", lookout.PullRequestAutofix, lookout.PushAutofix:
+		default:
+			return fmt.Errorf("unknown autofix mode for analyzer %s: %s", a.Name, a.Autofix)
+		}
+	}
+
+	return nil
+}
+
+// autofix applies the fixes of the comments of the analyzers with autofix
+// enabled. The applied comments are replaced with a global comment linking to
+// the fixes, the conflicting ones are posted as they are. If the fixes can't
+// be applied all the comments are posted. The comments already posted, or
+// fixed, in a previous analysis of the pull request are not fixed again.
+func (s *Server) autofix(
+	ctx context.Context,
+	e *lookout.ReviewEvent,
+	conf map[string]lookout.AnalyzerConfig,
+	comments []lookout.AnalyzerComments,
+) []lookout.AnalyzerComments {
+	res := make([]lookout.AnalyzerComments, len(comments))
+	for i, group := range comments {
+		res[i] = group
+
+		if group.Config.Autofix == "" || !hasFixes(group.Comments) {
+			continue
+		}
+
+		logger := ctxlog.Get(ctx).With(log.Fields{
+			"analyzer": group.Config.Name,
+			"autofix":  group.Config.Autofix,
+		})
+
+		if s.fixer == nil {
+			logger.Warningf("autofix is not available, the fixes are posted as comments")
+			continue
+		}
+
+		if group.Config.Autofix == lookout.PushAutofix && !conf[group.Config.Name].AutofixPush {
+			logger.Infof("the repository did not opt in to push the fixes, they are posted as comments")
+			continue
+		}
+
+		pending, err := s.notPosted(ctx, e, group.Comments)
+		if err != nil {
+			logger.Errorf(err, "posted comments check failed, the fixes are posted as comments")
+			continue
+		}
+
+		if !hasFixes(pending) {
+			logger.Debugf("the fixes were already applied or posted")
+			continue
+		}
+
+		fixed, err := s.fixer.Fix(ctx, e, group.Config.Autofix, group.Config.Name, pending)
+		if err != nil {
+			logger.Errorf(err, "fixes could not be applied, they are posted as comments")
+			continue
+		}
+
+		// the applied comments are saved as posted, so a retry or a rerun of
+		// the event does not push them again
+		for _, c := range fixed.Applied {
+			if err := s.commentOp.Save(ctx, e, c, group.Config.Name); err != nil {
+				logger.Errorf(err, "can't save the fixed comment")
+			}
+		}
+
+		logger.With(log.Fields{
+			"applied":   len(fixed.Applied),
+			"conflicts": len(fixed.Conflicts),
+		}).Infof("fixes applied")
+
+		res[i].Comments = fixedComments(group.Comments, fixed)
+	}
+
+	return res
+}
+
+// notPosted returns the comments that were not posted in a previous analysis
+// of the pull request
+func (s *Server) notPosted(
+	ctx context.Context,
+	e *lookout.ReviewEvent,
+	comments []*lookout.Comment,
+) ([]*lookout.Comment, error) {
+	var res []*lookout.Comment
+	for _, c := range comments {
+		posted, err := s.commentOp.Posted(ctx, e, c)
+		if err != nil {
+			return nil, err
+		}
+
+		if !posted {
+			res = append(res, c)
+		}
+	}
+
+	return res, nil
+}
+
+func hasFixes(comments []*lookout.Comment) bool {
+	for _, c := range comments {
+		if c.Fix != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fixedComments returns the comments without the applied ones, and a global
+// comment with the summary of the fixes
+func fixedComments(comments []*lookout.Comment, fixed *lookout.FixResult) []*lookout.Comment {
+	if len(fixed.Applied) == 0 && len(fixed.Conflicts) == 0 {
+		return comments
+	}
+
+	applied := make(map[*lookout.Comment]bool, len(fixed.Applied))
+	for _, c := range fixed.Applied {
+		applied[c] = true
+	}
+
+	var summary string
+	if len(fixed.Applied) > 0 {
+		summary = fmt.Sprintf("The fixes of %d comments were applied in %s",
+			len(fixed.Applied), fixed.URL)
+	}
+
+	if len(fixed.Conflicts) > 0 {
+		if summary != "" {
+			summary += "\n\n"
+		}
+
+		summary += fmt.Sprintf("The fixes of %d comments could not be applied, "+
+			"they conflict with other fixes or don't match the files", len(fixed.Conflicts))
+	}
+
+	res := []*lookout.Comment{{Text: summary}}
+	for _, c := range comments {
+		if !applied[c] {
+			res = append(res, c)
+		}
+	}
+
+	return res
+}
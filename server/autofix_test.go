@@ -0,0 +1,49 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/src-d/lookout"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAutofix(t *testing.T) {
+	require := require.New(t)
+
+	require.NoError(ValidateAutofix([]lookout.AnalyzerConfig{
+		{Name: "a"},
+		{Name: "b", Autofix: lookout.PullRequestAutofix},
+		{Name: "c", Autofix: lookout.PushAutofix},
+	}))
+
+	err := ValidateAutofix([]lookout.AnalyzerConfig{{Name: "a", Autofix: "commit"}})
+	require.EqualError(err, "unknown autofix mode for analyzer a: commit")
+}
+
+func TestFixedComments(t *testing.T) {
+	require := require.New(t)
+
+	applied := &lookout.Comment{Text: "applied", Fix: &lookout.Fix{StartLine: 1}}
+	conflict := &lookout.Comment{Text: "conflict", Fix: &lookout.Fix{StartLine: 1}}
+	other := &lookout.Comment{Text: "other"}
+	comments := []*lookout.Comment{applied, conflict, other}
+
+	res := fixedComments(comments, &lookout.FixResult{
+		Applied: []*lookout.Comment{applied},
+		URL:     "https://github.com/foo/bar/commit/1",
+	})
+	require.Len(res, 3)
+	require.Equal("The fixes of 1 comments were applied in https://github.com/foo/bar/commit/1", res[0].Text)
+	require.Equal([]*lookout.Comment{conflict, other}, res[1:])
+
+	res = fixedComments(comments, &lookout.FixResult{
+		Conflicts: []*lookout.Comment{applied, conflict},
+	})
+	require.Len(res, 4)
+	require.Equal("The fixes of 2 comments could not be applied, "+
+		"they conflict with other fixes or don't match the files", res[0].Text)
+
+	// nothing to apply
+	require.Equal(comments, fixedComments(comments, &lookout.FixResult{}))
+}
@@ -0,0 +1,141 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/src-d/lookout"
+)
+
+// eventBranch returns the branch the event is about: the base branch of a
+// pull request, or the pushed branch
+func eventBranch(e lookout.Event) string {
+	switch ev := e.(type) {
+	case *lookout.ReviewEvent:
+		return ev.CommitRevision.Base.ReferenceName.Short()
+	case *lookout.PushEvent:
+		return ev.CommitRevision.Head.ReferenceName.Short()
+	default:
+		return ""
+	}
+}
+
+// matchingBranches returns the patterns of the branch overrides that match
+// the branch, in alphabetical order
+func matchingBranches(branches map[string]BranchConfig, branch string) []string {
+	if branch == "" {
+		return nil
+	}
+
+	var res []string
+	for pattern := range branches {
+		if ok, _ := path.Match(pattern, branch); ok {
+			res = append(res, pattern)
+		}
+	}
+
+	sort.Strings(res)
+	return res
+}
+
+// effectiveAnalyzer is the effective configuration of an analyzer, as logged
+type effectiveAnalyzer struct {
+	Disabled      bool                   `json:"disabled,omitempty"`
+	MinConfidence uint32                 `json:"min_confidence,omitempty"`
+	Include       []string               `json:"include,omitempty"`
+	Exclude       []string               `json:"exclude,omitempty"`
+	TimeoutReview string                 `json:"timeout_review,omitempty"`
+	TimeoutPush   string                 `json:"timeout_push,omitempty"`
+	Settings      map[string]interface{} `json:"settings,omitempty"`
+}
+
+// String returns the effective configuration in JSON format
+func (c *repoConfig) String() string {
+	analyzers := make(map[string]effectiveAnalyzer, len(c.analyzers))
+	for name, a := range c.analyzers {
+		analyzers[name] = effectiveAnalyzer{
+			Disabled:      a.Disabled,
+			MinConfidence: a.MinConfidence,
+			Include:       a.Include,
+			Exclude:       a.Exclude,
+			TimeoutReview: durationString(a.TimeoutReview),
+			TimeoutPush:   durationString(a.TimeoutPush),
+			Settings:      jsonMap(a.Settings),
+		}
+	}
+
+	b, err := json.Marshal(struct {
+		Analyzers map[string]effectiveAnalyzer `json:"analyzers"`
+		Status    map[string]string            `json:"policy_status,omitempty"`
+		Review    map[string]string            `json:"policy_review,omitempty"`
+	}{analyzers, c.policy.Status, c.policy.Review})
+	if err != nil {
+		return err.Error()
+	}
+
+	return string(b)
+}
+
+// enabledAnalyzers returns the sorted names of the analyzers that are not
+// disabled
+func (c *repoConfig) enabledAnalyzers() []string {
+	var names []string
+	for name, a := range c.analyzers {
+		if !a.Disabled {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// durationString returns the duration as a string, empty if it is zero
+func durationString(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+
+	return d.String()
+}
+
+// jsonMap converts the nested maps decoded from yaml, with interface{} keys,
+// to maps that can be encoded to JSON
+func jsonMap(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+
+	res := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		res[k] = jsonValue(v)
+	}
+
+	return res
+}
+
+func jsonValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return jsonMap(v)
+	case map[interface{}]interface{}:
+		res := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			res[fmt.Sprint(k)] = jsonValue(e)
+		}
+
+		return res
+	case []interface{}:
+		res := make([]interface{}, len(v))
+		for i, e := range v {
+			res[i] = jsonValue(e)
+		}
+
+		return res
+	default:
+		return v
+	}
+}
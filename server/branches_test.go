@@ -0,0 +1,66 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/src-d/lookout"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/lookout-sdk.v0/pb"
+)
+
+func TestEventBranch(t *testing.T) {
+	require := require.New(t)
+
+	review := &lookout.ReviewEvent{ReviewEvent: pb.ReviewEvent{
+		CommitRevision: lookout.CommitRevision{
+			Base: lookout.ReferencePointer{ReferenceName: "refs/heads/release/1.0"},
+			Head: lookout.ReferencePointer{ReferenceName: "refs/heads/feature"},
+		},
+	}}
+	require.Equal("release/1.0", eventBranch(review))
+
+	push := &lookout.PushEvent{PushEvent: pb.PushEvent{
+		CommitRevision: lookout.CommitRevision{
+			Base: lookout.ReferencePointer{ReferenceName: "refs/heads/master"},
+			Head: lookout.ReferencePointer{ReferenceName: "refs/heads/master"},
+		},
+	}}
+	require.Equal("master", eventBranch(push))
+}
+
+func TestMatchingBranches(t *testing.T) {
+	require := require.New(t)
+
+	branches := map[string]BranchConfig{
+		"release/*":   {},
+		"release/1.*": {},
+		"master":      {},
+		"*":           {},
+	}
+
+	require.Equal([]string{"release/*", "release/1.*"},
+		matchingBranches(branches, "release/1.0"))
+	require.Equal([]string{"*", "master"}, matchingBranches(branches, "master"))
+	require.Empty(matchingBranches(branches, "feature/release/1.0"))
+	require.Empty(matchingBranches(branches, ""))
+}
+
+func TestRepoConfigString(t *testing.T) {
+	require := require.New(t)
+
+	c := &repoConfig{
+		analyzers: map[string]lookout.AnalyzerConfig{
+			"a": {Name: "a", Addr: "ipv4://localhost:9930", Disabled: true},
+			"b": {Name: "b", MinConfidence: 50, Settings: map[string]interface{}{
+				"nested": map[interface{}]interface{}{"key": []interface{}{1}},
+			}},
+		},
+		policy: DefaultPolicy,
+	}
+
+	require.Equal(`{"analyzers":{"a":{"disabled":true},`+
+		`"b":{"min_confidence":50,"settings":{"nested":{"key":[1]}}}},`+
+		`"policy_status":{"error":"failure"}}`, c.String())
+	require.Equal([]string{"b"}, c.enabledAnalyzers())
+}
@@ -0,0 +1,176 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/src-d/lookout/util/ctxlog"
+
+	"google.golang.org/grpc/codes"
+	"gopkg.in/src-d/go-errors.v1"
+	log "gopkg.in/src-d/go-log.v1"
+)
+
+// ErrCircuitOpen signals that an analyzer was not called because its circuit
+// breaker is open
+var ErrCircuitOpen = errors.NewKind("circuit breaker is open")
+
+// BreakerConfig defines when the circuit breaker of an analyzer opens, and how
+// often an open breaker probes the analyzer again
+type BreakerConfig struct {
+	// FailureThreshold is the number of consecutive failed requests that opens
+	// the breaker. Zero disables the circuit breakers.
+	FailureThreshold int `yaml:"failure_threshold"`
+	// ProbeInterval is the time an open breaker waits before letting a request
+	// through to check if the analyzer is back
+	ProbeInterval time.Duration `yaml:"probe_interval"`
+}
+
+// DefaultBreakerConfig is the BreakerConfig used by lookoutd if it is not
+// configured
+var DefaultBreakerConfig = BreakerConfig{
+	FailureThreshold: 5,
+	ProbeInterval:    time.Minute,
+}
+
+// BreakerState is the state of a circuit breaker
+type BreakerState int
+
+const (
+	// BreakerClosed lets all the requests through
+	BreakerClosed BreakerState = iota
+	// BreakerOpen skips all the requests
+	BreakerOpen
+	// BreakerHalfOpen lets a single probe request through, its result
+	// closes or opens the breaker again
+	BreakerHalfOpen
+)
+
+var breakerStateStrings = map[BreakerState]string{
+	BreakerClosed:   "closed",
+	BreakerOpen:     "open",
+	BreakerHalfOpen: "half-open",
+}
+
+func (s BreakerState) String() string {
+	return breakerStateStrings[s]
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (s BreakerState) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// BreakerStatus is a snapshot of a circuit breaker
+type BreakerStatus struct {
+	State               BreakerState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutive_failures"`
+	// OpenedAt is the last time the breaker was opened
+	OpenedAt  time.Time `json:"opened_at"`
+	LastError string    `json:"last_error,omitempty"`
+}
+
+// CircuitBreaker keeps track of the consecutive failures of an analyzer and
+// decides if it should be called. It is safe for concurrent use.
+type CircuitBreaker struct {
+	conf BreakerConfig
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	lastErr  error
+}
+
+// NewCircuitBreaker creates a new closed CircuitBreaker
+func NewCircuitBreaker(conf BreakerConfig) *CircuitBreaker {
+	return &CircuitBreaker{conf: conf}
+}
+
+// Allow returns true if the analyzer can be called. Once the probe interval
+// has passed, an open breaker allows a single request to probe the analyzer.
+func (b *CircuitBreaker) Allow(ctx context.Context) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if time.Since(b.openedAt) < b.conf.ProbeInterval {
+			return false
+		}
+
+		b.state = BreakerHalfOpen
+		ctxlog.Get(ctx).Infof("circuit breaker is half-open, probing the analyzer")
+		return true
+	case BreakerHalfOpen:
+		// a probe is already in progress
+		return false
+	default:
+		return true
+	}
+}
+
+// Report records the result of a request allowed by the breaker, a nil error
+// means the request succeeded
+func (b *CircuitBreaker) Report(ctx context.Context, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil {
+		if b.state != BreakerClosed {
+			ctxlog.Get(ctx).Infof("circuit breaker closed, the analyzer is back")
+		}
+
+		b.state = BreakerClosed
+		b.failures = 0
+		return
+	}
+
+	if grpcCode(err) == codes.Canceled {
+		// the request was canceled by lookout, it says nothing about the
+		// analyzer health. Let another request probe it
+		if b.state == BreakerHalfOpen {
+			b.state = BreakerOpen
+		}
+
+		return
+	}
+
+	b.failures++
+	b.lastErr = err
+
+	if b.conf.FailureThreshold <= 0 {
+		return
+	}
+
+	if b.state == BreakerHalfOpen || b.failures >= b.conf.FailureThreshold {
+		if b.state != BreakerOpen {
+			ctxlog.Get(ctx).With(log.Fields{
+				"consecutive-failures": b.failures,
+				"probe-interval":       b.conf.ProbeInterval,
+			}).Warningf("circuit breaker opened, the analyzer will be skipped")
+		}
+
+		b.state = BreakerOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// Status returns the current status of the breaker
+func (b *CircuitBreaker) Status() BreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	st := BreakerStatus{
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+		OpenedAt:            b.openedAt,
+	}
+
+	if b.lastErr != nil {
+		st.LastError = b.lastErr.Error()
+	}
+
+	return st
+}
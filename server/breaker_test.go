@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	probeInterval := 50 * time.Millisecond
+	b := NewCircuitBreaker(BreakerConfig{
+		FailureThreshold: 2,
+		ProbeInterval:    probeInterval,
+	})
+
+	require.True(b.Allow(ctx))
+	b.Report(ctx, errors.New("failed"))
+	require.Equal(BreakerClosed, b.Status().State)
+
+	// a success resets the consecutive failures
+	b.Report(ctx, nil)
+	b.Report(ctx, errors.New("failed"))
+	require.Equal(BreakerClosed, b.Status().State)
+
+	b.Report(ctx, errors.New("failed again"))
+	st := b.Status()
+	require.Equal(BreakerOpen, st.State)
+	require.Equal(2, st.ConsecutiveFailures)
+	require.Equal("failed again", st.LastError)
+	require.False(b.Allow(ctx))
+
+	time.Sleep(probeInterval)
+
+	// only one probe is allowed
+	require.True(b.Allow(ctx))
+	require.Equal(BreakerHalfOpen, b.Status().State)
+	require.False(b.Allow(ctx))
+
+	// failed probe opens the breaker again
+	b.Report(ctx, errors.New("still failing"))
+	require.Equal(BreakerOpen, b.Status().State)
+	require.False(b.Allow(ctx))
+
+	time.Sleep(probeInterval)
+
+	require.True(b.Allow(ctx))
+	b.Report(ctx, nil)
+	require.Equal(BreakerClosed, b.Status().State)
+	require.Equal(0, b.Status().ConsecutiveFailures)
+	require.True(b.Allow(ctx))
+}
+
+func TestCircuitBreakerDisabled(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	b := NewCircuitBreaker(BreakerConfig{})
+
+	for i := 0; i < 10; i++ {
+		require.True(b.Allow(ctx))
+		b.Report(ctx, errors.New("failed"))
+	}
+
+	require.Equal(BreakerClosed, b.Status().State)
+}
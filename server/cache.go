@@ -0,0 +1,107 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/src-d/lookout"
+	"github.com/src-d/lookout/store"
+	"github.com/src-d/lookout/util/ctxlog"
+
+	"gopkg.in/src-d/lookout-sdk.v0/pb"
+)
+
+// responseCache caches the analyzer responses in the store, so the analyzers
+// are not called again with the same input: the same analyzer version,
+// revisions and settings.
+// The version of an analyzer is only known after a response, the last known
+// version of each analyzer is kept in memory. It is safe for concurrent use.
+type responseCache struct {
+	op  store.ResponseCacheOperator
+	ttl time.Duration
+
+	mu       sync.Mutex
+	versions map[string]string
+}
+
+func newResponseCache(op store.ResponseCacheOperator, ttl time.Duration) *responseCache {
+	return &responseCache{
+		op:       op,
+		ttl:      ttl,
+		versions: make(map[string]string),
+	}
+}
+
+// enabled returns true if the responses of the analyzer can be cached
+func (c *responseCache) enabled(conf lookout.AnalyzerConfig) bool {
+	return c.ttl > 0 && (conf.Cache == nil || *conf.Cache)
+}
+
+// get returns the cached response of the analyzer for the event and
+// settings, or nil if there is none
+func (c *responseCache) get(
+	ctx context.Context,
+	e lookout.Event,
+	analyzer string,
+	settings map[string]interface{},
+) *lookout.EventResponse {
+	c.mu.Lock()
+	version, ok := c.versions[analyzer]
+	c.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	resp, err := c.op.Get(ctx, responseCacheKey(e, analyzer, version, settings))
+	if err != nil {
+		ctxlog.Get(ctx).Errorf(err, "can't get cached response")
+		return nil
+	}
+
+	return resp
+}
+
+// save caches the response of the analyzer for the event and settings
+func (c *responseCache) save(
+	ctx context.Context,
+	e lookout.Event,
+	analyzer string,
+	settings map[string]interface{},
+	resp *lookout.EventResponse,
+) {
+	c.mu.Lock()
+	c.versions[analyzer] = resp.AnalyzerVersion
+	c.mu.Unlock()
+
+	key := responseCacheKey(e, analyzer, resp.AnalyzerVersion, settings)
+	if err := c.op.Save(ctx, key, resp, time.Now().Add(c.ttl)); err != nil {
+		ctxlog.Get(ctx).Errorf(err, "can't save cached response")
+	}
+}
+
+// responseCacheKey returns the key identifying the input of an analyzer
+// request. The settings are converted to the struct sent to the analyzer,
+// its text format has the keys sorted.
+func responseCacheKey(
+	e lookout.Event,
+	analyzer, version string,
+	settings map[string]interface{},
+) string {
+	var settingsStr string
+	if st := pb.ToStruct(settings); st != nil {
+		settingsStr = st.String()
+	}
+
+	rev := e.Revision()
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%d\x00%s\x00%s\x00%s",
+		analyzer, version, e.Type(), rev.Base.Hash, rev.Head.Hash, settingsStr)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,33 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseCacheKey(t *testing.T) {
+	require := require.New(t)
+
+	e := correctReviewEvent()
+	settings := map[string]interface{}{
+		"a": 1,
+		"b": map[string]interface{}{"c": "d", "e": true},
+	}
+
+	key := responseCacheKey(e, "mock", "v1", settings)
+	for i := 0; i < 10; i++ {
+		require.Equal(key, responseCacheKey(e, "mock", "v1", map[string]interface{}{
+			"b": map[string]interface{}{"e": true, "c": "d"},
+			"a": 1,
+		}))
+	}
+
+	require.NotEqual(key, responseCacheKey(e, "other", "v1", settings))
+	require.NotEqual(key, responseCacheKey(e, "mock", "v2", settings))
+	require.NotEqual(key, responseCacheKey(e, "mock", "v1", nil))
+	require.NotEqual(key, responseCacheKey(correctPushEvent(), "mock", "v1", settings))
+
+	e.Head.Hash = "other-hash"
+	require.NotEqual(key, responseCacheKey(e, "mock", "v1", settings))
+}
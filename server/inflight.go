@@ -0,0 +1,82 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/src-d/lookout"
+)
+
+// reviewTracker keeps the review events being analyzed for each review target
+// (pull request), so the analysis of an event can be canceled when a newer
+// event for the same target arrives
+type reviewTracker struct {
+	mu      sync.Mutex
+	reviews map[string]*inFlightReview
+}
+
+type inFlightReview struct {
+	eventID    lookout.EventID
+	updatedAt  time.Time
+	cancel     context.CancelFunc
+	superseded bool
+}
+
+func newReviewTracker() *reviewTracker {
+	return &reviewTracker{reviews: make(map[string]*inFlightReview)}
+}
+
+// track registers the event as in-flight. The returned context is canceled
+// if a newer event for the same review target is tracked. The returned done
+// function must be called once the processing finishes, it returns true if
+// the event was superseded by a newer one.
+// Events other than *lookout.ReviewEvent are not tracked, neither are the
+// reruns, that must not cancel the regular analysis of the same head.
+func (t *reviewTracker) track(ctx context.Context, e lookout.Event) (context.Context, func() bool) {
+	ev, ok := e.(*lookout.ReviewEvent)
+	if !ok || ev.Rerun != nil {
+		return ctx, func() bool { return false }
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	r := &inFlightReview{
+		eventID:   ev.ID(),
+		updatedAt: ev.UpdatedAt,
+		cancel:    cancel,
+	}
+
+	key := ev.Provider + "/" + ev.InternalID
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if current, ok := t.reviews[key]; ok && current.eventID != r.eventID {
+		if current.updatedAt.After(r.updatedAt) {
+			// the event arrived late, a newer one is already being analyzed
+			r.superseded = true
+			cancel()
+		} else {
+			current.superseded = true
+			current.cancel()
+		}
+	}
+
+	if !r.superseded {
+		t.reviews[key] = r
+	}
+
+	done := func() bool {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		if t.reviews[key] == r {
+			delete(t.reviews, key)
+		}
+
+		cancel()
+		return r.superseded
+	}
+
+	return ctx, done
+}
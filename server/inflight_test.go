@@ -0,0 +1,73 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReviewTrackerSupersede(t *testing.T) {
+	require := require.New(t)
+
+	tracker := newReviewTracker()
+
+	older := correctReviewEvent()
+	newer := correctReviewEvent()
+	newer.Head.Hash = "new-sha"
+	newer.UpdatedAt = older.UpdatedAt.Add(time.Minute)
+
+	olderCtx, olderDone := tracker.track(context.Background(), older)
+	require.NoError(olderCtx.Err())
+
+	newerCtx, newerDone := tracker.track(context.Background(), newer)
+	require.Equal(context.Canceled, olderCtx.Err())
+	require.NoError(newerCtx.Err())
+
+	require.True(olderDone())
+	require.False(newerDone())
+}
+
+func TestReviewTrackerLateEvent(t *testing.T) {
+	require := require.New(t)
+
+	tracker := newReviewTracker()
+
+	older := correctReviewEvent()
+	newer := correctReviewEvent()
+	newer.Head.Hash = "new-sha"
+	newer.UpdatedAt = older.UpdatedAt.Add(time.Minute)
+
+	newerCtx, newerDone := tracker.track(context.Background(), newer)
+
+	// an older event arriving later is superseded right away
+	olderCtx, olderDone := tracker.track(context.Background(), older)
+	require.Equal(context.Canceled, olderCtx.Err())
+	require.NoError(newerCtx.Err())
+
+	require.True(olderDone())
+	require.False(newerDone())
+}
+
+func TestReviewTrackerOtherTargets(t *testing.T) {
+	require := require.New(t)
+
+	tracker := newReviewTracker()
+
+	review := correctReviewEvent()
+	otherReview := correctReviewEvent()
+	otherReview.InternalID = "2"
+
+	reviewCtx, reviewDone := tracker.track(context.Background(), review)
+	otherCtx, otherDone := tracker.track(context.Background(), otherReview)
+	pushCtx, pushDone := tracker.track(context.Background(), correctPushEvent())
+
+	require.NoError(reviewCtx.Err())
+	require.NoError(otherCtx.Err())
+	require.NoError(pushCtx.Err())
+
+	require.False(reviewDone())
+	require.False(otherDone())
+	require.False(pushDone())
+}
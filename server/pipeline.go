@@ -0,0 +1,158 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/src-d/lookout"
+)
+
+// upstreamSettingsKey is the key of the analyzer settings, sent in the event
+// Configuration, with the comments of the analyzers it depends on
+const upstreamSettingsKey = "upstream_comments"
+
+// ValidateDependencies checks that the depends_on of the analyzers don't form
+// a cycle. Unknown analyzers are allowed, they can be registered ones.
+func ValidateDependencies(analyzers []lookout.AnalyzerConfig) error {
+	deps := make(map[string][]string, len(analyzers))
+	for _, a := range analyzers {
+		deps[a.Name] = a.DependsOn
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+
+	state := make(map[string]int, len(analyzers))
+	var path []string
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("the analyzers depend on each other: %s",
+				strings.Join(append(path, name), " -> "))
+		case visited:
+			return nil
+		}
+
+		state[name] = visiting
+		path = append(path, name)
+		for _, dep := range deps[name] {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[name] = visited
+		return nil
+	}
+
+	for _, a := range analyzers {
+		if err := visit(a.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pipelineNode holds the comments produced by an analyzer for the analyzers
+// depending on it. comments must be set before closing done.
+type pipelineNode struct {
+	done     chan struct{}
+	comments []*lookout.Comment
+}
+
+type pipeline map[string]*pipelineNode
+
+func newPipeline(as *analyzerSet) pipeline {
+	p := make(pipeline, len(as.analyzers))
+	for name := range as.analyzers {
+		p[name] = &pipelineNode{done: make(chan struct{})}
+	}
+
+	return p
+}
+
+// finish makes the comments of the analyzer available to the ones depending
+// on it
+func (p pipeline) finish(name string, comments []*lookout.Comment) {
+	n := p[name]
+	n.comments = comments
+	close(n.done)
+}
+
+// upstream waits for the analyzers in dependsOn to finish, and returns their
+// comments. The analyzers that are not in the pipeline are ignored.
+func (p pipeline) upstream(ctx context.Context, dependsOn []string) (map[string][]*lookout.Comment, error) {
+	comments := make(map[string][]*lookout.Comment, len(dependsOn))
+	for _, name := range dependsOn {
+		n, ok := p[name]
+		if !ok {
+			continue
+		}
+
+		select {
+		case <-n.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		if len(n.comments) > 0 {
+			comments[name] = n.comments
+		}
+	}
+
+	return comments, nil
+}
+
+// withUpstream returns a copy of the settings with the upstream comments
+func withUpstream(
+	settings map[string]interface{},
+	upstream map[string][]*lookout.Comment,
+) map[string]interface{} {
+	if len(upstream) == 0 {
+		return settings
+	}
+
+	byAnalyzer := make(map[string]interface{}, len(upstream))
+	for name, comments := range upstream {
+		list := make([]interface{}, len(comments))
+		for i, c := range comments {
+			m := map[string]interface{}{
+				"file":       c.File,
+				"line":       c.Line,
+				"text":       c.Text,
+				"confidence": c.Confidence,
+				"severity":   severityName(c.Severity),
+			}
+
+			if c.EndLine > 0 {
+				m["end_line"] = c.EndLine
+			}
+
+			if c.Fix != nil {
+				m["fix"] = map[string]interface{}{
+					"start_line": c.Fix.StartLine,
+					"end_line":   c.Fix.EndLine,
+					"text":       c.Fix.Text,
+				}
+			}
+
+			list[i] = m
+		}
+
+		byAnalyzer[name] = list
+	}
+
+	merged := make(map[string]interface{}, len(settings)+1)
+	for k, v := range settings {
+		merged[k] = v
+	}
+
+	merged[upstreamSettingsKey] = byAnalyzer
+	return merged
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/src-d/lookout"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/lookout-sdk.v0/pb"
+)
+
+func TestValidateDependencies(t *testing.T) {
+	require := require.New(t)
+
+	require.NoError(ValidateDependencies([]lookout.AnalyzerConfig{
+		{Name: "rank", DependsOn: []string{"style", "lint"}},
+		{Name: "style", DependsOn: []string{"lint"}},
+		{Name: "lint", DependsOn: []string{"registered"}},
+	}))
+
+	err := ValidateDependencies([]lookout.AnalyzerConfig{
+		{Name: "rank", DependsOn: []string{"style"}},
+		{Name: "style", DependsOn: []string{"lint"}},
+		{Name: "lint", DependsOn: []string{"rank"}},
+	})
+	require.EqualError(err, "the analyzers depend on each other: rank -> style -> lint -> rank")
+
+	err = ValidateDependencies([]lookout.AnalyzerConfig{
+		{Name: "rank", DependsOn: []string{"rank"}},
+	})
+	require.EqualError(err, "the analyzers depend on each other: rank -> rank")
+}
+
+func TestPipeline(t *testing.T) {
+	require := require.New(t)
+
+	upstream := &AnalyzerClientMock{
+		ReviewSleep: 50 * time.Millisecond,
+		CommentsBuilder: func(lookout.Event, lookout.ReferencePointer, lookout.ReferencePointer) []*lookout.Comment {
+			return []*lookout.Comment{{
+				File:       "main.go",
+				Line:       3,
+				EndLine:    4,
+				Text:       "upstream comment",
+				Confidence: 80,
+				Severity:   lookout.WarningSeverity,
+				Fix:        &lookout.Fix{StartLine: 3, Text: "fixed()"},
+			}}
+		},
+	}
+	downstream := &AnalyzerClientMock{CommentsBuilder: makeComments}
+	poster := &PosterMock{}
+	srv := NewServer(Options{
+		Poster:     poster,
+		FileGetter: &FileGetterMock{},
+		Analyzers: map[string]lookout.Analyzer{
+			"upstream": {
+				Client: upstream,
+				Config: lookout.AnalyzerConfig{Name: "upstream"},
+			},
+			"downstream": {
+				Client: downstream,
+				Config: lookout.AnalyzerConfig{
+					Name:      "downstream",
+					DependsOn: []string{"upstream", "unknown"},
+					Settings:  map[string]interface{}{"mode": "rank"},
+				},
+			},
+		},
+	})
+
+	require.NoError(srv.HandleEvent(context.Background(), correctReviewEvent()))
+	require.Len(poster.PopComments(), 2)
+
+	events := upstream.PopReviewEvents()
+	require.Len(events, 1)
+	require.Nil(events[0].Configuration.GetFields())
+
+	events = downstream.PopReviewEvents()
+	require.Len(events, 1)
+	require.Equal(pb.ToStruct(map[string]interface{}{
+		"mode": "rank",
+		"upstream_comments": map[string]interface{}{
+			"upstream": []interface{}{map[string]interface{}{
+				"file":       "main.go",
+				"line":       3,
+				"end_line":   4,
+				"text":       "upstream comment",
+				"confidence": 80,
+				"severity":   "warning",
+				"fix": map[string]interface{}{
+					"start_line": 3,
+					"end_line":   0,
+					"text":       "fixed()",
+				},
+			}},
+		},
+	}).GetFields(), events[0].Configuration.GetFields())
+}
@@ -0,0 +1,183 @@
+package server
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/src-d/lookout"
+
+	"gopkg.in/src-d/lookout-sdk.v0/pb"
+)
+
+// noCommentsPolicyKey is the Policy.Review key used when the analysis
+// produced no comments
+const noCommentsPolicyKey = "none"
+
+var policyStatuses = map[string]lookout.AnalysisStatus{
+	"success": lookout.SuccessAnalysisStatus,
+	"failure": lookout.FailureAnalysisStatus,
+}
+
+var policyReviewActions = map[string]lookout.ReviewAction{
+	lookout.CommentReviewAction.String():        lookout.CommentReviewAction,
+	lookout.RequestChangesReviewAction.String(): lookout.RequestChangesReviewAction,
+	lookout.ApproveReviewAction.String():        lookout.ApproveReviewAction,
+}
+
+// Policy is the quality gate applied to the comments of an analysis. The keys
+// are comment severities (info, warning, error), each entry applies to the
+// comments of that severity or higher. The entry of the highest severity
+// found in the comments decides the result.
+type Policy struct {
+	// Status maps severities to the final status of the analysis: success or
+	// failure
+	Status map[string]string `yaml:"status"`
+	// Review maps severities, or none when there are no comments, to the
+	// review posted: comment, request_changes or approve
+	Review map[string]string `yaml:"review"`
+}
+
+// DefaultPolicy is the Policy used when it is not configured, it fails the
+// analysis if there is any error comment
+var DefaultPolicy = Policy{
+	Status: map[string]string{"error": "failure"},
+}
+
+// merge returns a new Policy with the entries of local overriding the ones of p
+func (p Policy) merge(local Policy) Policy {
+	return Policy{
+		Status: mergeStrings(p.Status, local.Status),
+		Review: mergeStrings(p.Review, local.Review),
+	}
+}
+
+func mergeStrings(global, local map[string]string) map[string]string {
+	merged := make(map[string]string, len(global)+len(local))
+	for k, v := range global {
+		merged[k] = v
+	}
+
+	for k, v := range local {
+		merged[k] = v
+	}
+
+	return merged
+}
+
+// validate returns a copy of the Policy without the invalid entries, and the
+// problems found in them
+func (p Policy) validate() (Policy, []string) {
+	res := Policy{
+		Status: make(map[string]string, len(p.Status)),
+		Review: make(map[string]string, len(p.Review)),
+	}
+
+	var problems []string
+	for _, k := range sortedKeys(p.Status) {
+		v := p.Status[k]
+		if _, ok := parseSeverity(k); !ok {
+			problems = append(problems, fmt.Sprintf("unknown severity `%s` in policy status", k))
+			continue
+		}
+
+		if _, ok := policyStatuses[v]; !ok {
+			problems = append(problems, fmt.Sprintf("unknown status `%s` for severity `%s` in policy", v, k))
+			continue
+		}
+
+		res.Status[k] = v
+	}
+
+	for _, k := range sortedKeys(p.Review) {
+		v := p.Review[k]
+		if _, ok := parseSeverity(k); !ok && k != noCommentsPolicyKey {
+			problems = append(problems, fmt.Sprintf("unknown severity `%s` in policy review", k))
+			continue
+		}
+
+		if _, ok := policyReviewActions[v]; !ok {
+			problems = append(problems, fmt.Sprintf("unknown review `%s` for severity `%s` in policy", v, k))
+			continue
+		}
+
+		res.Review[k] = v
+	}
+
+	return res, problems
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+// Evaluate returns the final status of the analysis and the review action
+// for the given comments
+func (p Policy) Evaluate(comments []lookout.AnalyzerComments) (lookout.AnalysisStatus, lookout.ReviewAction) {
+	highest, ok := highestSeverity(comments)
+	if !ok {
+		action := lookout.CommentReviewAction
+		if v, ok := p.Review[noCommentsPolicyKey]; ok {
+			action = policyReviewActions[v]
+		}
+
+		return lookout.SuccessAnalysisStatus, action
+	}
+
+	status := lookout.SuccessAnalysisStatus
+	if v, ok := lookupSeverity(p.Status, highest); ok {
+		status = policyStatuses[v]
+	}
+
+	action := lookout.CommentReviewAction
+	if v, ok := lookupSeverity(p.Review, highest); ok {
+		action = policyReviewActions[v]
+	}
+
+	return status, action
+}
+
+// lookupSeverity returns the value of the highest severity key that is lower
+// or equal than the given severity
+func lookupSeverity(m map[string]string, sev lookout.Severity) (string, bool) {
+	for ; sev >= lookout.InfoSeverity; sev-- {
+		if v, ok := m[severityName(sev)]; ok {
+			return v, true
+		}
+	}
+
+	return "", false
+}
+
+func highestSeverity(comments []lookout.AnalyzerComments) (lookout.Severity, bool) {
+	var (
+		highest lookout.Severity
+		found   bool
+	)
+
+	for _, cg := range comments {
+		for _, c := range cg.Comments {
+			if !found || c.Severity > highest {
+				highest = c.Severity
+				found = true
+			}
+		}
+	}
+
+	return highest, found
+}
+
+func parseSeverity(name string) (lookout.Severity, bool) {
+	v, ok := pb.Severity_value[strings.ToUpper(name)]
+	return lookout.Severity(v), ok
+}
+
+func severityName(sev lookout.Severity) string {
+	return strings.ToLower(sev.String())
+}
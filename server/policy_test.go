@@ -0,0 +1,105 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/src-d/lookout"
+
+	"github.com/stretchr/testify/require"
+)
+
+func commentsWithSeverities(sevs ...lookout.Severity) []lookout.AnalyzerComments {
+	var cs []*lookout.Comment
+	for _, sev := range sevs {
+		cs = append(cs, &lookout.Comment{Text: "comment", Severity: sev})
+	}
+
+	return []lookout.AnalyzerComments{{Comments: cs}}
+}
+
+func TestPolicyEvaluate(t *testing.T) {
+	require := require.New(t)
+
+	p := DefaultPolicy.merge(Policy{
+		Review: map[string]string{
+			"none":    "approve",
+			"warning": "comment",
+			"error":   "request_changes",
+		},
+	})
+
+	st, action := p.Evaluate(nil)
+	require.Equal(lookout.SuccessAnalysisStatus, st)
+	require.Equal(lookout.ApproveReviewAction, action)
+
+	// info has no review entry, the default is used
+	st, action = p.Evaluate(commentsWithSeverities(lookout.InfoSeverity))
+	require.Equal(lookout.SuccessAnalysisStatus, st)
+	require.Equal(lookout.CommentReviewAction, action)
+
+	st, action = p.Evaluate(commentsWithSeverities(lookout.InfoSeverity, lookout.WarningSeverity))
+	require.Equal(lookout.SuccessAnalysisStatus, st)
+	require.Equal(lookout.CommentReviewAction, action)
+
+	st, action = p.Evaluate(commentsWithSeverities(lookout.ErrorSeverity, lookout.InfoSeverity))
+	require.Equal(lookout.FailureAnalysisStatus, st)
+	require.Equal(lookout.RequestChangesReviewAction, action)
+}
+
+func TestPolicyEvaluateLowerSeverity(t *testing.T) {
+	require := require.New(t)
+
+	// an entry applies to its severity or higher
+	p := Policy{Status: map[string]string{"warning": "failure"}}
+
+	st, _ := p.Evaluate(commentsWithSeverities(lookout.InfoSeverity))
+	require.Equal(lookout.SuccessAnalysisStatus, st)
+
+	st, _ = p.Evaluate(commentsWithSeverities(lookout.ErrorSeverity))
+	require.Equal(lookout.FailureAnalysisStatus, st)
+}
+
+func TestPolicyMerge(t *testing.T) {
+	require := require.New(t)
+
+	p := DefaultPolicy.merge(Policy{
+		Status: map[string]string{"error": "success"},
+		Review: map[string]string{"error": "request_changes"},
+	})
+
+	require.Equal(Policy{
+		Status: map[string]string{"error": "success"},
+		Review: map[string]string{"error": "request_changes"},
+	}, p)
+
+	// the default policy is not modified
+	require.Equal(map[string]string{"error": "failure"}, DefaultPolicy.Status)
+}
+
+func TestPolicyValidate(t *testing.T) {
+	require := require.New(t)
+
+	p := Policy{
+		Status: map[string]string{
+			"error":   "failure",
+			"warning": "pending",
+			"fatal":   "failure",
+		},
+		Review: map[string]string{
+			"none":  "approve",
+			"error": "reject",
+			"info":  "comment",
+		},
+	}
+
+	valid, problems := p.validate()
+	require.Equal(Policy{
+		Status: map[string]string{"error": "failure"},
+		Review: map[string]string{"none": "approve", "info": "comment"},
+	}, valid)
+	require.Equal([]string{
+		"unknown severity `fatal` in policy status",
+		"unknown status `pending` for severity `warning` in policy",
+		"unknown review `reject` for severity `error` in policy",
+	}, problems)
+}
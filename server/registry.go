@@ -0,0 +1,476 @@
+package server
+
+import (
+	"context"
+	"io"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/src-d/lookout"
+	"github.com/src-d/lookout/util/ctxlog"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+	log "gopkg.in/src-d/go-log.v1"
+	"gopkg.in/src-d/lookout-sdk.v0/pb"
+)
+
+// registrationEventTypes maps the event type names used in the registrations
+// to the event types
+var registrationEventTypes = map[string]lookout.EventType{
+	"review": pb.ReviewEventType,
+	"push":   pb.PushEventType,
+}
+
+// AnalyzerDialer connects to an analyzer, and returns its clients. The
+// returned io.Closer closes the connection.
+type AnalyzerDialer func(conf lookout.AnalyzerConfig) (lookout.Analyzer, io.Closer, error)
+
+// RegistryOptions are the options of a Registry
+type RegistryOptions struct {
+	// TTL is the time a registration is kept without a heartbeat
+	TTL time.Duration
+	// Dial connects to the registered analyzers
+	Dial AnalyzerDialer
+	// Analyzers are the names of the analyzers allowed to register
+	Analyzers []string
+}
+
+// Registry is the lookout.RegistryServer that adds the analyzers announced
+// by registration to a Server, and removes them when they unregister or stop
+// sending heartbeats. Each analyzer can have several replicas, registered
+// with the same name and different addresses; the events are distributed
+// among them.
+type Registry struct {
+	srv  *Server
+	ttl  time.Duration
+	dial AnalyzerDialer
+	now  func() time.Time
+	// allowed are the names of the analyzers allowed to register
+	allowed map[string]bool
+
+	mu sync.Mutex
+	// replicas are the registered analyzer replicas, by name and address
+	replicas map[string]map[string]*replica
+}
+
+var _ lookout.RegistryServer = &Registry{}
+
+// replica is a registered analyzer replica
+type replica struct {
+	addr       string
+	version    string
+	eventTypes []lookout.EventType
+	analyzer   lookout.Analyzer
+	closer     io.Closer
+	expires    time.Time
+}
+
+// NewRegistry creates a new Registry for the server
+func NewRegistry(srv *Server, opt RegistryOptions) *Registry {
+	allowed := make(map[string]bool, len(opt.Analyzers))
+	for _, name := range opt.Analyzers {
+		allowed[name] = true
+	}
+
+	return &Registry{
+		srv:      srv,
+		ttl:      opt.TTL,
+		dial:     opt.Dial,
+		now:      time.Now,
+		allowed:  allowed,
+		replicas: make(map[string]map[string]*replica),
+	}
+}
+
+// Register adds the analyzer replica, or renews its registration
+func (r *Registry) Register(ctx context.Context, req *lookout.AnalyzerRegistration) (*lookout.RegistrationResponse, error) {
+	eventTypes, err := r.validate(req)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := ctxlog.Get(ctx).With(log.Fields{
+		"analyzer":         req.Name,
+		"addr":             req.Addr,
+		"analyzer-version": req.Version,
+	})
+
+	if r.renew(logger, req, eventTypes) {
+		return &lookout.RegistrationResponse{Ttl: r.ttl}, nil
+	}
+
+	// the connection is made without holding mu, so the heartbeats and the
+	// events are not blocked by a slow analyzer
+	analyzer, closer, err := r.dial(lookout.AnalyzerConfig{
+		Name: req.Name,
+		Addr: req.Addr,
+	})
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument,
+			"can't connect to analyzer %s at %s: %s", req.Name, req.Addr, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.replicas[req.Name][req.Addr]; ok {
+		// a concurrent registration of the replica connected first
+		if err := closer.Close(); err != nil {
+			logger.Errorf(err, "can't close the analyzer connection")
+		}
+
+		r.renewLocked(logger, req, eventTypes)
+		return &lookout.RegistrationResponse{Ttl: r.ttl}, nil
+	}
+
+	if r.replicas[req.Name] == nil {
+		r.replicas[req.Name] = make(map[string]*replica)
+	}
+
+	r.replicas[req.Name][req.Addr] = &replica{
+		addr:       req.Addr,
+		version:    req.Version,
+		eventTypes: eventTypes,
+		analyzer:   analyzer,
+		closer:     closer,
+		expires:    r.now().Add(r.ttl),
+	}
+
+	logger.With(log.Fields{"replicas": len(r.replicas[req.Name])}).
+		Infof("analyzer registered")
+	r.update(nil)
+
+	return &lookout.RegistrationResponse{Ttl: r.ttl}, nil
+}
+
+// renew renews the registration of the replica, it returns false if the
+// replica is not registered
+func (r *Registry) renew(
+	logger log.Logger,
+	req *lookout.AnalyzerRegistration,
+	eventTypes []lookout.EventType,
+) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.replicas[req.Name][req.Addr]; !ok {
+		return false
+	}
+
+	r.renewLocked(logger, req, eventTypes)
+	return true
+}
+
+// renewLocked renews the registration of a registered replica, it must be
+// called holding mu
+func (r *Registry) renewLocked(
+	logger log.Logger,
+	req *lookout.AnalyzerRegistration,
+	eventTypes []lookout.EventType,
+) {
+	rep := r.replicas[req.Name][req.Addr]
+	rep.expires = r.now().Add(r.ttl)
+	if rep.version == req.Version && sameEventTypes(rep.eventTypes, eventTypes) {
+		logger.Debugf("analyzer registration renewed")
+		return
+	}
+
+	// the replica is replaced, not modified, as it is used by the
+	// replicaClient of the current analyzers
+	updated := *rep
+	updated.version = req.Version
+	updated.eventTypes = eventTypes
+	r.replicas[req.Name][req.Addr] = &updated
+
+	logger.Infof("analyzer registration updated")
+	r.update(nil)
+}
+
+// Unregister removes the analyzer replica
+func (r *Registry) Unregister(ctx context.Context, req *lookout.AnalyzerRegistration) (*lookout.RegistrationResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rep, ok := r.replicas[req.Name][req.Addr]
+	if !ok {
+		return nil, grpcstatus.Errorf(codes.NotFound,
+			"analyzer %s at %s is not registered", req.Name, req.Addr)
+	}
+
+	r.remove(req.Name, req.Addr)
+	ctxlog.Get(ctx).With(log.Fields{
+		"analyzer": req.Name,
+		"addr":     req.Addr,
+		"replicas": len(r.replicas[req.Name]),
+	}).Infof("analyzer unregistered")
+	r.update([]*replica{rep})
+
+	return &lookout.RegistrationResponse{}, nil
+}
+
+// Run removes the registrations without a heartbeat in their TTL, until the
+// context is canceled. All the registered analyzers are removed on return.
+func (r *Registry) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.ttl / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.mu.Lock()
+			var removed []*replica
+			for name, replicas := range r.replicas {
+				for addr, rep := range replicas {
+					removed = append(removed, rep)
+					r.remove(name, addr)
+				}
+			}
+
+			r.update(removed)
+			r.mu.Unlock()
+			return
+		case <-ticker.C:
+			r.expire()
+		}
+	}
+}
+
+// expire removes the registrations without a heartbeat in their TTL
+func (r *Registry) expire() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	var removed []*replica
+	for name, replicas := range r.replicas {
+		for addr, rep := range replicas {
+			if now.Before(rep.expires) {
+				continue
+			}
+
+			ctxlog.Get(context.Background()).With(log.Fields{
+				"analyzer": name,
+				"addr":     addr,
+			}).Warningf("analyzer registration expired, no heartbeat received")
+			removed = append(removed, rep)
+			r.remove(name, addr)
+		}
+	}
+
+	if len(removed) > 0 {
+		r.update(removed)
+	}
+}
+
+// remove deletes a replica, it must be called holding mu
+func (r *Registry) remove(name, addr string) {
+	delete(r.replicas[name], addr)
+	if len(r.replicas[name]) == 0 {
+		delete(r.replicas, name)
+	}
+}
+
+// update sets the registered analyzers in the server, and closes the removed
+// replicas once the events using them are processed. It must be called
+// holding mu.
+func (r *Registry) update(removed []*replica) {
+	analyzers := make(map[string]lookout.Analyzer, len(r.replicas))
+	for name, replicas := range r.replicas {
+		analyzers[name] = newReplicaAnalyzer(name, replicas)
+	}
+
+	drained := r.srv.SetRegisteredAnalyzers(analyzers)
+	if len(removed) == 0 {
+		return
+	}
+
+	go func() {
+		<-drained
+		for _, rep := range removed {
+			if err := rep.closer.Close(); err != nil {
+				ctxlog.Get(context.Background()).With(log.Fields{"addr": rep.addr}).
+					Errorf(err, "can't close the analyzer connection")
+			}
+		}
+	}()
+}
+
+// validate checks the registration request, and returns its event types
+func (r *Registry) validate(req *lookout.AnalyzerRegistration) ([]lookout.EventType, error) {
+	if req.Name == "" {
+		return nil, grpcstatus.Error(codes.InvalidArgument, "missing analyzer name")
+	}
+
+	if req.Addr == "" {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument,
+			"missing address of analyzer %s", req.Name)
+	}
+
+	if r.srv.configured(req.Name) {
+		return nil, grpcstatus.Errorf(codes.AlreadyExists,
+			"analyzer %s is configured in the server, it can't be registered", req.Name)
+	}
+
+	if !r.allowed[req.Name] {
+		return nil, grpcstatus.Errorf(codes.PermissionDenied,
+			"analyzer %s is not allowed to register", req.Name)
+	}
+
+	var eventTypes []lookout.EventType
+	for _, name := range req.EventTypes {
+		t, ok := registrationEventTypes[name]
+		if !ok {
+			return nil, grpcstatus.Errorf(codes.InvalidArgument,
+				"unknown event type %q of analyzer %s", name, req.Name)
+		}
+
+		eventTypes = append(eventTypes, t)
+	}
+
+	sort.Slice(eventTypes, func(i, j int) bool { return eventTypes[i] < eventTypes[j] })
+	return eventTypes, nil
+}
+
+func sameEventTypes(a, b []lookout.EventType) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// newReplicaAnalyzer returns the lookout.Analyzer that distributes the events
+// among the replicas. It supports the event types supported by any replica.
+func newReplicaAnalyzer(name string, replicas map[string]*replica) lookout.Analyzer {
+	addrs := make([]string, 0, len(replicas))
+	for addr := range replicas {
+		addrs = append(addrs, addr)
+	}
+
+	sort.Strings(addrs)
+
+	client := &replicaClient{name: name}
+	var stream lookout.AnalyzerStreamClient
+	all := false
+	types := make(map[lookout.EventType]bool)
+	for _, addr := range addrs {
+		rep := replicas[addr]
+		client.replicas = append(client.replicas, rep)
+		if rep.analyzer.Stream != nil {
+			stream = replicaStreamClient{client}
+		}
+
+		if len(rep.eventTypes) == 0 {
+			all = true
+		}
+
+		for _, t := range rep.eventTypes {
+			types[t] = true
+		}
+	}
+
+	var eventTypes []lookout.EventType
+	if !all {
+		for t := range types {
+			eventTypes = append(eventTypes, t)
+		}
+
+		sort.Slice(eventTypes, func(i, j int) bool { return eventTypes[i] < eventTypes[j] })
+	}
+
+	return lookout.Analyzer{
+		Client:     client,
+		Stream:     stream,
+		Config:     lookout.AnalyzerConfig{Name: name, Addr: addrs[0]},
+		EventTypes: eventTypes,
+	}
+}
+
+// replicaClient is a lookout.AnalyzerClient that sends each event to the
+// next replica supporting it, in round-robin
+type replicaClient struct {
+	name     string
+	replicas []*replica
+	next     uint32
+}
+
+var _ lookout.AnalyzerClient = &replicaClient{}
+
+func (c *replicaClient) NotifyReviewEvent(ctx context.Context, in *pb.ReviewEvent, opts ...grpc.CallOption) (*lookout.EventResponse, error) {
+	rep, err := c.pick(pb.ReviewEventType)
+	if err != nil {
+		return nil, err
+	}
+
+	return rep.analyzer.Client.NotifyReviewEvent(ctx, in, opts...)
+}
+
+func (c *replicaClient) NotifyPushEvent(ctx context.Context, in *pb.PushEvent, opts ...grpc.CallOption) (*lookout.EventResponse, error) {
+	rep, err := c.pick(pb.PushEventType)
+	if err != nil {
+		return nil, err
+	}
+
+	return rep.analyzer.Client.NotifyPushEvent(ctx, in, opts...)
+}
+
+// pick returns the next replica supporting the event type
+func (c *replicaClient) pick(t lookout.EventType) (*replica, error) {
+	start := atomic.AddUint32(&c.next, 1)
+	for i := range c.replicas {
+		rep := c.replicas[(int(start)+i)%len(c.replicas)]
+		if supportsEvent(lookout.Analyzer{EventTypes: rep.eventTypes}, t) {
+			return rep, nil
+		}
+	}
+
+	return nil, grpcstatus.Errorf(codes.Unimplemented,
+		"no replica of analyzer %s supports the event", c.name)
+}
+
+// replicaStreamClient is the lookout.AnalyzerStreamClient of a replicaClient.
+// It fails with codes.Unimplemented for the replicas without the streaming
+// variant, so the unary one is used.
+type replicaStreamClient struct {
+	client *replicaClient
+}
+
+var _ lookout.AnalyzerStreamClient = replicaStreamClient{}
+
+func (c replicaStreamClient) NotifyReviewEvent(ctx context.Context, in *pb.ReviewEvent, opts ...grpc.CallOption) (pb.AnalyzerStream_NotifyReviewEventClient, error) {
+	rep, err := c.client.pick(pb.ReviewEventType)
+	if err != nil {
+		return nil, err
+	}
+
+	if rep.analyzer.Stream == nil {
+		return nil, errNoStream
+	}
+
+	return rep.analyzer.Stream.NotifyReviewEvent(ctx, in, opts...)
+}
+
+func (c replicaStreamClient) NotifyPushEvent(ctx context.Context, in *pb.PushEvent, opts ...grpc.CallOption) (pb.AnalyzerStream_NotifyPushEventClient, error) {
+	rep, err := c.client.pick(pb.PushEventType)
+	if err != nil {
+		return nil, err
+	}
+
+	if rep.analyzer.Stream == nil {
+		return nil, errNoStream
+	}
+
+	return rep.analyzer.Stream.NotifyPushEvent(ctx, in, opts...)
+}
+
+var errNoStream = grpcstatus.Error(codes.Unimplemented, "the analyzer replica is not streaming")
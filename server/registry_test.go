@@ -0,0 +1,238 @@
+package server
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/src-d/lookout"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+type closerMock struct {
+	closed chan struct{}
+}
+
+func (c *closerMock) Close() error {
+	close(c.closed)
+	return nil
+}
+
+type registryFixture struct {
+	srv      *Server
+	poster   *PosterMock
+	registry *Registry
+	clients  map[string]*AnalyzerClientMock
+	closers  map[string]*closerMock
+}
+
+func newRegistryFixture() *registryFixture {
+	f := &registryFixture{
+		poster:  &PosterMock{},
+		clients: make(map[string]*AnalyzerClientMock),
+		closers: make(map[string]*closerMock),
+	}
+
+	f.srv = NewServer(Options{
+		Poster:     f.poster,
+		FileGetter: &FileGetterMock{},
+		Analyzers: map[string]lookout.Analyzer{
+			"static": lookout.Analyzer{
+				Client: &AnalyzerClientMock{CommentsBuilder: makeComments},
+			},
+		},
+	})
+
+	f.registry = NewRegistry(f.srv, RegistryOptions{
+		TTL:       time.Minute,
+		Analyzers: []string{"registered"},
+		Dial: func(conf lookout.AnalyzerConfig) (lookout.Analyzer, io.Closer, error) {
+			client := &AnalyzerClientMock{CommentsBuilder: makeComments}
+			closer := &closerMock{closed: make(chan struct{})}
+			f.clients[conf.Addr] = client
+			f.closers[conf.Addr] = closer
+
+			return lookout.Analyzer{Client: client}, closer, nil
+		},
+	})
+
+	return f
+}
+
+func (f *registryFixture) register(name, addr string, eventTypes ...string) error {
+	_, err := f.registry.Register(context.Background(), &lookout.AnalyzerRegistration{
+		Name:       name,
+		Addr:       addr,
+		Version:    "v1",
+		EventTypes: eventTypes,
+	})
+
+	return err
+}
+
+func TestRegistryRegister(t *testing.T) {
+	require := require.New(t)
+
+	f := newRegistryFixture()
+
+	resp, err := f.registry.Register(context.Background(), &lookout.AnalyzerRegistration{
+		Name: "registered",
+		Addr: "ipv4://replica-1:9930",
+	})
+	require.NoError(err)
+	require.Equal(time.Minute, resp.Ttl)
+	require.Contains(f.srv.AnalyzersHealth(), "registered")
+	require.Contains(f.srv.AnalyzersHealth(), "static")
+
+	require.NoError(f.srv.HandleEvent(context.Background(), correctReviewEvent()))
+	require.Len(f.clients["ipv4://replica-1:9930"].PopReviewEvents(), 1)
+	require.Len(f.poster.PopComments(), 2)
+
+	// the heartbeat does not connect again
+	require.NoError(f.register("registered", "ipv4://replica-1:9930"))
+	require.Len(f.clients, 1)
+}
+
+func TestRegistryInvalid(t *testing.T) {
+	require := require.New(t)
+
+	f := newRegistryFixture()
+
+	require.Equal(codes.InvalidArgument, grpcstatus.Code(f.register("", "ipv4://replica-1:9930")))
+	require.Equal(codes.InvalidArgument, grpcstatus.Code(f.register("registered", "")))
+	require.Equal(codes.InvalidArgument, grpcstatus.Code(
+		f.register("registered", "ipv4://replica-1:9930", "merge")))
+	require.Equal(codes.AlreadyExists, grpcstatus.Code(f.register("static", "ipv4://replica-1:9930")))
+	require.Equal(codes.PermissionDenied, grpcstatus.Code(f.register("unknown", "ipv4://replica-1:9930")))
+
+	require.Len(f.clients, 0)
+	require.NotContains(f.srv.AnalyzersHealth(), "registered")
+}
+
+func TestRegistryReplicas(t *testing.T) {
+	require := require.New(t)
+
+	f := newRegistryFixture()
+
+	require.NoError(f.register("registered", "ipv4://replica-1:9930"))
+	require.NoError(f.register("registered", "ipv4://replica-2:9930"))
+	require.Len(f.srv.AnalyzersHealth(), 2)
+
+	for i := 0; i < 4; i++ {
+		require.NoError(f.srv.HandleEvent(context.Background(), correctPushEvent()))
+	}
+
+	require.Len(f.clients["ipv4://replica-1:9930"].PopPushEvents(), 2)
+	require.Len(f.clients["ipv4://replica-2:9930"].PopPushEvents(), 2)
+
+	_, err := f.registry.Unregister(context.Background(), &lookout.AnalyzerRegistration{
+		Name: "registered",
+		Addr: "ipv4://replica-1:9930",
+	})
+	require.NoError(err)
+
+	select {
+	case <-f.closers["ipv4://replica-1:9930"].closed:
+	case <-time.After(time.Second):
+		require.Fail("the unregistered replica was not closed")
+	}
+
+	require.NoError(f.srv.HandleEvent(context.Background(), correctPushEvent()))
+	require.Len(f.clients["ipv4://replica-1:9930"].PopPushEvents(), 0)
+	require.Len(f.clients["ipv4://replica-2:9930"].PopPushEvents(), 1)
+
+	_, err = f.registry.Unregister(context.Background(), &lookout.AnalyzerRegistration{
+		Name: "registered",
+		Addr: "ipv4://replica-1:9930",
+	})
+	require.Equal(codes.NotFound, grpcstatus.Code(err))
+}
+
+func TestRegistrySlowDial(t *testing.T) {
+	require := require.New(t)
+
+	f := newRegistryFixture()
+	require.NoError(f.register("registered", "ipv4://replica-1:9930"))
+
+	dialing := make(chan struct{})
+	release := make(chan struct{})
+	dial := f.registry.dial
+	f.registry.dial = func(conf lookout.AnalyzerConfig) (lookout.Analyzer, io.Closer, error) {
+		close(dialing)
+		<-release
+		return dial(conf)
+	}
+
+	done := make(chan error)
+	go func() { done <- f.register("registered", "ipv4://replica-2:9930") }()
+	<-dialing
+
+	// the heartbeats are not blocked while a new replica is connecting
+	heartbeat := make(chan error)
+	go func() { heartbeat <- f.register("registered", "ipv4://replica-1:9930") }()
+
+	select {
+	case err := <-heartbeat:
+		require.NoError(err)
+	case <-time.After(time.Second):
+		require.Fail("the heartbeat was blocked by the connection")
+	}
+
+	close(release)
+	require.NoError(<-done)
+	require.Len(f.clients, 2)
+}
+
+func TestRegistryEventTypes(t *testing.T) {
+	require := require.New(t)
+
+	f := newRegistryFixture()
+
+	require.NoError(f.register("registered", "ipv4://replica-1:9930", "push"))
+
+	require.NoError(f.srv.HandleEvent(context.Background(), correctReviewEvent()))
+	require.Len(f.clients["ipv4://replica-1:9930"].PopReviewEvents(), 0)
+
+	require.NoError(f.srv.HandleEvent(context.Background(), correctPushEvent()))
+	require.Len(f.clients["ipv4://replica-1:9930"].PopPushEvents(), 1)
+
+	// the replica supports now review events too
+	require.NoError(f.register("registered", "ipv4://replica-1:9930", "review", "push"))
+
+	require.NoError(f.srv.HandleEvent(context.Background(), correctReviewEvent()))
+	require.Len(f.clients["ipv4://replica-1:9930"].PopReviewEvents(), 1)
+}
+
+func TestRegistryExpire(t *testing.T) {
+	require := require.New(t)
+
+	f := newRegistryFixture()
+
+	now := time.Now()
+	f.registry.now = func() time.Time { return now }
+
+	require.NoError(f.register("registered", "ipv4://replica-1:9930"))
+	require.NoError(f.register("registered", "ipv4://replica-2:9930"))
+
+	now = now.Add(40 * time.Second)
+	require.NoError(f.register("registered", "ipv4://replica-2:9930"))
+
+	now = now.Add(30 * time.Second)
+	f.registry.expire()
+
+	select {
+	case <-f.closers["ipv4://replica-1:9930"].closed:
+	case <-time.After(time.Second):
+		require.Fail("the expired replica was not closed")
+	}
+
+	require.Contains(f.srv.AnalyzersHealth(), "registered")
+
+	now = now.Add(time.Minute)
+	f.registry.expire()
+	require.NotContains(f.srv.AnalyzersHealth(), "registered")
+}
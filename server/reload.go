@@ -0,0 +1,178 @@
+package server
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/src-d/lookout"
+	"github.com/src-d/lookout/util/ctxlog"
+
+	log "gopkg.in/src-d/go-log.v1"
+)
+
+// ReloadOptions are the Options that can be replaced in a running Server
+type ReloadOptions struct {
+	Analyzers map[string]lookout.Analyzer
+	// ReviewTimeout is the timeout for an analyzer to reply a NotifyReviewEvent.
+	// Zero means no timeout.
+	ReviewTimeout time.Duration
+	// PushTimeout is the timeout for an analyzer to reply a NotifyPushEvent.
+	// Zero means no timeout.
+	PushTimeout time.Duration
+}
+
+// analyzerSet is the set of analyzers, and their options, used to process
+// events. Reload replaces it as a whole, the events being processed keep
+// using the set they started with.
+type analyzerSet struct {
+	analyzers     map[string]lookout.Analyzer
+	breakers      map[string]*CircuitBreaker
+	reviewTimeout time.Duration
+	pushTimeout   time.Duration
+
+	// inFlight counts the events using the set
+	inFlight sync.WaitGroup
+}
+
+// newAnalyzerSet creates a new analyzerSet with the analyzers in opt and the
+// registered ones. The analyzers in opt take precedence over the registered
+// analyzers with the same name. The circuit breakers of the analyzers in
+// previous are kept, so their state is not lost on reload.
+func newAnalyzerSet(
+	opt ReloadOptions,
+	registered map[string]lookout.Analyzer,
+	breaker BreakerConfig,
+	previous map[string]*CircuitBreaker,
+) *analyzerSet {
+	analyzers := make(map[string]lookout.Analyzer, len(opt.Analyzers)+len(registered))
+	for name, a := range registered {
+		if _, ok := opt.Analyzers[name]; ok {
+			ctxlog.Get(context.Background()).With(log.Fields{"analyzer": name}).Warningf(
+				"registered analyzer ignored, an analyzer with the same name is configured")
+			continue
+		}
+
+		analyzers[name] = a
+	}
+
+	for name, a := range opt.Analyzers {
+		analyzers[name] = a
+	}
+
+	set := &analyzerSet{
+		analyzers:     analyzers,
+		breakers:      make(map[string]*CircuitBreaker, len(analyzers)),
+		reviewTimeout: opt.ReviewTimeout,
+		pushTimeout:   opt.PushTimeout,
+	}
+
+	for name := range analyzers {
+		if b, ok := previous[name]; ok {
+			set.breakers[name] = b
+			continue
+		}
+
+		set.breakers[name] = NewCircuitBreaker(breaker)
+	}
+
+	return set
+}
+
+// known returns true if the analyzer is in the set
+func (as *analyzerSet) known(name string) bool {
+	_, ok := as.analyzers[name]
+	return ok
+}
+
+// supportsEvent returns true if the events of type t are sent to the analyzer
+func supportsEvent(a lookout.Analyzer, t lookout.EventType) bool {
+	if len(a.EventTypes) == 0 {
+		return true
+	}
+
+	for _, et := range a.EventTypes {
+		if et == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+// acquireAnalyzers returns the current analyzerSet, it must be released once
+// the event is processed
+func (s *Server) acquireAnalyzers() *analyzerSet {
+	s.setMu.RLock()
+	defer s.setMu.RUnlock()
+
+	s.set.inFlight.Add(1)
+	return s.set
+}
+
+func (s *Server) releaseAnalyzers(as *analyzerSet) {
+	as.inFlight.Done()
+}
+
+// Reload replaces the analyzers and timeouts of the server. The events being
+// processed finish with the previous ones, the returned channel is closed
+// once all of them are done, so the removed analyzers can be closed.
+func (s *Server) Reload(opt ReloadOptions) <-chan struct{} {
+	s.setMu.Lock()
+	s.static = opt
+	drained := s.replaceSet()
+	s.setMu.Unlock()
+
+	ctxlog.Get(context.Background()).With(log.Fields{
+		"analyzers":      analyzerNames(opt.Analyzers),
+		"review-timeout": opt.ReviewTimeout,
+		"push-timeout":   opt.PushTimeout,
+	}).Infof("server reloaded")
+
+	return drained
+}
+
+// SetRegisteredAnalyzers replaces the analyzers added by registration,
+// instead of Options or Reload. Like Reload, the returned channel is closed
+// once the events using the previous analyzers are processed.
+func (s *Server) SetRegisteredAnalyzers(analyzers map[string]lookout.Analyzer) <-chan struct{} {
+	s.setMu.Lock()
+	defer s.setMu.Unlock()
+
+	s.registered = analyzers
+	return s.replaceSet()
+}
+
+// configured returns true if the analyzer is set with Options or Reload
+func (s *Server) configured(name string) bool {
+	s.setMu.RLock()
+	defer s.setMu.RUnlock()
+
+	_, ok := s.static.Analyzers[name]
+	return ok
+}
+
+// replaceSet builds a new analyzerSet, it must be called holding setMu
+func (s *Server) replaceSet() <-chan struct{} {
+	previous := s.set
+	s.set = newAnalyzerSet(s.static, s.registered, s.breakerConf, previous.breakers)
+
+	drained := make(chan struct{})
+	go func() {
+		previous.inFlight.Wait()
+		close(drained)
+	}()
+
+	return drained
+}
+
+func analyzerNames(analyzers map[string]lookout.Analyzer) []string {
+	names := make([]string, 0, len(analyzers))
+	for name := range analyzers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/src-d/lookout"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReload(t *testing.T) {
+	require := require.New(t)
+
+	oldClient := &AnalyzerClientMock{
+		CommentsBuilder: makeComments,
+		ReviewSleep:     200 * time.Millisecond,
+	}
+	newClient := &AnalyzerClientMock{CommentsBuilder: makeComments}
+
+	poster := &PosterMock{}
+	srv := NewServer(Options{
+		Poster:     poster,
+		FileGetter: &FileGetterMock{},
+		Analyzers: map[string]lookout.Analyzer{
+			"old": lookout.Analyzer{Client: oldClient},
+		},
+	})
+
+	handled := make(chan error, 1)
+	go func() {
+		handled <- srv.HandleEvent(context.Background(), correctReviewEvent())
+	}()
+
+	// wait for the event to start using the old analyzer
+	time.Sleep(50 * time.Millisecond)
+
+	drained := srv.Reload(ReloadOptions{
+		Analyzers: map[string]lookout.Analyzer{
+			"new": lookout.Analyzer{Client: newClient},
+		},
+	})
+
+	select {
+	case <-drained:
+		require.Fail("drained before the in-flight event finished")
+	default:
+	}
+
+	require.NoError(<-handled)
+	select {
+	case <-drained:
+	case <-time.After(time.Second):
+		require.Fail("not drained after the in-flight event finished")
+	}
+
+	require.Len(oldClient.PopReviewEvents(), 1)
+	require.Len(newClient.PopReviewEvents(), 0)
+	require.Len(poster.PopComments(), 1)
+
+	require.NoError(srv.HandleEvent(context.Background(), correctPushEvent()))
+	require.Len(oldClient.PopPushEvents(), 0)
+	require.Len(newClient.PopPushEvents(), 1)
+	require.Len(poster.PopComments(), 1)
+
+	health := srv.AnalyzersHealth()
+	require.Contains(health, "new")
+	require.NotContains(health, "old")
+}
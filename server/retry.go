@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryPolicy defines how the processing of events that failed because of a
+// transient error is retried
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times an event is processed.
+	// Zero or one means failed events are never retried.
+	MaxAttempts int `yaml:"max_attempts"`
+	// InitialBackoff is the delay before the first retry
+	InitialBackoff time.Duration `yaml:"initial_backoff"`
+	// MaxBackoff is the maximum delay between two attempts. Zero means no limit.
+	MaxBackoff time.Duration `yaml:"max_backoff"`
+	// Multiplier is the factor the delay is multiplied by after each attempt
+	Multiplier float64 `yaml:"multiplier"`
+}
+
+// DefaultRetryPolicy is the RetryPolicy used by lookoutd if it is not
+// configured
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    5,
+	InitialBackoff: time.Minute,
+	MaxBackoff:     time.Hour,
+	Multiplier:     2,
+}
+
+// Backoff returns the delay to wait after the given attempt, starting from 1,
+// before trying again
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	backoff := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		return p.MaxBackoff
+	}
+
+	return time.Duration(backoff)
+}
+
+// CanRetry returns true if a new attempt is allowed after the given number
+// of attempts
+func (p RetryPolicy) CanRetry(attempts int) bool {
+	return attempts < p.MaxAttempts
+}
+
+// IsTransientError returns true if the error, or any of its causes, is
+// likely to go away if the operation is retried: analyzers that are
+// unavailable or timed out, network timeouts, and errors reporting themselves
+// as temporary, like GitHub API 5xx responses.
+// The analyzers skipped by their circuit breaker are not retried, the breaker
+// already decides when they are called again. Any other error, for example a
+// validation error, is considered permanent.
+func IsTransientError(err error) bool {
+	if isCircuitOpen(err) {
+		return false
+	}
+
+	for err != nil {
+		if ErrAnalyzerUnavailable.Is(err) {
+			return true
+		}
+
+		if err == context.DeadlineExceeded {
+			return true
+		}
+
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.Unavailable, codes.DeadlineExceeded:
+				return true
+			}
+		}
+
+		if t, ok := err.(interface{ Temporary() bool }); ok && t.Temporary() {
+			return true
+		}
+
+		if t, ok := err.(interface{ Timeout() bool }); ok && t.Timeout() {
+			return true
+		}
+
+		c, ok := err.(interface{ Cause() error })
+		if !ok {
+			return false
+		}
+
+		err = c.Cause()
+	}
+
+	return false
+}
+
+// isCircuitOpen returns true if the error, or any of its causes, is an
+// ErrCircuitOpen
+func isCircuitOpen(err error) bool {
+	for err != nil {
+		if ErrCircuitOpen.Is(err) {
+			return true
+		}
+
+		c, ok := err.(interface{ Cause() error })
+		if !ok {
+			return false
+		}
+
+		err = c.Cause()
+	}
+
+	return false
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	goerrors "gopkg.in/src-d/go-errors.v1"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	require := require.New(t)
+
+	p := RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: time.Second,
+		MaxBackoff:     10 * time.Second,
+		Multiplier:     3,
+	}
+
+	require.Equal(time.Second, p.Backoff(1))
+	require.Equal(3*time.Second, p.Backoff(2))
+	require.Equal(9*time.Second, p.Backoff(3))
+	require.Equal(10*time.Second, p.Backoff(4))
+
+	require.True(p.CanRetry(4))
+	require.False(p.CanRetry(5))
+
+	require.False(RetryPolicy{}.CanRetry(1))
+}
+
+type temporaryError struct{ temporary bool }
+
+func (e *temporaryError) Error() string   { return "temporary error" }
+func (e *temporaryError) Temporary() bool { return e.temporary }
+
+func TestIsTransientError(t *testing.T) {
+	kind := goerrors.NewKind("wrapped")
+
+	testCases := []struct {
+		name      string
+		err       error
+		transient bool
+	}{
+		{"nil", nil, false},
+		{"plain", errors.New("validation failed"), false},
+		{"context deadline", context.DeadlineExceeded, true},
+		{"grpc unavailable", status.Error(codes.Unavailable, ""), true},
+		{"grpc deadline", status.Error(codes.DeadlineExceeded, ""), true},
+		{"grpc invalid argument", status.Error(codes.InvalidArgument, ""), false},
+		{"temporary", &temporaryError{true}, true},
+		{"not temporary", &temporaryError{false}, false},
+		{"wrapped transient", kind.Wrap(status.Error(codes.Unavailable, "")), true},
+		{"wrapped permanent", kind.Wrap(errors.New("validation failed")), false},
+		{"analyzer unavailable", ErrAnalyzerUnavailable.New("mock"), true},
+		{"circuit open", ErrAnalyzerUnavailable.Wrap(ErrCircuitOpen.New(), "mock"), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.transient, IsTransientError(tc.err))
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"context"
+	"strings"
+
+	"github.com/src-d/lookout"
+
+	"gopkg.in/src-d/go-git.v4/plumbing/format/gitignore"
+)
+
+// pathScope decides which files are analyzed by an analyzer, using the
+// include and exclude patterns of its configuration. The patterns follow the
+// .gitignore format, like "services/py/**" or "*.py".
+// A nil pathScope matches any file.
+type pathScope struct {
+	include []gitignore.Pattern
+	exclude []gitignore.Pattern
+}
+
+// newPathScope returns the pathScope of the analyzer configuration, or nil if
+// it doesn't define any pattern
+func newPathScope(conf lookout.AnalyzerConfig) *pathScope {
+	if len(conf.Include) == 0 && len(conf.Exclude) == 0 {
+		return nil
+	}
+
+	return &pathScope{
+		include: parsePatterns(conf.Include),
+		exclude: parsePatterns(conf.Exclude),
+	}
+}
+
+func parsePatterns(ps []string) []gitignore.Pattern {
+	res := make([]gitignore.Pattern, 0, len(ps))
+	for _, p := range ps {
+		res = append(res, gitignore.ParsePattern(p, nil))
+	}
+
+	return res
+}
+
+// Match returns true if the file path is in the scope: it matches any include
+// pattern, if there is any, and no exclude pattern
+func (s *pathScope) Match(path string) bool {
+	if s == nil {
+		return true
+	}
+
+	parts := strings.Split(path, "/")
+	if len(s.include) > 0 && !matchAny(s.include, parts) {
+		return false
+	}
+
+	return !matchAny(s.exclude, parts)
+}
+
+// MatchAny returns true if any of the file paths is in the scope
+func (s *pathScope) MatchAny(paths []string) bool {
+	for _, p := range paths {
+		if s.Match(p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchAny(ps []gitignore.Pattern, path []string) bool {
+	for _, p := range ps {
+		if p.Match(path, false) != gitignore.NoMatch {
+			return true
+		}
+	}
+
+	return false
+}
+
+// changedFiles returns the paths of the files changed by the event, both the
+// old and the new path of renamed files. It returns false if the changes
+// can't be known.
+func (s *Server) changedFiles(ctx context.Context, e lookout.Event) ([]string, bool, error) {
+	if s.changeGetter == nil {
+		return nil, false, nil
+	}
+
+	rev := e.Revision()
+	scanner, err := s.changeGetter.GetChanges(ctx, &lookout.ChangesRequest{
+		Base: &rev.Base,
+		Head: &rev.Head,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	var paths []string
+	for scanner.Next() {
+		ch := scanner.Change()
+		if ch.Base != nil {
+			paths = append(paths, ch.Base.Path)
+		}
+
+		if ch.Head != nil && (ch.Base == nil || ch.Head.Path != ch.Base.Path) {
+			paths = append(paths, ch.Head.Path)
+		}
+	}
+
+	scanner.Close()
+	if err := scanner.Err(); err != nil {
+		return nil, false, err
+	}
+
+	return paths, true, nil
+}
@@ -0,0 +1,38 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/src-d/lookout"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPathScopeMatch(t *testing.T) {
+	require := require.New(t)
+
+	var nilScope *pathScope
+	require.True(nilScope.Match("any/file.go"))
+	require.Nil(newPathScope(lookout.AnalyzerConfig{}))
+
+	scope := newPathScope(lookout.AnalyzerConfig{
+		Include: []string{"services/py/**", "*.md"},
+		Exclude: []string{"**/vendor/**"},
+	})
+
+	require.True(scope.Match("services/py/main.py"))
+	require.True(scope.Match("services/py/pkg/util.py"))
+	require.True(scope.Match("README.md"))
+	require.True(scope.Match("docs/README.md"))
+	require.False(scope.Match("services/go/main.go"))
+	require.False(scope.Match("services/py/vendor/lib.py"))
+
+	require.True(scope.MatchAny([]string{"main.go", "services/py/main.py"}))
+	require.False(scope.MatchAny([]string{"main.go"}))
+	require.False(scope.MatchAny(nil))
+
+	// only exclude patterns, anything else is in scope
+	scope = newPathScope(lookout.AnalyzerConfig{Exclude: []string{"*_test.go"}})
+	require.True(scope.Match("server/server.go"))
+	require.False(scope.Match("server/server_test.go"))
+}
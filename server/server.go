@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/src-d/lookout"
@@ -14,8 +16,21 @@ import (
 	"google.golang.org/grpc/status"
 	"gopkg.in/src-d/lookout-sdk.v0/pb"
 
+	"gopkg.in/src-d/go-errors.v1"
 	log "gopkg.in/src-d/go-log.v1"
-	yaml "gopkg.in/yaml.v2"
+)
+
+var (
+	// ErrGetConfig signals an error while reading the repository .lookout.yml
+	ErrGetConfig = errors.NewKind("Can't get .lookout.yml in revision %s")
+	// ErrAnalyzerUnavailable signals that an analyzer could not be reached or
+	// did not reply in time
+	ErrAnalyzerUnavailable = errors.NewKind("analyzer %s is unavailable")
+	// ErrPosting signals an error while posting the analysis results
+	ErrPosting = errors.NewKind("posting analysis failed")
+	// ErrSuperseded signals that the analysis results were not posted
+	// because a newer event for the same pull request was received
+	ErrSuperseded = errors.NewKind("event was superseded by a newer one")
 )
 
 var grpcErrorMessages = map[lookout.EventType]map[codes.Code]string{
@@ -30,25 +45,85 @@ var grpcErrorMessages = map[lookout.EventType]map[codes.Code]string{
 // Config is a server configuration
 type Config struct {
 	Analyzers []lookout.AnalyzerConfig
+	Policy    Policy
+	// Branches overrides the configuration for the events on the branches
+	// matching each pattern, like "release/*"
+	Branches map[string]BranchConfig `yaml:"branches"`
+}
+
+// BranchConfig overrides the configuration for some branches
+type BranchConfig struct {
+	Analyzers []lookout.AnalyzerConfig `yaml:"analyzers"`
+	Policy    Policy                   `yaml:"policy"`
+}
+
+// configLayer is a set of overrides of the analyzers configuration and the
+// policy, from a configuration file or one of its branches
+type configLayer struct {
+	// name identifies the layer in the logs
+	name string
+	// analyzers has only the analyzers listed in the layer
+	analyzers map[string]lookout.AnalyzerConfig
+	policy    Policy
+}
+
+// parsedConfig is a configuration file, resolved for the branch of an event
+type parsedConfig struct {
+	// layers are applied in order, the base configuration and then the
+	// branches matching the event
+	layers   []configLayer
+	problems configProblems
+}
+
+// repoConfig is the configuration used to analyze a repository, the result of
+// merging the server configuration, the organization configuration and the
+// repository .lookout.yml
+type repoConfig struct {
+	analyzers map[string]lookout.AnalyzerConfig
+	policy    Policy
+	// problems found validating the configuration files, the invalid parts
+	// are ignored
+	problems configProblems
 }
 
+// reqSent sends the event to the analyzer. If the analyzer fails after
+// streaming some comments, they are returned along with the error.
 type reqSent func(
 	ctx context.Context,
-	client lookout.AnalyzerClient,
+	a lookout.Analyzer,
+	conf lookout.AnalyzerConfig,
 	settings map[string]interface{},
-) ([]*lookout.Comment, error)
+) (*lookout.EventResponse, error)
 
 // Server implements glue between providers / data-server / analyzers
 type Server struct {
 	poster         lookout.Poster
 	fileGetter     lookout.FileGetter
-	analyzers      map[string]lookout.Analyzer
+	changeGetter   lookout.ChangeGetter
 	eventOp        store.EventOperator
 	commentOp      store.CommentOperator
 	organizationOp store.OrganizationOperator
+	analyzerRunOp  store.AnalyzerRunOperator
+
+	// setMu guards set, the analyzers replaced on Reload or registration,
+	// and the options they are built from
+	setMu       sync.RWMutex
+	set         *analyzerSet
+	static      ReloadOptions
+	registered  map[string]lookout.Analyzer
+	breakerConf BreakerConfig
+
+	retryPolicy RetryPolicy
+
+	reviews *reviewTracker
+
+	// statusMu serializes the analyzer statuses, the pending ones are posted
+	// by the concurrent requests to the analyzers
+	statusMu sync.Mutex
 
-	analyzerReviewTimeout time.Duration
-	analyzerPushTimeout   time.Duration
+	responseCache *responseCache
+
+	fixer lookout.Fixer
 
 	exitOnError bool
 }
@@ -57,7 +132,11 @@ type Server struct {
 type Options struct {
 	Poster     lookout.Poster
 	FileGetter lookout.FileGetter
-	Analyzers  map[string]lookout.Analyzer
+	// ChangeGetter is used to know the files changed by an event, for the
+	// analyzers scoped to some paths. Can be left unset, then the scoped
+	// analyzers are always called.
+	ChangeGetter lookout.ChangeGetter
+	Analyzers    map[string]lookout.Analyzer
 
 	// EventOp is the operator for the Event persistence. Can be left unset.
 	EventOp store.EventOperator
@@ -65,6 +144,8 @@ type Options struct {
 	CommentOp store.CommentOperator
 	// OrganizationOp is the operator for the Organization persistence. Can be left unset.
 	OrganizationOp store.OrganizationOperator
+	// AnalyzerRunOp is the operator for the AnalyzerRun persistence. Can be left unset.
+	AnalyzerRunOp store.AnalyzerRunOperator
 
 	// ReviewTimeout is the timeout for an analyzer to reply a NotifyReviewEvent.
 	// Zero means no timeout.
@@ -73,6 +154,24 @@ type Options struct {
 	// Zero means no timeout.
 	PushTimeout time.Duration
 
+	// RetryPolicy defines how events that failed because of a transient
+	// error are retried. The zero value disables retries.
+	RetryPolicy RetryPolicy
+
+	// Breaker configures the circuit breaker of each analyzer. The zero value
+	// disables them.
+	Breaker BreakerConfig
+
+	// ResponseCacheOp persists the cached analyzer responses
+	ResponseCacheOp store.ResponseCacheOperator
+	// ResponseCacheTTL is the time an analyzer response is cached. Zero
+	// disables the cache.
+	ResponseCacheTTL time.Duration
+
+	// Fixer applies the fixes of the analyzers with autofix enabled. Can be
+	// left unset, then the fixes are posted as comments.
+	Fixer lookout.Fixer
+
 	// ExitOnError set to true will stop the server and return an error
 	// if any analyzer Notify* call or a posting call fails
 	ExitOnError bool
@@ -81,19 +180,32 @@ type Options struct {
 // NewServer creates a new Server with the given options
 func NewServer(opt Options) *Server {
 	server := Server{
-		poster:                opt.Poster,
-		fileGetter:            opt.FileGetter,
-		analyzers:             opt.Analyzers,
-		eventOp:               opt.EventOp,
-		commentOp:             opt.CommentOp,
-		organizationOp:        opt.OrganizationOp,
-		analyzerReviewTimeout: opt.ReviewTimeout,
-		analyzerPushTimeout:   opt.PushTimeout,
-		exitOnError:           opt.ExitOnError,
+		poster:         opt.Poster,
+		fileGetter:     opt.FileGetter,
+		changeGetter:   opt.ChangeGetter,
+		eventOp:        opt.EventOp,
+		commentOp:      opt.CommentOp,
+		organizationOp: opt.OrganizationOp,
+		analyzerRunOp:  opt.AnalyzerRunOp,
+		breakerConf:    opt.Breaker,
+		retryPolicy:    opt.RetryPolicy,
+		reviews:        newReviewTracker(),
+		responseCache:  newResponseCache(opt.ResponseCacheOp, opt.ResponseCacheTTL),
+		fixer:          opt.Fixer,
+		exitOnError:    opt.ExitOnError,
+	}
+
+	server.static = ReloadOptions{
+		Analyzers:     opt.Analyzers,
+		ReviewTimeout: opt.ReviewTimeout,
+		PushTimeout:   opt.PushTimeout,
 	}
+	server.set = newAnalyzerSet(server.static, nil, opt.Breaker, nil)
 
 	if opt.EventOp == nil {
 		server.eventOp = &store.NoopEventOperator{}
+		// attempts can't be counted without persistence
+		server.retryPolicy = RetryPolicy{}
 	}
 
 	if opt.CommentOp == nil {
@@ -104,10 +216,20 @@ func NewServer(opt Options) *Server {
 		server.organizationOp = &store.NoopOrganizationOperator{}
 	}
 
+	if opt.AnalyzerRunOp == nil {
+		server.analyzerRunOp = &store.NoopAnalyzerRunOperator{}
+	}
+
+	if opt.ResponseCacheOp == nil {
+		server.responseCache = newResponseCache(&store.NoopResponseCacheOperator{}, 0)
+	}
+
 	return &server
 }
 
-// HandleEvent processes the event calling the analyzers, and posting the results
+// HandleEvent processes the event calling the analyzers, and posting the results.
+// If the processing fails because of a transient error and the RetryPolicy
+// allows it, a *lookout.RetryEventError is returned.
 func (s *Server) HandleEvent(ctx context.Context, e lookout.Event) error {
 	ctx, logger := ctxlog.WithLogFields(ctx, log.Fields{
 		"event-type": reflect.TypeOf(e).String(),
@@ -127,29 +249,88 @@ func (s *Server) HandleEvent(ctx context.Context, e lookout.Event) error {
 		return nil
 	}
 
-	// TODO(max): we need some retry policy here depends on errors
 	if status == models.EventStatusFailed {
 		logger.Debugf("event processing failed, skipping...")
 		return nil
 	}
 
+	if status == models.EventStatusSuperseded {
+		logger.Debugf("event was superseded by a newer one, skipping...")
+		return nil
+	}
+
+	attempts, nextRetryAt, err := s.eventOp.Attempts(ctx, e)
+	if err != nil {
+		logger.Errorf(err, "can't get event attempts from database")
+		return err
+	}
+
+	// nextRetryAt is the scheduled retry of a failed attempt, or the end of
+	// the attempt being processed
+	if wait := time.Until(nextRetryAt); wait > 0 {
+		if status == models.EventStatusRetry {
+			logger.Debugf("event retry is scheduled in %s, skipping...", wait)
+		} else {
+			logger.Debugf("event is being processed, it will be checked again in %s", wait)
+		}
+
+		return &lookout.RetryEventError{Delay: wait}
+	}
+
+	// postpone the next retry while this attempt is running, so the same event
+	// received again meanwhile is delayed instead of processed concurrently
+	attempts++
+	nextRetryAt = time.Now().Add(s.retryPolicy.Backoff(attempts))
+	if err := s.eventOp.UpdateAttempts(ctx, e, attempts, nextRetryAt); err != nil {
+		logger.Errorf(err, "can't update event attempts in database")
+		return err
+	}
+
 	// positing started before but never changed to success of failure
 	// we need to retry analyzis but post only new comments (poster should handle it)
-	safePosting := status == models.EventStatusPosting
+	// a retried event could have failed in the middle of posting as well
+	safePosting := status == models.EventStatusPosting ||
+		status == models.EventStatusRetry
+
+	// the analysis is canceled if a newer event for the same pull request
+	// arrives meanwhile
+	analysisCtx, done := s.reviews.track(ctx, e)
 
 	switch ev := e.(type) {
 	case *lookout.ReviewEvent:
-		err = s.HandleReview(ctx, ev, safePosting)
+		err = s.HandleReview(analysisCtx, ev, safePosting)
 	case *lookout.PushEvent:
-		err = s.HandlePush(ctx, ev, safePosting)
+		err = s.HandlePush(analysisCtx, ev, safePosting)
 	default:
 		logger.Debugf("ignoring unsupported event: %s", ev)
 	}
 
-	if err == nil {
+	// the cancellation by a newer event only supersedes this one if it
+	// stopped the analysis, otherwise the outcome of the handler is kept
+	canceled := done()
+	superseded := ErrSuperseded.Is(err) || (canceled && grpcCode(err) == codes.Canceled)
+
+	var retryErr *lookout.RetryEventError
+	switch {
+	case superseded:
+		logger.Infof("event was superseded by a newer one, its analysis was canceled")
+		status = models.EventStatusSuperseded
+	case err == nil:
 		status = models.EventStatusProcessed
-	} else {
-		logger.Errorf(err, "event processing failed")
+	case IsTransientError(err) && s.retryPolicy.CanRetry(attempts):
+		delay := s.retryPolicy.Backoff(attempts)
+		logger.With(log.Fields{
+			"attempt":  attempts,
+			"retry-in": delay,
+		}).Warningf("event processing failed, it will be retried: %s", err)
+
+		status = models.EventStatusRetry
+		retryErr = &lookout.RetryEventError{Delay: delay, Err: err}
+		if updateErr := s.eventOp.UpdateAttempts(ctx, e, attempts, time.Now().Add(delay)); updateErr != nil {
+			logger.Errorf(updateErr, "can't update event attempts in database")
+		}
+	default:
+		logger.With(log.Fields{"attempt": attempts}).Errorf(err, "event processing failed")
 		status = models.EventStatusFailed
 	}
 
@@ -157,6 +338,10 @@ func (s *Server) HandleEvent(ctx context.Context, e lookout.Event) error {
 		logger.Errorf(updateErr, "can't update status in database")
 	}
 
+	if retryErr != nil {
+		return retryErr
+	}
+
 	// don't fail on event processing error, just skip it
 	if !s.exitOnError {
 		return nil
@@ -176,55 +361,87 @@ func (s *Server) HandleReview(ctx context.Context, e *lookout.ReviewEvent, safeP
 		return err
 	}
 
-	repoConf, err := s.getConfig(ctx, e)
-	if err != nil {
-		return err
+	as := s.acquireAnalyzers()
+	defer s.releaseAnalyzers(as)
+
+	conf, err := s.repoConfig(ctx, e, as)
+	if cerr, ok := err.(*ConfigError); ok {
+		return s.postConfigError(ctx, e, cerr, safePosting)
 	}
 
-	orgConf, err := s.getOrgConfig(ctx, e)
 	if err != nil {
 		return err
 	}
 
-	conf := mergeConfigs(orgConf, repoConf)
-
 	s.status(ctx, e, lookout.PendingAnalysisStatus)
 
 	send := func(
 		ctx context.Context,
-		a lookout.AnalyzerClient,
+		a lookout.Analyzer,
+		conf lookout.AnalyzerConfig,
 		settings map[string]interface{},
-	) ([]*lookout.Comment, error) {
+	) (*lookout.EventResponse, error) {
+		// the analyzers are requested concurrently with their own settings,
+		// each one gets a copy of the event
+		ev := e.ReviewEvent
 		st := pb.ToStruct(settings)
 		if st != nil {
-			e.Configuration = *st
+			ev.Configuration = *st
 		}
 
-		if s.analyzerReviewTimeout > 0 {
+		if timeout := analyzerTimeout(conf.TimeoutReview, as.reviewTimeout); timeout > 0 {
 			var cancel context.CancelFunc
-			ctx, cancel = context.WithTimeout(ctx, s.analyzerReviewTimeout)
+			ctx, cancel = context.WithTimeout(ctx, timeout)
 			defer cancel()
 		}
 
-		resp, err := a.NotifyReviewEvent(ctx, &e.ReviewEvent)
-		if err != nil {
-			return nil, err
-		}
-		return resp.Comments, nil
+		return notifyReview(ctx, a, &ev)
+	}
+	results, err := s.concurrentRequest(ctx, e, as, conf.analyzers, send, grpcErrorMessages[pb.ReviewEventType])
+	if err != nil {
+		return err
 	}
-	comments, err := s.concurrentRequest(ctx, conf, send, grpcErrorMessages[pb.ReviewEventType])
+
+	// the context is canceled when the event is superseded by a newer one,
+	// don't post stale comments
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// the newer event could be processed by another worker, or before a
+	// restart or a retry of this one
+	superseded, err := s.eventOp.Superseded(ctx, e)
 	if err != nil {
 		return err
 	}
 
-	if err := s.post(ctx, e, comments, safePosting); err != nil {
+	if superseded {
+		return ErrSuperseded.New()
+	}
+
+	s.limitComments(ctx, results)
+	s.filterPaths(ctx, conf.analyzers, results)
+	s.filterConfidence(ctx, e, conf.analyzers, results)
+	s.filterSuppressed(ctx, e, results)
+	comments := results.comments()
+	st, action := conf.policy.Evaluate(comments)
+	if singleAnalyzerRerun(e) {
+		// the comments of the other analyzers are unknown, the policy can't
+		// approve or request changes
+		action = lookout.CommentReviewAction
+	}
+
+	comments = s.autofix(ctx, e, conf.analyzers, comments)
+	comments, st = conf.withProblems(comments, st)
+	if err := s.post(ctx, e, comments, safePosting, action); err != nil {
 		s.status(ctx, e, lookout.ErrorAnalysisStatus)
-		return fmt.Errorf("posting analysis failed: %s", err)
+		return ErrPosting.Wrap(err)
 	}
 
-	s.status(ctx, e, lookout.SuccessAnalysisStatus)
+	s.analyzerStatuses(ctx, e, conf.policy, results)
+	s.status(ctx, e, st)
 
-	return nil
+	return results.unavailableErr()
 }
 
 // HandlePush sends request to analyzers concurrently
@@ -238,57 +455,189 @@ func (s *Server) HandlePush(ctx context.Context, e *lookout.PushEvent, safePosti
 		return err
 	}
 
-	repoConf, err := s.getConfig(ctx, e)
-	if err != nil {
-		return err
+	as := s.acquireAnalyzers()
+	defer s.releaseAnalyzers(as)
+
+	conf, err := s.repoConfig(ctx, e, as)
+	if cerr, ok := err.(*ConfigError); ok {
+		return s.postConfigError(ctx, e, cerr, safePosting)
 	}
 
-	orgConf, err := s.getOrgConfig(ctx, e)
 	if err != nil {
 		return err
 	}
 
-	conf := mergeConfigs(orgConf, repoConf)
-
 	s.status(ctx, e, lookout.PendingAnalysisStatus)
 
 	send := func(
 		ctx context.Context,
-		a lookout.AnalyzerClient,
+		a lookout.Analyzer,
+		conf lookout.AnalyzerConfig,
 		settings map[string]interface{},
-	) ([]*lookout.Comment, error) {
+	) (*lookout.EventResponse, error) {
+		// the analyzers are requested concurrently with their own settings,
+		// each one gets a copy of the event
+		ev := e.PushEvent
 		st := pb.ToStruct(settings)
 		if st != nil {
-			e.Configuration = *st
+			ev.Configuration = *st
 		}
 
-		if s.analyzerPushTimeout > 0 {
+		if timeout := analyzerTimeout(conf.TimeoutPush, as.pushTimeout); timeout > 0 {
 			var cancel context.CancelFunc
-			ctx, cancel = context.WithTimeout(ctx, s.analyzerPushTimeout)
+			ctx, cancel = context.WithTimeout(ctx, timeout)
 			defer cancel()
 		}
 
-		resp, err := a.NotifyPushEvent(ctx, &e.PushEvent)
-		if err != nil {
-			return nil, err
-		}
-		return resp.Comments, nil
+		return notifyPush(ctx, a, &ev)
 	}
-	comments, err := s.concurrentRequest(ctx, conf, send, grpcErrorMessages[pb.PushEventType])
+	results, err := s.concurrentRequest(ctx, e, as, conf.analyzers, send, grpcErrorMessages[pb.PushEventType])
 	if err != nil {
 		return err
 	}
 
-	if err := s.post(ctx, e, comments, safePosting); err != nil {
+	s.limitComments(ctx, results)
+	s.filterPaths(ctx, conf.analyzers, results)
+	s.filterConfidence(ctx, e, conf.analyzers, results)
+	s.filterSuppressed(ctx, e, results)
+	comments := results.comments()
+	st, _ := conf.policy.Evaluate(comments)
+	comments, st = conf.withProblems(comments, st)
+	if err := s.post(ctx, e, comments, safePosting, lookout.CommentReviewAction); err != nil {
+		s.status(ctx, e, lookout.ErrorAnalysisStatus)
+		return ErrPosting.Wrap(err)
+	}
+
+	s.analyzerStatuses(ctx, e, conf.policy, results)
+	s.status(ctx, e, st)
+
+	return results.unavailableErr()
+}
+
+// repoConfig returns the configuration for the event, merging the
+// organization configuration with the repository one, and the branch
+// overrides of each of them. If any of them can't be used it returns a
+// *ConfigError.
+func (s *Server) repoConfig(ctx context.Context, e lookout.Event, as *analyzerSet) (*repoConfig, error) {
+	branch := eventBranch(e)
+
+	repoConf, repoErr := s.getConfig(ctx, e, as, branch)
+	if _, ok := repoErr.(*ConfigError); repoErr != nil && !ok {
+		return nil, repoErr
+	}
+
+	orgConf, orgErr := s.getOrgConfig(ctx, e, as, branch)
+	if _, ok := orgErr.(*ConfigError); orgErr != nil && !ok {
+		return nil, orgErr
+	}
+
+	if repoErr != nil || orgErr != nil {
+		problems := make(configProblems)
+		for _, err := range []error{orgErr, repoErr} {
+			if cerr, ok := err.(*ConfigError); ok {
+				for source, ps := range cerr.Problems {
+					problems.add(source, ps)
+				}
+			}
+		}
+
+		return nil, &ConfigError{Problems: problems}
+	}
+
+	analyzers := make(map[string]lookout.AnalyzerConfig, len(as.analyzers))
+	for name, a := range as.analyzers {
+		// the push autofix is opted in by the repositories
+		c := a.Config
+		c.AutofixPush = false
+		analyzers[name] = c
+	}
+
+	conf := &repoConfig{
+		analyzers: analyzers,
+		policy:    DefaultPolicy,
+		problems:  make(configProblems),
+	}
+
+	var layers []string
+	for _, c := range []*parsedConfig{orgConf, repoConf} {
+		if c == nil {
+			continue
+		}
+
+		for _, l := range c.layers {
+			conf.analyzers = mergeConfigs(conf.analyzers, l.analyzers)
+			conf.policy = conf.policy.merge(l.policy)
+			layers = append(layers, l.name)
+		}
+
+		for source, ps := range c.problems {
+			conf.problems.add(source, ps)
+		}
+	}
+
+	// the repositories can't make the analyzers wait longer than the server
+	// allows
+	for name, a := range as.analyzers {
+		c := conf.analyzers[name]
+		c.TimeoutReview = capTimeout(c.TimeoutReview,
+			analyzerTimeout(a.Config.TimeoutReview, as.reviewTimeout))
+		c.TimeoutPush = capTimeout(c.TimeoutPush,
+			analyzerTimeout(a.Config.TimeoutPush, as.pushTimeout))
+		conf.analyzers[name] = c
+	}
+
+	// the settings can hold secrets, only the names are logged by default
+	logger := ctxlog.Get(ctx).With(log.Fields{
+		"branch":        branch,
+		"config-layers": strings.Join(layers, ", "),
+	})
+	logger.With(log.Fields{
+		"analyzers": strings.Join(conf.enabledAnalyzers(), ", "),
+	}).Infof("effective configuration")
+	logger.With(log.Fields{"config": conf.String()}).
+		Debugf("effective configuration details")
+
+	return conf, nil
+}
+
+// withProblems adds to the comments a global comment describing the
+// configuration problems, if any. The analysis status is then an error.
+func (c *repoConfig) withProblems(
+	comments []lookout.AnalyzerComments,
+	st lookout.AnalysisStatus,
+) ([]lookout.AnalyzerComments, lookout.AnalysisStatus) {
+	if len(c.problems) == 0 {
+		return comments, st
+	}
+
+	comment := c.problems.comment("There are problems in the configuration of the analysis, " +
+		"the invalid parts were ignored. Please fix them:")
+	return append(comments, comment), lookout.ErrorAnalysisStatus
+}
+
+// postConfigError posts a comment describing the configuration problems that
+// prevent the analysis, and sets its status to error. The event is not
+// retried, the configuration must be fixed by the users.
+func (s *Server) postConfigError(ctx context.Context, e lookout.Event, cerr *ConfigError, safe bool) error {
+	ctxlog.Get(ctx).Warningf("the analysis can't run: %s", cerr)
+
+	comment := configProblems(cerr.Problems).comment(
+		"The analysis could not run because of errors in its configuration. Please fix them:")
+	if err := s.post(ctx, e, []lookout.AnalyzerComments{comment}, safe, lookout.CommentReviewAction); err != nil {
 		s.status(ctx, e, lookout.ErrorAnalysisStatus)
-		return fmt.Errorf("posting analysis failed: %s", err)
+		return ErrPosting.Wrap(err)
 	}
-	s.status(ctx, e, lookout.SuccessAnalysisStatus)
 
+	s.status(ctx, e, lookout.ErrorAnalysisStatus)
 	return nil
 }
 
-func (s *Server) getConfig(ctx context.Context, e lookout.Event) (map[string]lookout.AnalyzerConfig, error) {
+func (s *Server) getConfig(
+	ctx context.Context,
+	e lookout.Event,
+	as *analyzerSet,
+	branch string,
+) (*parsedConfig, error) {
 	rev := e.Revision()
 	ctxlog.Get(ctx).Debugf("getting .lookout.yml")
 	scanner, err := s.fileGetter.GetFiles(ctx, &lookout.FilesRequest{
@@ -297,7 +646,7 @@ func (s *Server) getConfig(ctx context.Context, e lookout.Event) (map[string]loo
 		WantContents:   true,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("Can't get .lookout.yml in revision %s: %s", rev.Head, err)
+		return nil, ErrGetConfig.Wrap(err, rev.Head)
 	}
 	var configContent []byte
 	if scanner.Next() {
@@ -314,76 +663,252 @@ func (s *Server) getConfig(ctx context.Context, e lookout.Event) (map[string]loo
 	}
 
 	parseCtx, _ := ctxlog.WithLogFields(ctx, log.Fields{"config-file": "repository .lookout.yml"})
-	conf, err := s.parseConfig(parseCtx, configContent)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get the local .lookout.yml file from the repository: %s", err)
-	}
-
-	return conf, nil
+	return s.parseConfig(parseCtx, as, repoConfigSource, configContent, branch)
 }
 
-func (s *Server) parseConfig(ctx context.Context, configContent []byte) (map[string]lookout.AnalyzerConfig, error) {
-	var conf Config
-	if err := yaml.Unmarshal(configContent, &conf); err != nil {
-		return nil, fmt.Errorf("can't parse configuration file: %s", err)
+// parseConfig parses and validates the configuration content, and resolves
+// it for the given branch. The problems found are kept in the returned
+// parsedConfig, unless the content can't be parsed at all, then a
+// *ConfigError is returned.
+func (s *Server) parseConfig(
+	ctx context.Context,
+	as *analyzerSet,
+	source string,
+	configContent []byte,
+	branch string,
+) (*parsedConfig, error) {
+	conf, problems, fatal := decodeConfig(configContent, as.known)
+	if fatal {
+		return nil, &ConfigError{Problems: map[string][]string{source: problems}}
+	}
+
+	for _, p := range problems {
+		ctxlog.Get(ctx).Warningf("invalid configuration: %s", p)
 	}
 
-	res := make(map[string]lookout.AnalyzerConfig, len(s.analyzers))
-	for name, a := range s.analyzers {
-		res[name] = a.Config
+	res := &parsedConfig{
+		layers: []configLayer{{
+			name:      source,
+			analyzers: analyzersByName(conf.Analyzers),
+			policy:    conf.Policy,
+		}},
+		problems: make(configProblems),
 	}
-	for _, aConf := range conf.Analyzers {
-		if _, ok := s.analyzers[aConf.Name]; !ok {
-			ctxlog.Get(ctx).Warningf("analyzer '%s' required by configuration file isn't enabled on server", aConf.Name)
-			continue
-		}
-		res[aConf.Name] = aConf
+
+	for _, pattern := range matchingBranches(conf.Branches, branch) {
+		b := conf.Branches[pattern]
+		res.layers = append(res.layers, configLayer{
+			name:      fmt.Sprintf("%s branches %s", source, pattern),
+			analyzers: analyzersByName(b.Analyzers),
+			policy:    b.Policy,
+		})
 	}
 
+	res.problems.add(source, problems)
+
 	return res, nil
 }
 
-func (s *Server) getOrgConfig(ctx context.Context, e lookout.Event) (map[string]lookout.AnalyzerConfig, error) {
+func analyzersByName(list []lookout.AnalyzerConfig) map[string]lookout.AnalyzerConfig {
+	res := make(map[string]lookout.AnalyzerConfig, len(list))
+	for _, a := range list {
+		res[a.Name] = a
+	}
+
+	return res
+}
+
+func (s *Server) getOrgConfig(
+	ctx context.Context,
+	e lookout.Event,
+	as *analyzerSet,
+	branch string,
+) (*parsedConfig, error) {
 	configContent, err := s.organizationOp.Config(ctx, e.GetProvider(), e.GetOrganizationID())
 	if err != nil {
 		return nil, fmt.Errorf("could not load default configuration for organization from the DB: %s", err)
 	}
 
 	parseCtx, _ := ctxlog.WithLogFields(ctx, log.Fields{"config-file": "organization default"})
-	conf, err := s.parseConfig(parseCtx, []byte(configContent))
-	if err != nil {
-		return nil, fmt.Errorf("failed to get the organization default configuration from the DB: %s", err)
+	return s.parseConfig(parseCtx, as, orgConfigSource, []byte(configContent), branch)
+}
+
+// analyzerResult is the outcome of the request to a single analyzer
+type analyzerResult struct {
+	name     string
+	config   lookout.AnalyzerConfig
+	comments []*lookout.Comment
+	// skipped is true if the analyzer was not called because the event
+	// doesn't change any file in its paths
+	skipped bool
+	// suppressed is the number of comments suppressed by markers in the
+	// source code
+	suppressed int
+	// partial is true if the analyzer timed out, and comments are the ones
+	// it streamed before
+	partial bool
+	// limited is true if the analyzer produced more comments than its
+	// max_comments, and the rest were dropped
+	limited bool
+	// err is the error of the request, or the reason the analyzer was skipped
+	err error
+}
+
+type analyzerResults []analyzerResult
+
+// comments returns the comments of the analyzers that produced any
+func (rs analyzerResults) comments() []lookout.AnalyzerComments {
+	var res []lookout.AnalyzerComments
+	for _, r := range rs {
+		if len(r.comments) == 0 {
+			continue
+		}
+
+		res = append(res, lookout.AnalyzerComments{
+			Config:   r.config,
+			Comments: r.comments,
+			Partial:  r.partial,
+		})
 	}
 
-	return conf, nil
+	return res
 }
 
-func (s *Server) concurrentRequest(ctx context.Context, conf map[string]lookout.AnalyzerConfig, send reqSent, logErrorMessages map[codes.Code]string) ([]lookout.AnalyzerComments, error) {
+// unavailableErr returns the first transient error of the analyzers, if any,
+// as an ErrAnalyzerUnavailable
+func (rs analyzerResults) unavailableErr() error {
+	for _, r := range rs {
+		if r.err == nil || !IsTransientError(r.err) {
+			continue
+		}
+
+		if ErrAnalyzerUnavailable.Is(r.err) {
+			return r.err
+		}
+
+		return ErrAnalyzerUnavailable.Wrap(r.err, r.name)
+	}
+
+	return nil
+}
+
+// concurrentRequest calls the analyzers concurrently and returns their
+// results. Analyzer failures don't stop the other analyzers unless
+// exitOnError is set, the failures are part of the results so the caller can
+// post the available comments and retry later. Disabled analyzers have no
+// result.
+func (s *Server) concurrentRequest(
+	ctx context.Context,
+	e lookout.Event,
+	as *analyzerSet,
+	conf map[string]lookout.AnalyzerConfig,
+	send reqSent,
+	logErrorMessages map[codes.Code]string,
+) (analyzerResults, error) {
 	var cancel context.CancelFunc
 	ctx, cancel = context.WithCancel(ctx)
 	defer cancel()
 
-	commentsCh := make(chan *lookout.AnalyzerComments, len(s.analyzers))
-	errCh := make(chan error)
+	resultsCh := make(chan *analyzerResult, len(as.analyzers))
+	// buffered, so the analyzers failing after the first error don't block
+	errCh := make(chan error, len(as.analyzers))
+
+	changed, changesKnown := s.scopedChanges(ctx, e, as, conf)
+
+	// the analyzers with depends_on wait for the ones they depend on
+	p := newPipeline(as)
+
+	rerun := lookout.RerunOf(e)
+	if rerun != nil && rerun.Analyzer != "" {
+		if _, ok := as.analyzers[rerun.Analyzer]; !ok {
+			ctxlog.Get(ctx).Warningf("analyzer %s requested to rerun is not configured", rerun.Analyzer)
+		}
+	}
+
+	for name, a := range as.analyzers {
+		if rerun != nil && rerun.Analyzer != "" && rerun.Analyzer != name {
+			ctxlog.Get(ctx).Debugf("analyzer %s skipped, the rerun is only for %s", name, rerun.Analyzer)
+			p.finish(name, nil)
+			resultsCh <- nil
+			continue
+		}
 
-	for name, a := range s.analyzers {
 		if a.Config.Disabled || conf[name].Disabled {
 			ctxlog.Get(ctx).Infof("analyzer %s disabled by local repository configuration", name)
-			commentsCh <- nil
+			p.finish(name, nil)
+			resultsCh <- nil
+			continue
+		}
+
+		if !supportsEvent(a, e.Type()) {
+			ctxlog.Get(ctx).Debugf("analyzer %s skipped, it does not support the event type", name)
+			p.finish(name, nil)
+			resultsCh <- nil
+			continue
+		}
+
+		if changesKnown && !newPathScope(conf[name]).MatchAny(changed) {
+			ctxlog.Get(ctx).Infof("analyzer %s skipped, no changes in its paths", name)
+			p.finish(name, nil)
+			resultsCh <- &analyzerResult{name: name, config: a.Config, skipped: true}
 			continue
 		}
 
 		go func(name string, a lookout.Analyzer) {
-			var result *lookout.AnalyzerComments
-			defer func() { commentsCh <- result }()
+			result := &analyzerResult{name: name, config: a.Config}
+			defer func() {
+				p.finish(name, result.comments)
+				resultsCh <- result
+			}()
 
 			ctx, aLogger := ctxlog.WithLogFields(ctx, log.Fields{
 				"analyzer": name,
 			})
 
-			settings := mergeSettings(a.Config.Settings, conf[name].Settings)
+			upstream, err := p.upstream(ctx, a.Config.DependsOn)
+			if err != nil {
+				result.err = err
+				return
+			}
+
+			settings := withUpstream(mergeSettings(a.Config.Settings, conf[name].Settings), upstream)
+
+			// a rerun does not use the cached responses, but updates them
+			cache := s.responseCache.enabled(a.Config)
+			if cache && rerun == nil {
+				if resp := s.responseCache.get(ctx, e, name, settings); resp != nil {
+					aLogger.With(log.Fields{
+						"analyzer-version": resp.AnalyzerVersion,
+						"comments":         len(resp.Comments),
+					}).Infof("using cached response")
+
+					result.comments = resp.Comments
+					return
+				}
+			}
+
+			breaker := as.breakers[name]
+			if !breaker.Allow(ctx) {
+				st := breaker.Status()
+				aLogger.With(log.Fields{
+					"breaker-state":        st.State,
+					"consecutive-failures": st.ConsecutiveFailures,
+				}).Warningf("analyzer skipped, its circuit breaker is open")
 
-			cs, err := send(ctx, a.Client, settings)
+				result.err = ErrAnalyzerUnavailable.Wrap(ErrCircuitOpen.New(), name)
+				return
+			}
+
+			s.analyzerStatus(ctx, e, lookout.AnalyzerStatus{
+				Analyzer:    name,
+				Status:      lookout.PendingAnalysisStatus,
+				Description: "The analysis is in progress",
+			})
+
+			startedAt := time.Now()
+			resp, err := send(ctx, a, conf[name], settings)
+			breaker.Report(ctx, err)
+			s.saveAnalyzerRun(ctx, e, name, startedAt, resp, err)
 			if err != nil {
 				grpcStatus := status.Convert(err)
 				errMessage := "analysis failed"
@@ -398,34 +923,271 @@ func (s *Server) concurrentRequest(ctx context.Context, conf map[string]lookout.
 					errCh <- err
 				}
 
+				result.err = err
+				if resp != nil && len(resp.Comments) > 0 && grpcCode(err) == codes.DeadlineExceeded {
+					aLogger.With(log.Fields{"comments": len(resp.Comments)}).
+						Warningf("the comments produced before the timeout are posted as partial results")
+					result.comments = resp.Comments
+					result.partial = true
+				}
+
 				return
 			}
 
-			if len(cs) == 0 {
+			aLogger = aLogger.With(log.Fields{
+				"analyzer-version": resp.AnalyzerVersion,
+			})
+
+			// a stream is not read further than the limit, the response
+			// could be incomplete
+			if cache && !exceedsLimit(a.Config, resp.Comments) {
+				s.responseCache.save(ctx, e, name, settings, resp)
+			}
+
+			if len(resp.Comments) == 0 {
 				aLogger.Infof("no comments were produced")
 				return
 			}
 
-			result = &lookout.AnalyzerComments{
-				Config:   a.Config,
-				Comments: cs,
-			}
+			result.comments = resp.Comments
 		}(name, a)
 	}
 
-	var comments []lookout.AnalyzerComments
-	for i := 0; i < len(s.analyzers); i++ {
+	var results analyzerResults
+	for i := 0; i < len(as.analyzers); i++ {
 		select {
 		case err := <-errCh:
 			return nil, err
-		case cs := <-commentsCh:
-			if cs != nil {
-				comments = append(comments, *cs)
+		case r := <-resultsCh:
+			if r != nil {
+				results = append(results, *r)
 			}
 		}
 	}
 
-	return comments, nil
+	return results, nil
+}
+
+// scopedChanges returns the files changed by the event if any enabled
+// analyzer is scoped to some paths. It returns false if the changes are not
+// needed or can't be known, then all the analyzers must be called.
+func (s *Server) scopedChanges(
+	ctx context.Context,
+	e lookout.Event,
+	as *analyzerSet,
+	conf map[string]lookout.AnalyzerConfig,
+) ([]string, bool) {
+	var scoped bool
+	for name, a := range as.analyzers {
+		if !a.Config.Disabled && !conf[name].Disabled && newPathScope(conf[name]) != nil {
+			scoped = true
+			break
+		}
+	}
+
+	if !scoped {
+		return nil, false
+	}
+
+	changed, ok, err := s.changedFiles(ctx, e)
+	if err != nil {
+		ctxlog.Get(ctx).Errorf(err, "can't get the changed files, all the analyzers will be called")
+		return nil, false
+	}
+
+	return changed, ok
+}
+
+// limitComments drops the comments of each result over the max_comments of
+// its analyzer
+func (s *Server) limitComments(ctx context.Context, results analyzerResults) {
+	for i, r := range results {
+		if !exceedsLimit(r.config, r.comments) {
+			continue
+		}
+
+		results[i].comments = r.comments[:r.config.MaxComments]
+		results[i].limited = true
+
+		ctxlog.Get(ctx).With(log.Fields{
+			"analyzer":     r.name,
+			"max-comments": r.config.MaxComments,
+		}).Warningf("the analyzer produced too many comments, the rest are dropped")
+	}
+}
+
+func exceedsLimit(conf lookout.AnalyzerConfig, comments []*lookout.Comment) bool {
+	return conf.MaxComments > 0 && len(comments) > conf.MaxComments
+}
+
+// filterPaths removes from the results the comments on files outside the
+// paths of their analyzer. Global comments are kept.
+func (s *Server) filterPaths(
+	ctx context.Context,
+	conf map[string]lookout.AnalyzerConfig,
+	results analyzerResults,
+) {
+	for i, r := range results {
+		scope := newPathScope(conf[r.name])
+		if scope == nil || len(r.comments) == 0 {
+			continue
+		}
+
+		var comments []*lookout.Comment
+		for _, c := range r.comments {
+			if c.File == "" || scope.Match(c.File) {
+				comments = append(comments, c)
+			}
+		}
+
+		results[i].comments = comments
+
+		ctxlog.Get(ctx).With(log.Fields{
+			"analyzer": r.name,
+			"filtered": len(r.comments) - len(comments),
+		}).Debugf("comments filtered by path")
+	}
+}
+
+// filterConfidence removes from the results the comments with a confidence
+// lower than the min_confidence of their analyzer. The filtered out comments
+// are saved.
+func (s *Server) filterConfidence(
+	ctx context.Context,
+	e lookout.Event,
+	conf map[string]lookout.AnalyzerConfig,
+	results analyzerResults,
+) {
+	for i, r := range results {
+		minConfidence := conf[r.name].MinConfidence
+		if minConfidence == 0 || len(r.comments) == 0 {
+			continue
+		}
+
+		groups := lookout.AnalyzerCommentsGroups{{Config: r.config, Comments: r.comments}}
+		filtered, _ := groups.Filter(func(c *lookout.Comment) (bool, error) {
+			if c.Confidence >= minConfidence {
+				return false, nil
+			}
+
+			m := models.NewFilteredComment(e, c, r.name, minConfidence)
+			if err := s.commentOp.SaveFiltered(ctx, m); err != nil {
+				ctxlog.Get(ctx).Errorf(err, "can't save filtered comment")
+			}
+
+			return true, nil
+		})
+
+		results[i].comments = nil
+		if len(filtered) > 0 {
+			results[i].comments = filtered[0].Comments
+		}
+
+		ctxlog.Get(ctx).With(log.Fields{
+			"analyzer":       r.name,
+			"min-confidence": minConfidence,
+			"filtered":       len(r.comments) - len(results[i].comments),
+		}).Debugf("comments filtered by confidence")
+	}
+}
+
+// analyzerStatuses posts the final status of each analyzer
+func (s *Server) analyzerStatuses(ctx context.Context, e lookout.Event, policy Policy, results analyzerResults) {
+	for _, r := range results {
+		st := lookout.AnalyzerStatus{Analyzer: r.name}
+
+		switch {
+		case r.skipped:
+			st.Status = lookout.SuccessAnalysisStatus
+			st.Description = "No changes in the analyzer paths"
+		case r.partial:
+			st.Status = lookout.ErrorAnalysisStatus
+			st.Description = commentsDescription(len(r.comments)) + " before timing out"
+		case ErrCircuitOpen.Is(r.err):
+			st.Status = lookout.ErrorAnalysisStatus
+			st.Description = "The analyzer is unavailable"
+		case r.err != nil && grpcCode(r.err) == codes.DeadlineExceeded:
+			st.Status = lookout.ErrorAnalysisStatus
+			st.Description = "The analysis timed out"
+		case r.err != nil:
+			st.Status = lookout.ErrorAnalysisStatus
+			st.Description = "There was an error during the analysis"
+		default:
+			st.Status, _ = policy.Evaluate([]lookout.AnalyzerComments{{
+				Config:   r.config,
+				Comments: r.comments,
+			}})
+			st.Description = commentsDescription(len(r.comments))
+			if r.suppressed > 0 {
+				st.Description += fmt.Sprintf(", %d suppressed", r.suppressed)
+			}
+
+			if r.limited {
+				st.Description += fmt.Sprintf(", the ones over the limit of %d were dropped", r.config.MaxComments)
+			}
+		}
+
+		s.analyzerStatus(ctx, e, st)
+	}
+}
+
+func commentsDescription(n int) string {
+	switch n {
+	case 0:
+		return "The analysis produced no comments"
+	case 1:
+		return "The analysis produced 1 comment"
+	default:
+		return fmt.Sprintf("The analysis produced %d comments", n)
+	}
+}
+
+func (s *Server) saveAnalyzerRun(
+	ctx context.Context,
+	e lookout.Event,
+	name string,
+	startedAt time.Time,
+	resp *lookout.EventResponse,
+	err error,
+) {
+	run := models.NewAnalyzerRun(e, name, startedAt)
+	run.FinishedAt = time.Now()
+	run.StatusCode = uint32(grpcCode(err))
+
+	if resp != nil {
+		run.Version = resp.AnalyzerVersion
+		run.Comments = len(resp.Comments)
+	}
+
+	if err := s.analyzerRunOp.Save(ctx, run); err != nil {
+		ctxlog.Get(ctx).Errorf(err, "can't save analyzer run")
+	}
+}
+
+// grpcCode returns the gRPC status code of an analyzer request error,
+// converting the errors of the context of the request
+func grpcCode(err error) codes.Code {
+	switch err {
+	case context.Canceled:
+		return codes.Canceled
+	case context.DeadlineExceeded:
+		return codes.DeadlineExceeded
+	default:
+		return status.Code(err)
+	}
+}
+
+// AnalyzersHealth returns the status of the circuit breaker of each analyzer
+func (s *Server) AnalyzersHealth() map[string]BreakerStatus {
+	s.setMu.RLock()
+	defer s.setMu.RUnlock()
+
+	res := make(map[string]BreakerStatus, len(s.set.breakers))
+	for name, b := range s.set.breakers {
+		res[name] = b.Status()
+	}
+
+	return res
 }
 
 func mergeConfigs(global, local map[string]lookout.AnalyzerConfig) map[string]lookout.AnalyzerConfig {
@@ -445,6 +1207,39 @@ func mergeConfigs(global, local map[string]lookout.AnalyzerConfig) map[string]lo
 	for k, v := range local {
 		if globalV, ok := merged[k]; ok {
 			globalV.Settings = mergeMaps(globalV.Settings, v.Settings)
+			if v.MinConfidence != 0 {
+				globalV.MinConfidence = v.MinConfidence
+			}
+
+			// a layer can disable the analyzer, but not enable it again
+			if v.Disabled {
+				globalV.Disabled = true
+			}
+
+			if v.Include != nil {
+				globalV.Include = v.Include
+			}
+
+			if v.Exclude != nil {
+				globalV.Exclude = v.Exclude
+			}
+
+			if v.TimeoutReview != 0 {
+				globalV.TimeoutReview = v.TimeoutReview
+			}
+
+			if v.TimeoutPush != 0 {
+				globalV.TimeoutPush = v.TimeoutPush
+			}
+
+			if v.OutOfDiff != "" {
+				globalV.OutOfDiff = v.OutOfDiff
+			}
+
+			if v.AutofixPush {
+				globalV.AutofixPush = true
+			}
+
 			merged[k] = globalV
 			continue
 		}
@@ -488,8 +1283,25 @@ func mergeMaps(global, local map[string]interface{}) map[string]interface{} {
 	return merged
 }
 
-func (s *Server) post(ctx context.Context, e lookout.Event, comments lookout.AnalyzerCommentsGroups, safe bool) error {
+func (s *Server) post(
+	ctx context.Context,
+	e lookout.Event,
+	comments lookout.AnalyzerCommentsGroups,
+	safe bool,
+	action lookout.ReviewAction,
+) error {
+	// the posted comments are only checked for pull requests, the comments of
+	// a push are posted for its own head commit
+	_, review := e.(*lookout.ReviewEvent)
+
+	// a rerun posts again the comments already posted
+	rerun := lookout.RerunOf(e) != nil
+
 	comments, err := comments.Dedup().Filter(func(c *lookout.Comment) (bool, error) {
+		if !review || rerun {
+			return false, nil
+		}
+
 		yes, err := s.commentOp.Posted(ctx, e, c)
 		if err != nil {
 			ctxlog.Get(ctx).Errorf(err, "comment posted check failed")
@@ -502,7 +1314,9 @@ func (s *Server) post(ctx context.Context, e lookout.Event, comments lookout.Ana
 		return err
 	}
 
-	if len(comments) == 0 {
+	// an approval is posted even if there are no comments, and so are the
+	// results of a push, to clear the findings of the previous one
+	if len(comments) == 0 && review && action != lookout.ApproveReviewAction {
 		return nil
 	}
 
@@ -515,9 +1329,10 @@ func (s *Server) post(ctx context.Context, e lookout.Event, comments lookout.Ana
 
 	ctxlog.Get(ctx).With(log.Fields{
 		"comments": comments.Count(),
+		"review":   action,
 	}).Infof("posting analysis")
 
-	if err := s.poster.Post(ctx, e, comments, safe); err != nil {
+	if err := s.poster.Post(ctx, e, comments, safe, action); err != nil {
 		return err
 	}
 
@@ -533,17 +1348,42 @@ func (s *Server) post(ctx context.Context, e lookout.Event, comments lookout.Ana
 }
 
 func (s *Server) status(ctx context.Context, e lookout.Event, st lookout.AnalysisStatus) {
+	// the global status depends on the results of all the analyzers
+	if singleAnalyzerRerun(e) {
+		return
+	}
+
 	if err := s.poster.Status(ctx, e, st); err != nil {
 		ctxlog.Get(ctx).With(log.Fields{"status": st}).Errorf(err, "posting status failed")
 	}
 }
 
+// singleAnalyzerRerun returns true if the event is a rerun of only one
+// analyzer
+func singleAnalyzerRerun(e lookout.Event) bool {
+	rerun := lookout.RerunOf(e)
+	return rerun != nil && rerun.Analyzer != ""
+}
+
+func (s *Server) analyzerStatus(ctx context.Context, e lookout.Event, st lookout.AnalyzerStatus) {
+	s.statusMu.Lock()
+	defer s.statusMu.Unlock()
+
+	if err := s.poster.AnalyzerStatus(ctx, e, st); err != nil {
+		ctxlog.Get(ctx).With(log.Fields{
+			"analyzer": st.Analyzer,
+			"status":   st.Status,
+		}).Errorf(err, "posting analyzer status failed")
+	}
+}
+
 type LogPoster struct {
 	Log log.Logger
 }
 
 func (p *LogPoster) Post(ctx context.Context, e lookout.Event,
-	aCommentsList []lookout.AnalyzerComments, safe bool) error {
+	aCommentsList []lookout.AnalyzerComments, safe bool, action lookout.ReviewAction) error {
+	p.Log.Infof("review: %s", action)
 	for _, aComments := range aCommentsList {
 		for _, c := range aComments.Comments {
 			logger := p.Log.With(log.Fields{
@@ -573,4 +1413,10 @@ func (p *LogPoster) Status(ctx context.Context, e lookout.Event,
 	return nil
 }
 
+func (p *LogPoster) AnalyzerStatus(ctx context.Context, e lookout.Event,
+	st lookout.AnalyzerStatus) error {
+	p.Log.Infof("analyzer %s status: %s, %s", st.Analyzer, st.Status, st.Description)
+	return nil
+}
+
 var _ lookout.Poster = &LogPoster{}
@@ -2,7 +2,9 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
 	"testing"
 	"time"
 
@@ -13,6 +15,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
 	log "gopkg.in/src-d/go-log.v1"
 	"gopkg.in/src-d/lookout-sdk.v0/pb"
 )
@@ -144,6 +148,40 @@ func (s *ServerTestSuite) TestReviewTimeout() {
 	require.Len(comments, 1)
 }
 
+func (s *ServerTestSuite) TestAnalyzerStatus() {
+	require := s.Require()
+
+	watcher, poster := setupMockedServerDefault()
+
+	require.Nil(watcher.Send(correctReviewEvent()))
+	require.Equal([]lookout.AnalyzerStatus{{
+		Analyzer:    "mock",
+		Status:      lookout.PendingAnalysisStatus,
+		Description: "The analysis is in progress",
+	}, {
+		Analyzer:    "mock",
+		Status:      lookout.SuccessAnalysisStatus,
+		Description: "The analysis produced 1 comment",
+	}}, poster.PopAnalyzerStatuses())
+
+	watcher, poster = setupMockedServer(mockedServerParams{
+		AnalyzerClient: &AnalyzerClientMock{
+			CommentsBuilder: makeComments,
+			ReviewSleep:     200 * time.Millisecond,
+		},
+		ReviewTimeout: 100 * time.Millisecond,
+	})
+
+	require.Nil(watcher.Send(correctReviewEvent()))
+	statuses := poster.PopAnalyzerStatuses()
+	require.Len(statuses, 2)
+	require.Equal(lookout.AnalyzerStatus{
+		Analyzer:    "mock",
+		Status:      lookout.ErrorAnalysisStatus,
+		Description: "The analysis timed out",
+	}, statuses[1])
+}
+
 func (s *ServerTestSuite) TestPushTimeout() {
 	require := s.Require()
 
@@ -289,6 +327,59 @@ func (s *ServerTestSuite) TestIncrementalReview() {
 	require.Len(comments, 0)
 }
 
+func (s *ServerTestSuite) TestReviewRerun() {
+	require := s.Require()
+
+	client := &AnalyzerClientMock{CommentsBuilder: makeComments}
+	watcher, poster := setupMockedServer(mockedServerParams{
+		AnalyzerClient: client,
+		ResponseCache:  store.NewMemResponseCacheOperator(),
+		CacheTTL:       time.Hour,
+		Persist:        true,
+	})
+
+	reviewEvent := correctReviewEvent()
+	require.Nil(watcher.Send(reviewEvent))
+	require.Len(client.PopReviewEvents(), 1)
+	require.Len(poster.PopComments(), 1)
+	require.Equal(lookout.SuccessAnalysisStatus, poster.PopStatus())
+
+	// the processed event is skipped
+	require.Nil(watcher.Send(reviewEvent))
+	require.Len(client.PopReviewEvents(), 0)
+
+	// the rerun calls the analyzer, and posts the comments again
+	rerun := correctReviewEvent()
+	rerun.Rerun = &lookout.Rerun{ID: "1"}
+	require.Nil(watcher.Send(rerun))
+	require.Len(client.PopReviewEvents(), 1)
+	require.Len(poster.PopComments(), 1)
+	require.Equal(lookout.SuccessAnalysisStatus, poster.PopStatus())
+
+	// a rerun of other analyzer does not call this one
+	rerun = correctReviewEvent()
+	rerun.Rerun = &lookout.Rerun{ID: "2", Analyzer: "other"}
+	require.Nil(watcher.Send(rerun))
+	require.Len(client.PopReviewEvents(), 0)
+	require.Len(poster.PopComments(), 0)
+
+	// a rerun of a single analyzer does not set the global status
+	poster.PopAnalyzerStatuses()
+	rerun = correctReviewEvent()
+	rerun.Rerun = &lookout.Rerun{ID: "3", Analyzer: "mock"}
+	require.Nil(watcher.Send(rerun))
+	require.Len(client.PopReviewEvents(), 1)
+	require.Len(poster.PopComments(), 1)
+	require.Equal(lookout.AnalysisStatus(0), poster.PopStatus())
+
+	statuses := poster.PopAnalyzerStatuses()
+	require.Equal(lookout.AnalyzerStatus{
+		Analyzer:    "mock",
+		Status:      lookout.SuccessAnalysisStatus,
+		Description: "The analysis produced 1 comment",
+	}, statuses[len(statuses)-1])
+}
+
 func (s *ServerTestSuite) TestAnalyzerConfigDisabled() {
 	require := s.Require()
 
@@ -308,6 +399,890 @@ func (s *ServerTestSuite) TestAnalyzerConfigDisabled() {
 	require.Equal(lookout.SuccessAnalysisStatus, status)
 }
 
+func (s *ServerTestSuite) TestReviewRetry() {
+	require := s.Require()
+
+	client := &AnalyzerClientMock{
+		CommentsBuilder: makeComments,
+		Err:             grpcstatus.Error(codes.Unavailable, "analyzer is down"),
+	}
+	backoff := 100 * time.Millisecond
+	watcher, poster := setupMockedServer(mockedServerParams{
+		AnalyzerClient: client,
+		Persist:        true,
+		RetryPolicy:    RetryPolicy{MaxAttempts: 2, InitialBackoff: backoff},
+	})
+
+	reviewEvent := correctReviewEvent()
+
+	err := watcher.Send(reviewEvent)
+	require.IsType(&lookout.RetryEventError{}, err)
+	require.Equal(backoff, err.(*lookout.RetryEventError).Delay)
+	require.Len(client.PopReviewEvents(), 1)
+	require.Len(poster.PopComments(), 0)
+
+	// the retry is not due yet
+	err = watcher.Send(reviewEvent)
+	require.IsType(&lookout.RetryEventError{}, err)
+	require.Len(client.PopReviewEvents(), 0)
+
+	time.Sleep(backoff)
+	client.Err = nil
+
+	err = watcher.Send(reviewEvent)
+	require.Nil(err)
+	require.Len(client.PopReviewEvents(), 1)
+	require.Len(poster.PopComments(), 1)
+	require.Equal(lookout.SuccessAnalysisStatus, poster.PopStatus())
+}
+
+func (s *ServerTestSuite) TestReviewRetryMaxAttempts() {
+	require := s.Require()
+
+	client := &AnalyzerClientMock{
+		CommentsBuilder: makeComments,
+		Err:             grpcstatus.Error(codes.DeadlineExceeded, "too slow"),
+	}
+	watcher, _ := setupMockedServer(mockedServerParams{
+		AnalyzerClient: client,
+		Persist:        true,
+		RetryPolicy:    RetryPolicy{MaxAttempts: 2},
+	})
+
+	reviewEvent := correctReviewEvent()
+
+	err := watcher.Send(reviewEvent)
+	require.IsType(&lookout.RetryEventError{}, err)
+
+	// last attempt, the event is marked as failed
+	err = watcher.Send(reviewEvent)
+	require.Nil(err)
+	require.Len(client.PopReviewEvents(), 2)
+
+	// failed events are skipped
+	err = watcher.Send(reviewEvent)
+	require.Nil(err)
+	require.Len(client.PopReviewEvents(), 0)
+}
+
+func (s *ServerTestSuite) TestReviewPermanentError() {
+	require := s.Require()
+
+	client := &AnalyzerClientMock{CommentsBuilder: makeComments}
+	watcher, poster := setupMockedServer(mockedServerParams{
+		AnalyzerClient: client,
+		Persist:        true,
+		RetryPolicy:    RetryPolicy{MaxAttempts: 5},
+	})
+	poster.Err = errors.New("validation failed")
+
+	reviewEvent := correctReviewEvent()
+
+	err := watcher.Send(reviewEvent)
+	require.Nil(err)
+	require.Len(client.PopReviewEvents(), 1)
+	require.Equal(lookout.ErrorAnalysisStatus, poster.PopStatus())
+
+	// permanent errors are not retried
+	err = watcher.Send(reviewEvent)
+	require.Nil(err)
+	require.Len(client.PopReviewEvents(), 0)
+}
+
+func (s *ServerTestSuite) TestAnalyzerCircuitBreaker() {
+	require := s.Require()
+
+	client := &AnalyzerClientMock{
+		CommentsBuilder: makeComments,
+		Err:             grpcstatus.Error(codes.Unavailable, "analyzer is down"),
+	}
+	probeInterval := 100 * time.Millisecond
+	watcher, poster := setupMockedServer(mockedServerParams{
+		AnalyzerClient: client,
+		Breaker: BreakerConfig{
+			FailureThreshold: 2,
+			ProbeInterval:    probeInterval,
+		},
+	})
+
+	for i := 0; i < 2; i++ {
+		require.Nil(watcher.Send(correctReviewEvent()))
+	}
+	require.Len(client.PopReviewEvents(), 2)
+
+	// the breaker is open, the analyzer is skipped
+	require.Nil(watcher.Send(correctReviewEvent()))
+	require.Len(client.PopReviewEvents(), 0)
+	require.Len(poster.PopComments(), 0)
+
+	time.Sleep(probeInterval)
+	client.Err = nil
+
+	require.Nil(watcher.Send(correctReviewEvent()))
+	require.Len(client.PopReviewEvents(), 1)
+	require.Len(poster.PopComments(), 1)
+}
+
+func (s *ServerTestSuite) TestAnalyzerRuns() {
+	require := s.Require()
+
+	client := &AnalyzerClientMock{
+		CommentsBuilder: makeComments,
+		Version:         "v1.2.3",
+	}
+	runOp := store.NewMemAnalyzerRunOperator()
+	watcher, _ := setupMockedServer(mockedServerParams{
+		AnalyzerClient: client,
+		AnalyzerRunOp:  runOp,
+	})
+
+	reviewEvent := correctReviewEvent()
+	require.Nil(watcher.Send(reviewEvent))
+
+	runs, err := runOp.Runs(context.TODO(), reviewEvent)
+	require.NoError(err)
+	require.Len(runs, 1)
+	require.Equal("mock", runs[0].Analyzer)
+	require.Equal("v1.2.3", runs[0].Version)
+	require.Equal(reviewEvent.ID().String(), runs[0].EventID)
+	require.EqualValues(codes.OK, runs[0].StatusCode)
+	require.Equal(1, runs[0].Comments)
+	require.False(runs[0].FinishedAt.Before(runs[0].StartedAt))
+
+	client.Err = grpcstatus.Error(codes.Unavailable, "analyzer is down")
+	pushEvent := correctPushEvent()
+	require.Nil(watcher.Send(pushEvent))
+
+	runs, err = runOp.Runs(context.TODO(), pushEvent)
+	require.NoError(err)
+	require.Len(runs, 1)
+	require.EqualValues(codes.Unavailable, runs[0].StatusCode)
+	require.Equal(0, runs[0].Comments)
+}
+
+func (s *ServerTestSuite) TestReviewSuperseded() {
+	require := s.Require()
+
+	client := &AnalyzerClientMock{
+		CommentsBuilder: makeComments,
+		ReviewSleep:     200 * time.Millisecond,
+	}
+	watcher, poster := setupMockedServer(mockedServerParams{
+		AnalyzerClient: client,
+		Persist:        true,
+	})
+
+	olderEvent := correctReviewEvent()
+	newerEvent := correctReviewEvent()
+	newerEvent.Head.Hash = "new-sha"
+
+	olderErr := make(chan error)
+	go func() {
+		olderErr <- watcher.Send(olderEvent)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	require.Nil(watcher.Send(newerEvent))
+	require.Nil(<-olderErr)
+
+	// only the comments of the newer event are posted
+	require.Len(client.PopReviewEvents(), 1)
+	comments := poster.PopComments()
+	require.Len(comments, 1)
+	require.Equal(makeComment(newerEvent.Base, newerEvent.Head), comments[0])
+
+	// the superseded event is not analyzed again
+	require.Nil(watcher.Send(olderEvent))
+	require.Len(client.PopReviewEvents(), 0)
+}
+
+func (s *ServerTestSuite) TestReviewSupersededStored() {
+	require := s.Require()
+
+	// two workers sharing the same database
+	eventOp := store.NewMemEventOperator()
+	client := &AnalyzerClientMock{CommentsBuilder: makeComments}
+	watcher1, poster1 := setupMockedServer(mockedServerParams{
+		AnalyzerClient: client,
+		EventOp:        eventOp,
+	})
+	watcher2, poster2 := setupMockedServer(mockedServerParams{
+		AnalyzerClient: client,
+		EventOp:        eventOp,
+	})
+
+	olderEvent := correctReviewEvent()
+	olderEvent.UpdatedAt = time.Now()
+	newerEvent := correctReviewEvent()
+	newerEvent.Head.Hash = "new-sha"
+	newerEvent.UpdatedAt = olderEvent.UpdatedAt.Add(time.Minute)
+
+	require.Nil(watcher1.Send(newerEvent))
+	require.Len(poster1.PopComments(), 1)
+
+	// the older event is analyzed by the other worker, but not posted
+	require.Nil(watcher2.Send(olderEvent))
+	require.Len(client.PopReviewEvents(), 2)
+	require.Len(poster2.PopComments(), 0)
+
+	// the superseded event is not analyzed again
+	require.Nil(watcher1.Send(olderEvent))
+	require.Len(client.PopReviewEvents(), 0)
+}
+
+func (s *ServerTestSuite) TestReviewInProgress() {
+	require := s.Require()
+
+	eventOp := store.NewMemEventOperator()
+	client := &AnalyzerClientMock{CommentsBuilder: makeComments}
+	watcher, poster := setupMockedServer(mockedServerParams{
+		AnalyzerClient: client,
+		EventOp:        eventOp,
+	})
+
+	// another worker started processing the event
+	reviewEvent := correctReviewEvent()
+	_, err := eventOp.Save(context.Background(), reviewEvent)
+	require.NoError(err)
+	require.NoError(eventOp.UpdateAttempts(
+		context.Background(), reviewEvent, 1, time.Now().Add(time.Minute)))
+
+	err = watcher.Send(reviewEvent)
+	require.IsType(&lookout.RetryEventError{}, err)
+	require.Len(client.PopReviewEvents(), 0)
+	require.Len(poster.PopComments(), 0)
+}
+
+func (s *ServerTestSuite) TestReviewPolicy() {
+	require := s.Require()
+
+	errorComments := func(ev lookout.Event, from, to lookout.ReferencePointer) []*lookout.Comment {
+		c := makeComment(from, to)
+		c.Severity = lookout.ErrorSeverity
+		return []*lookout.Comment{c}
+	}
+
+	// the default policy fails the analysis on error comments
+	watcher, poster := setupMockedServer(mockedServerParams{
+		AnalyzerClient: &AnalyzerClientMock{CommentsBuilder: errorComments},
+	})
+
+	require.Nil(watcher.Send(correctReviewEvent()))
+	require.Len(poster.PopComments(), 1)
+	require.Equal(lookout.FailureAnalysisStatus, poster.PopStatus())
+	action, posted := poster.PopAction()
+	require.True(posted)
+	require.Equal(lookout.CommentReviewAction, action)
+
+	// the repository policy requests changes on warnings or errors
+	watcher, poster = setupMockedServer(mockedServerParams{
+		AnalyzerClient: &AnalyzerClientMock{CommentsBuilder: errorComments},
+		FileGetter: &FileGetterMockWithConfig{
+			content: `policy:
+  status:
+    error: success
+  review:
+    warning: request_changes
+`,
+		},
+	})
+
+	require.Nil(watcher.Send(correctReviewEvent()))
+	require.Len(poster.PopComments(), 1)
+	require.Equal(lookout.SuccessAnalysisStatus, poster.PopStatus())
+	action, _ = poster.PopAction()
+	require.Equal(lookout.RequestChangesReviewAction, action)
+
+	// an approval is posted when there are no comments
+	watcher, poster = setupMockedServer(mockedServerParams{
+		AnalyzerConfig: &lookout.AnalyzerConfig{Disabled: true},
+		FileGetter: &FileGetterMockWithConfig{
+			content: `policy:
+  review:
+    none: approve
+`,
+		},
+	})
+
+	require.Nil(watcher.Send(correctReviewEvent()))
+	require.Len(poster.PopComments(), 0)
+	require.Equal(lookout.SuccessAnalysisStatus, poster.PopStatus())
+	action, posted = poster.PopAction()
+	require.True(posted)
+	require.Equal(lookout.ApproveReviewAction, action)
+}
+
+func (s *ServerTestSuite) TestResponseCache() {
+	require := s.Require()
+
+	client := &AnalyzerClientMock{
+		CommentsBuilder: makeComments,
+		Version:         "v1",
+	}
+	watcher, poster := setupMockedServer(mockedServerParams{
+		AnalyzerClient: client,
+		ResponseCache:  store.NewMemResponseCacheOperator(),
+		CacheTTL:       time.Hour,
+	})
+
+	reviewEvent := correctReviewEvent()
+
+	require.Nil(watcher.Send(reviewEvent))
+	require.Len(client.PopReviewEvents(), 1)
+	require.Len(poster.PopComments(), 1)
+
+	// same input, the cached response is used
+	require.Nil(watcher.Send(reviewEvent))
+	require.Len(client.PopReviewEvents(), 0)
+	comments := poster.PopComments()
+	require.Len(comments, 1)
+	require.Equal(makeComment(reviewEvent.Base, reviewEvent.Head), comments[0])
+
+	// new head, the analyzer is called
+	reviewEvent.Head.Hash = "new-head-hash"
+	require.Nil(watcher.Send(reviewEvent))
+	require.Len(client.PopReviewEvents(), 1)
+}
+
+func (s *ServerTestSuite) TestResponseCacheDisabled() {
+	require := s.Require()
+
+	noCache := false
+	client := &AnalyzerClientMock{CommentsBuilder: makeComments}
+	watcher, _ := setupMockedServer(mockedServerParams{
+		AnalyzerClient: client,
+		AnalyzerConfig: &lookout.AnalyzerConfig{Cache: &noCache},
+		ResponseCache:  store.NewMemResponseCacheOperator(),
+		CacheTTL:       time.Hour,
+	})
+
+	reviewEvent := correctReviewEvent()
+
+	require.Nil(watcher.Send(reviewEvent))
+	require.Nil(watcher.Send(reviewEvent))
+	require.Len(client.PopReviewEvents(), 2)
+}
+
+func (s *ServerTestSuite) TestMinConfidence() {
+	require := s.Require()
+
+	client := &AnalyzerClientMock{
+		CommentsBuilder: func(ev lookout.Event, from, to lookout.ReferencePointer) []*lookout.Comment {
+			return []*lookout.Comment{
+				{Text: "low", Confidence: 10},
+				{Text: "high", Confidence: 90},
+			}
+		},
+	}
+	commentOp := store.NewMemCommentOperator()
+	watcher, poster := setupMockedServer(mockedServerParams{
+		AnalyzerClient: client,
+		AnalyzerConfig: &lookout.AnalyzerConfig{MinConfidence: 95},
+		CommentOp:      commentOp,
+		FileGetter: &FileGetterMockWithConfig{
+			content: `analyzers:
+ - name: mock
+   min_confidence: 50
+`,
+		},
+	})
+
+	reviewEvent := correctReviewEvent()
+	require.Nil(watcher.Send(reviewEvent))
+
+	// the repository threshold overrides the global one
+	comments := poster.PopComments()
+	require.Len(comments, 1)
+	require.Equal("high", comments[0].Text)
+
+	filtered, err := commentOp.Filtered(context.TODO(), reviewEvent)
+	require.NoError(err)
+	require.Len(filtered, 1)
+	require.Equal("low", filtered[0].Text)
+	require.Equal("mock", filtered[0].Analyzer)
+	require.Equal(uint32(50), filtered[0].MinConfidence)
+}
+
+type fixerMock struct {
+	mode     string
+	analyzer string
+	fixed    []*lookout.Comment
+	err      error
+}
+
+func (f *fixerMock) Fix(
+	ctx context.Context,
+	e *lookout.ReviewEvent,
+	mode string,
+	analyzer string,
+	comments []*lookout.Comment,
+) (*lookout.FixResult, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	f.mode = mode
+	f.analyzer = analyzer
+	f.fixed = comments
+
+	// the first fix is applied, the rest conflict with it
+	res := &lookout.FixResult{URL: "https://github.com/foo/bar/pull/43"}
+	for _, c := range comments {
+		if c.Fix == nil {
+			continue
+		}
+
+		if len(res.Applied) == 0 {
+			res.Applied = append(res.Applied, c)
+		} else {
+			res.Conflicts = append(res.Conflicts, c)
+		}
+	}
+
+	return res, nil
+}
+
+func autofixClient() *AnalyzerClientMock {
+	return &AnalyzerClientMock{
+		CommentsBuilder: func(ev lookout.Event, from, to lookout.ReferencePointer) []*lookout.Comment {
+			return []*lookout.Comment{
+				{File: "main.go", Line: 1, Text: "fixed", Fix: &lookout.Fix{StartLine: 1, Text: "a"}},
+				{File: "main.go", Line: 1, Text: "conflict", Fix: &lookout.Fix{StartLine: 1, Text: "b"}},
+				{File: "main.go", Line: 2, Text: "no fix"},
+			}
+		},
+	}
+}
+
+func (s *ServerTestSuite) TestAutofix() {
+	require := s.Require()
+
+	fixer := &fixerMock{}
+	watcher, poster := setupMockedServer(mockedServerParams{
+		AnalyzerClient: autofixClient(),
+		AnalyzerConfig: &lookout.AnalyzerConfig{Name: "mock", Autofix: lookout.PullRequestAutofix},
+		Fixer:          fixer,
+	})
+
+	require.Nil(watcher.Send(correctReviewEvent()))
+
+	require.Equal(lookout.PullRequestAutofix, fixer.mode)
+	require.Equal("mock", fixer.analyzer)
+	require.Len(fixer.fixed, 3)
+
+	comments := poster.PopComments()
+	require.Len(comments, 3)
+	require.Equal("The fixes of 1 comments were applied in https://github.com/foo/bar/pull/43\n\n"+
+		"The fixes of 1 comments could not be applied, they conflict with other fixes or don't match the files",
+		comments[0].Text)
+	require.Equal("conflict", comments[1].Text)
+	require.Equal("no fix", comments[2].Text)
+}
+
+func (s *ServerTestSuite) TestAutofixDisabled() {
+	require := s.Require()
+
+	fixer := &fixerMock{}
+	watcher, poster := setupMockedServer(mockedServerParams{
+		AnalyzerClient: autofixClient(),
+		AnalyzerConfig: &lookout.AnalyzerConfig{Name: "mock"},
+		Fixer:          fixer,
+	})
+
+	require.Nil(watcher.Send(correctReviewEvent()))
+	require.Nil(fixer.fixed)
+	require.Len(poster.PopComments(), 3)
+
+	// the fixes are not applied for pushes
+	watcher, poster = setupMockedServer(mockedServerParams{
+		AnalyzerClient: autofixClient(),
+		AnalyzerConfig: &lookout.AnalyzerConfig{Name: "mock", Autofix: lookout.PushAutofix},
+		Fixer:          fixer,
+	})
+
+	require.Nil(watcher.Send(correctPushEvent()))
+	require.Nil(fixer.fixed)
+	require.Len(poster.PopComments(), 3)
+}
+
+func (s *ServerTestSuite) TestAutofixPush() {
+	require := s.Require()
+
+	// the fixes are not pushed to the head branch without the opt-in of the
+	// repository
+	fixer := &fixerMock{}
+	watcher, poster := setupMockedServer(mockedServerParams{
+		AnalyzerClient: autofixClient(),
+		AnalyzerConfig: &lookout.AnalyzerConfig{Name: "mock", Autofix: lookout.PushAutofix, AutofixPush: true},
+		Fixer:          fixer,
+	})
+
+	require.Nil(watcher.Send(correctReviewEvent()))
+	require.Nil(fixer.fixed)
+	require.Len(poster.PopComments(), 3)
+
+	watcher, poster = setupMockedServer(mockedServerParams{
+		AnalyzerClient: autofixClient(),
+		AnalyzerConfig: &lookout.AnalyzerConfig{Name: "mock", Autofix: lookout.PushAutofix},
+		Fixer:          fixer,
+		FileGetter: &FileGetterMockWithConfig{
+			content: `analyzers:
+  - name: mock
+    autofix_push: true
+`,
+		},
+	})
+
+	require.Nil(watcher.Send(correctReviewEvent()))
+	require.Equal(lookout.PushAutofix, fixer.mode)
+	require.Len(fixer.fixed, 3)
+	require.Len(poster.PopComments(), 3)
+}
+
+func (s *ServerTestSuite) TestAutofixPosted() {
+	require := s.Require()
+
+	fixer := &fixerMock{}
+	watcher, poster := setupMockedServer(mockedServerParams{
+		AnalyzerClient: autofixClient(),
+		AnalyzerConfig: &lookout.AnalyzerConfig{Name: "mock", Autofix: lookout.PullRequestAutofix},
+		Fixer:          fixer,
+		Persist:        true,
+	})
+
+	require.Nil(watcher.Send(correctReviewEvent()))
+	require.Len(fixer.fixed, 3)
+	require.Len(poster.PopComments(), 3)
+
+	// a new commit with the same comments does not apply the fixes again
+	fixer.fixed = nil
+	newerEvent := correctReviewEvent()
+	newerEvent.Head.Hash = "new-sha"
+	newerEvent.UpdatedAt = newerEvent.UpdatedAt.Add(time.Minute)
+
+	require.Nil(watcher.Send(newerEvent))
+	require.Nil(fixer.fixed)
+	require.Len(poster.PopComments(), 0)
+}
+
+func (s *ServerTestSuite) TestAutofixError() {
+	require := s.Require()
+
+	watcher, poster := setupMockedServer(mockedServerParams{
+		AnalyzerClient: autofixClient(),
+		AnalyzerConfig: &lookout.AnalyzerConfig{Name: "mock", Autofix: lookout.PullRequestAutofix},
+		Fixer:          &fixerMock{err: fmt.Errorf("push rejected")},
+	})
+
+	// the comments are posted as they are
+	require.Nil(watcher.Send(correctReviewEvent()))
+	require.Len(poster.PopComments(), 3)
+}
+
+func (s *ServerTestSuite) TestPushComments() {
+	require := s.Require()
+
+	// the posted comments are not tracked for push events
+	watcher, poster := setupMockedServer(mockedServerParams{
+		CommentOp: store.NewMemCommentOperator(),
+	})
+
+	require.Nil(watcher.Send(correctPushEvent()))
+	require.Len(poster.PopComments(), 1)
+
+	require.Nil(watcher.Send(correctPushEvent()))
+	require.Len(poster.PopComments(), 1)
+}
+
+func (s *ServerTestSuite) TestPushNoComments() {
+	require := s.Require()
+
+	client := &AnalyzerClientMock{
+		CommentsBuilder: func(lookout.Event, lookout.ReferencePointer, lookout.ReferencePointer) []*lookout.Comment {
+			return nil
+		},
+	}
+	watcher, poster := setupMockedServer(mockedServerParams{
+		AnalyzerClient: client,
+	})
+
+	// the results of a push are posted even without comments, to clear the
+	// findings of the previous one
+	require.Nil(watcher.Send(correctPushEvent()))
+	_, posted := poster.PopAction()
+	require.True(posted)
+	require.Len(poster.PopComments(), 0)
+
+	// a review without comments is not posted
+	require.Nil(watcher.Send(correctReviewEvent()))
+	_, posted = poster.PopAction()
+	require.False(posted)
+}
+
+func (s *ServerTestSuite) TestPathScope() {
+	require := s.Require()
+
+	reviewEvent := correctReviewEvent()
+	changes := func(paths ...string) lookout.ChangeGetter {
+		var cs []*lookout.Change
+		for _, p := range paths {
+			cs = append(cs, &lookout.Change{Head: &lookout.File{Path: p}})
+		}
+
+		return &mock.MockChangesService{
+			T: s.T(),
+			ExpectedRequest: &lookout.ChangesRequest{
+				Base: &reviewEvent.CommitRevision.Base,
+				Head: &reviewEvent.CommitRevision.Head,
+			},
+			ChangeScanner: &mock.SliceChangeScanner{Changes: cs},
+		}
+	}
+
+	fileGetter := &FileGetterMockWithConfig{
+		content: `analyzers:
+ - name: mock
+   include: ["services/py/**"]
+   exclude: ["**/vendor/**"]
+`,
+	}
+
+	client := &AnalyzerClientMock{
+		CommentsBuilder: func(ev lookout.Event, from, to lookout.ReferencePointer) []*lookout.Comment {
+			return []*lookout.Comment{
+				{Text: "global"},
+				{File: "services/py/main.py", Text: "in scope"},
+				{File: "services/go/main.go", Text: "outside include"},
+				{File: "services/py/vendor/lib.py", Text: "excluded"},
+			}
+		},
+	}
+
+	// the diff doesn't touch the analyzer paths
+	watcher, poster := setupMockedServer(mockedServerParams{
+		AnalyzerClient: client,
+		FileGetter:     fileGetter,
+		ChangeGetter:   changes("services/go/main.go", "services/py/vendor/lib.py"),
+	})
+
+	require.Nil(watcher.Send(reviewEvent))
+	require.Len(client.PopReviewEvents(), 0)
+	require.Len(poster.PopComments(), 0)
+	require.Equal([]lookout.AnalyzerStatus{{
+		Analyzer:    "mock",
+		Status:      lookout.SuccessAnalysisStatus,
+		Description: "No changes in the analyzer paths",
+	}}, poster.PopAnalyzerStatuses())
+
+	// the comments outside the analyzer paths are dropped
+	watcher, poster = setupMockedServer(mockedServerParams{
+		AnalyzerClient: client,
+		FileGetter:     fileGetter,
+		ChangeGetter:   changes("services/go/main.go", "services/py/main.py"),
+	})
+
+	require.Nil(watcher.Send(reviewEvent))
+	require.Len(client.PopReviewEvents(), 1)
+
+	var texts []string
+	for _, c := range poster.PopComments() {
+		texts = append(texts, c.Text)
+	}
+
+	require.ElementsMatch([]string{"global", "in scope"}, texts)
+}
+
+func (s *ServerTestSuite) TestSuppressedComments() {
+	require := s.Require()
+
+	client := &AnalyzerClientMock{
+		CommentsBuilder: func(ev lookout.Event, from, to lookout.ReferencePointer) []*lookout.Comment {
+			return []*lookout.Comment{
+				{Text: "global"},
+				{File: "main.go", Line: 2, Text: "suppressed"},
+				{File: "main.go", Line: 4, Text: "other analyzer"},
+				{File: "main.go", Line: 5, Text: "posted"},
+				{File: "generated.go", Line: 1, Text: "ignored file"},
+			}
+		},
+	}
+	watcher, poster := setupMockedServer(mockedServerParams{
+		AnalyzerClient: client,
+		FileGetter: &FileGetterMockWithFiles{files: map[string]string{
+			"main.go": `// lookout:ignore
+var a = 1
+var b = 2
+var c = 3 // lookout:ignore other
+var d = 4
+`,
+			"generated.go": "// Code generated. lookout:ignore-file\n",
+		}},
+	})
+
+	require.Nil(watcher.Send(correctReviewEvent()))
+
+	var texts []string
+	for _, c := range poster.PopComments() {
+		texts = append(texts, c.Text)
+	}
+
+	require.ElementsMatch([]string{"global", "other analyzer", "posted"}, texts)
+	statuses := poster.PopAnalyzerStatuses()
+	require.Equal(lookout.AnalyzerStatus{
+		Analyzer:    "mock",
+		Status:      lookout.SuccessAnalysisStatus,
+		Description: "The analysis produced 3 comments, 2 suppressed",
+	}, statuses[len(statuses)-1])
+}
+
+func (s *ServerTestSuite) TestConfigProblems() {
+	require := s.Require()
+
+	client := &AnalyzerClientMock{CommentsBuilder: makeComments}
+	watcher, poster := setupMockedServer(mockedServerParams{
+		AnalyzerClient: client,
+		FileGetter: &FileGetterMockWithConfig{
+			content: `analyzers:
+ - name: mock
+   settings:
+     key: value
+ - name: missing
+unknown: true
+`,
+		},
+	})
+
+	require.Nil(watcher.Send(correctReviewEvent()))
+
+	// the analysis runs with the valid parts of the configuration
+	require.Len(client.PopReviewEvents(), 1)
+
+	comments := poster.PopComments()
+	require.Len(comments, 2)
+	require.Equal("", comments[1].File)
+	require.Contains(comments[1].Text, "**.lookout.yml**")
+	require.Contains(comments[1].Text, "- line 6: unknown key `unknown`")
+	require.Contains(comments[1].Text,
+		"- unknown analyzer `missing`, it is not enabled in the server")
+	require.Equal(lookout.ErrorAnalysisStatus, poster.PopStatus())
+}
+
+func (s *ServerTestSuite) TestConfigSyntaxError() {
+	require := s.Require()
+
+	client := &AnalyzerClientMock{CommentsBuilder: makeComments}
+	watcher, poster := setupMockedServer(mockedServerParams{
+		AnalyzerClient: client,
+		FileGetter: &FileGetterMockWithConfig{
+			content: "analyzers: [\n",
+		},
+		Persist: true,
+	})
+
+	// the event is not retried, the configuration must be fixed
+	reviewEvent := correctReviewEvent()
+	require.Nil(watcher.Send(reviewEvent))
+	require.Len(client.PopReviewEvents(), 0)
+
+	comments := poster.PopComments()
+	require.Len(comments, 1)
+	require.Contains(comments[0].Text, "The analysis could not run")
+	require.Contains(comments[0].Text, "**.lookout.yml**")
+	require.Equal(lookout.ErrorAnalysisStatus, poster.PopStatus())
+
+	// the same comment is not posted again
+	reviewEvent.CommitRevision.Head.Hash = "4eebef102d7979570aadf69ff54ae1ffcca7ce00"
+	require.Nil(watcher.Send(reviewEvent))
+	require.Len(poster.PopComments(), 0)
+	require.Equal(lookout.ErrorAnalysisStatus, poster.PopStatus())
+}
+
+func (s *ServerTestSuite) TestRepoConfigDisabled() {
+	require := s.Require()
+
+	client := &AnalyzerClientMock{CommentsBuilder: makeComments}
+	watcher, _ := setupMockedServer(mockedServerParams{
+		AnalyzerClient: client,
+		FileGetter: &FileGetterMockWithConfig{
+			content: `analyzers:
+ - name: mock
+   disabled: true
+`,
+		},
+		OrganizationOp: &OrganizationOperatorMock{},
+	})
+
+	require.Nil(watcher.Send(correctReviewEvent()))
+	require.Len(client.PopReviewEvents(), 0)
+}
+
+func (s *ServerTestSuite) TestBranchConfig() {
+	require := s.Require()
+
+	client := &AnalyzerClientMock{CommentsBuilder: makeComments}
+	watcher, poster := setupMockedServer(mockedServerParams{
+		AnalyzerClient: client,
+		FileGetter: &FileGetterMockWithConfig{
+			content: `analyzers:
+ - name: mock
+   settings:
+     level: repo
+branches:
+  release/*:
+    analyzers:
+     - name: mock
+       settings:
+         level: release
+    policy:
+      review:
+        info: request_changes
+  release/legacy:
+    analyzers:
+     - name: mock
+       disabled: true
+`,
+		},
+		OrganizationOp: &OrganizationOperatorMockWithConfig{
+			content: `branches:
+  release/*:
+    analyzers:
+     - name: mock
+       settings:
+         org_level: release
+`,
+		},
+	})
+
+	// the branch overrides don't apply
+	require.Nil(watcher.Send(correctReviewEvent()))
+	events := client.PopReviewEvents()
+	require.Len(events, 1)
+	require.Equal(
+		pb.ToStruct(map[string]interface{}{"level": "repo"}).GetFields(),
+		events[0].Configuration.GetFields())
+	action, _ := poster.PopAction()
+	require.Equal(lookout.CommentReviewAction, action)
+
+	// the branch overrides of the organization and the repository apply
+	releaseEvent := correctReviewEvent()
+	releaseEvent.CommitRevision.Base.ReferenceName = "refs/heads/release/1.0"
+	require.Nil(watcher.Send(releaseEvent))
+	events = client.PopReviewEvents()
+	require.Len(events, 1)
+	require.Equal(pb.ToStruct(map[string]interface{}{
+		"level":     "release",
+		"org_level": "release",
+	}).GetFields(), events[0].Configuration.GetFields())
+	action, _ = poster.PopAction()
+	require.Equal(lookout.RequestChangesReviewAction, action)
+
+	// the analyzer is disabled in a branch
+	legacyEvent := correctReviewEvent()
+	legacyEvent.CommitRevision.Base.ReferenceName = "refs/heads/release/legacy"
+	require.Nil(watcher.Send(legacyEvent))
+	require.Len(client.PopReviewEvents(), 0)
+}
+
 func (s *ServerTestSuite) TestMergeConfig() {
 	fileGetter := &FileGetterMockWithConfig{
 		content: `analyzers:
@@ -492,15 +1467,50 @@ func TestConfigMerger(t *testing.T) {
 	require.Equal(expectedMap, merged)
 }
 
+func TestMergeConfigsDisabled(t *testing.T) {
+	require := require.New(t)
+
+	org := map[string]lookout.AnalyzerConfig{
+		"mock": {Name: "mock", Disabled: true},
+	}
+
+	// a layer without the key keeps the analyzer disabled
+	merged := mergeConfigs(org, map[string]lookout.AnalyzerConfig{
+		"mock": {Name: "mock", Settings: map[string]interface{}{"key": "value"}},
+	})
+	require.True(merged["mock"].Disabled)
+
+	// a layer with disabled: false can't enable it again
+	merged = mergeConfigs(org, map[string]lookout.AnalyzerConfig{
+		"mock": {Name: "mock", Disabled: false},
+	})
+	require.True(merged["mock"].Disabled)
+
+	// a layer can disable an enabled analyzer
+	merged = mergeConfigs(map[string]lookout.AnalyzerConfig{
+		"mock": {Name: "mock"},
+	}, map[string]lookout.AnalyzerConfig{
+		"mock": {Name: "mock", Disabled: true},
+	})
+	require.True(merged["mock"].Disabled)
+}
+
 type mockedServerParams struct {
 	AnalyzerClient lookout.AnalyzerClient
 	AnalyzerConfig *lookout.AnalyzerConfig
 	FileGetter     lookout.FileGetter
+	ChangeGetter   lookout.ChangeGetter
 	EventOp        store.EventOperator
 	CommentOp      store.CommentOperator
 	OrganizationOp store.OrganizationOperator
+	AnalyzerRunOp  store.AnalyzerRunOperator
 	ReviewTimeout  time.Duration
 	PushTimeout    time.Duration
+	RetryPolicy    RetryPolicy
+	Breaker        BreakerConfig
+	ResponseCache  store.ResponseCacheOperator
+	CacheTTL       time.Duration
+	Fixer          lookout.Fixer
 	Persist        bool
 }
 
@@ -553,14 +1563,21 @@ func setupMockedServer(params mockedServerParams) (*WatcherMock, *PosterMock) {
 	}
 
 	srv := NewServer(Options{
-		Poster:         poster,
-		FileGetter:     fileGetter,
-		Analyzers:      analyzers,
-		EventOp:        eventOp,
-		CommentOp:      commentOp,
-		OrganizationOp: organizationOp,
-		ReviewTimeout:  params.ReviewTimeout,
-		PushTimeout:    params.PushTimeout,
+		Poster:           poster,
+		FileGetter:       fileGetter,
+		ChangeGetter:     params.ChangeGetter,
+		Analyzers:        analyzers,
+		EventOp:          eventOp,
+		CommentOp:        commentOp,
+		OrganizationOp:   organizationOp,
+		AnalyzerRunOp:    params.AnalyzerRunOp,
+		ReviewTimeout:    params.ReviewTimeout,
+		PushTimeout:      params.PushTimeout,
+		RetryPolicy:      params.RetryPolicy,
+		Breaker:          params.Breaker,
+		ResponseCacheOp:  params.ResponseCache,
+		ResponseCacheTTL: params.CacheTTL,
+		Fixer:            params.Fixer,
 	})
 
 	watcher.Watch(context.TODO(), srv.HandleEvent)
@@ -584,11 +1601,24 @@ func (w *WatcherMock) Send(e lookout.Event) error {
 var _ lookout.Poster = &PosterMock{}
 
 type PosterMock struct {
-	comments []*lookout.Comment
-	status   lookout.AnalysisStatus
+	comments         []*lookout.Comment
+	groups           []lookout.AnalyzerComments
+	status           lookout.AnalysisStatus
+	analyzerStatuses []lookout.AnalyzerStatus
+	action           lookout.ReviewAction
+	posted           bool
+	Err              error
 }
 
-func (p *PosterMock) Post(_ context.Context, e lookout.Event, aCommentsList []lookout.AnalyzerComments, safe bool) error {
+func (p *PosterMock) Post(_ context.Context, e lookout.Event, aCommentsList []lookout.AnalyzerComments, safe bool, action lookout.ReviewAction) error {
+	if p.Err != nil {
+		return p.Err
+	}
+
+	p.action = action
+	p.posted = true
+	p.groups = aCommentsList
+
 	cs := make([]*lookout.Comment, 0)
 	for _, aComments := range aCommentsList {
 		cs = append(cs, aComments.Comments...)
@@ -603,6 +1633,22 @@ func (p *PosterMock) PopComments() []*lookout.Comment {
 	return cs
 }
 
+// PopAnalyzerComments returns the comments of the last post grouped by
+// analyzer
+func (p *PosterMock) PopAnalyzerComments() []lookout.AnalyzerComments {
+	groups := p.groups
+	p.groups = nil
+	return groups
+}
+
+// PopAction returns the review action of the last post, and whether there
+// was any post
+func (p *PosterMock) PopAction() (lookout.ReviewAction, bool) {
+	action, posted := p.action, p.posted
+	p.action, p.posted = 0, false
+	return action, posted
+}
+
 func (p *PosterMock) Status(_ context.Context, e lookout.Event, st lookout.AnalysisStatus) error {
 	p.status = st
 	return nil
@@ -614,6 +1660,17 @@ func (p *PosterMock) PopStatus() lookout.AnalysisStatus {
 	return st
 }
 
+func (p *PosterMock) AnalyzerStatus(_ context.Context, e lookout.Event, st lookout.AnalyzerStatus) error {
+	p.analyzerStatuses = append(p.analyzerStatuses, st)
+	return nil
+}
+
+func (p *PosterMock) PopAnalyzerStatuses() []lookout.AnalyzerStatus {
+	sts := p.analyzerStatuses
+	p.analyzerStatuses = nil
+	return sts
+}
+
 type FileGetterMock struct {
 }
 
@@ -635,6 +1692,40 @@ func (g *FileGetterMockWithConfig) GetFiles(_ context.Context, req *lookout.File
 	return &NoopFileScanner{}, nil
 }
 
+// FileGetterMockWithFiles returns the files whose path matches the request
+// IncludePattern
+type FileGetterMockWithFiles struct {
+	files map[string]string
+}
+
+func (g *FileGetterMockWithFiles) GetFiles(_ context.Context, req *lookout.FilesRequest) (lookout.FileScanner, error) {
+	re, err := regexp.Compile(req.IncludePattern)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*lookout.File
+	for path, content := range g.files {
+		if re.MatchString(path) {
+			files = append(files, &lookout.File{Path: path, Content: []byte(content)})
+		}
+	}
+
+	return &mock.SliceFileScanner{Files: files}, nil
+}
+
+type OrganizationOperatorMockWithConfig struct {
+	content string
+}
+
+func (o *OrganizationOperatorMockWithConfig) Save(ctx context.Context, provider string, orgID string, config string) error {
+	return nil
+}
+
+func (o *OrganizationOperatorMockWithConfig) Config(ctx context.Context, provider string, orgID string) (string, error) {
+	return o.content, nil
+}
+
 type OrganizationOperatorMock struct{}
 
 func (o *OrganizationOperatorMock) Save(ctx context.Context, provider string, orgID string, config string) error {
@@ -660,6 +1751,8 @@ type AnalyzerClientMock struct {
 	CommentsBuilder func(ev lookout.Event, from, to lookout.ReferencePointer) []*lookout.Comment
 	ReviewSleep     time.Duration
 	PushSleep       time.Duration
+	Version         string
+	Err             error
 }
 
 func (a *AnalyzerClientMock) NotifyReviewEvent(ctx context.Context, in *pb.ReviewEvent, opts ...grpc.CallOption) (*lookout.EventResponse, error) {
@@ -672,7 +1765,12 @@ func (a *AnalyzerClientMock) NotifyReviewEvent(ctx context.Context, in *pb.Revie
 	}
 
 	a.reviewEvents = append(a.reviewEvents, in)
+	if a.Err != nil {
+		return nil, a.Err
+	}
+
 	return &lookout.EventResponse{
+		AnalyzerVersion: a.Version,
 		Comments: a.CommentsBuilder(&lookout.ReviewEvent{ReviewEvent: *in},
 			in.CommitRevision.Base, in.CommitRevision.Head),
 	}, nil
@@ -688,7 +1786,12 @@ func (a *AnalyzerClientMock) NotifyPushEvent(ctx context.Context, in *pb.PushEve
 	}
 
 	a.pushEvents = append(a.pushEvents, in)
+	if a.Err != nil {
+		return nil, a.Err
+	}
+
 	return &lookout.EventResponse{
+		AnalyzerVersion: a.Version,
 		Comments: a.CommentsBuilder(&lookout.PushEvent{PushEvent: *in},
 			in.CommitRevision.Base, in.CommitRevision.Head),
 	}, nil
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/src-d/lookout"
+
+	"google.golang.org/grpc/codes"
+	"gopkg.in/src-d/lookout-sdk.v0/pb"
+)
+
+// eventResponseStream is the client side of a streaming analyzer request
+type eventResponseStream interface {
+	Recv() (*lookout.EventResponse, error)
+}
+
+// receive reads the responses of a streaming analyzer request, and returns
+// them merged in one. If the stream fails after some responses, the merged
+// response is returned along with the error, as partial results. If
+// maxComments is not zero, the reading stops as soon as the responses exceed
+// it, so the merged response has at most maxComments+1 comments.
+func receive(stream eventResponseStream, err error, maxComments int) (*lookout.EventResponse, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	var resp *lookout.EventResponse
+	for {
+		r, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return resp, err
+		}
+
+		if resp == nil {
+			resp = &lookout.EventResponse{}
+		}
+
+		if r.AnalyzerVersion != "" {
+			resp.AnalyzerVersion = r.AnalyzerVersion
+		}
+
+		resp.Comments = append(resp.Comments, r.Comments...)
+		if maxComments > 0 && len(resp.Comments) > maxComments {
+			resp.Comments = resp.Comments[:maxComments+1]
+			break
+		}
+	}
+
+	if resp == nil {
+		resp = &lookout.EventResponse{}
+	}
+
+	return resp, nil
+}
+
+// notifyReview sends the review event to the analyzer, using its streaming
+// variant if it is implemented
+func notifyReview(ctx context.Context, a lookout.Analyzer, e *pb.ReviewEvent) (*lookout.EventResponse, error) {
+	if a.Stream != nil {
+		// cancels the stream if the reading stops before its end
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		stream, err := a.Stream.NotifyReviewEvent(ctx, e)
+		resp, err := receive(stream, err, a.Config.MaxComments)
+		if resp != nil || grpcCode(err) != codes.Unimplemented {
+			return resp, err
+		}
+	}
+
+	return a.Client.NotifyReviewEvent(ctx, e)
+}
+
+// notifyPush sends the push event to the analyzer, using its streaming
+// variant if it is implemented
+func notifyPush(ctx context.Context, a lookout.Analyzer, e *pb.PushEvent) (*lookout.EventResponse, error) {
+	if a.Stream != nil {
+		// cancels the stream if the reading stops before its end
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		stream, err := a.Stream.NotifyPushEvent(ctx, e)
+		resp, err := receive(stream, err, a.Config.MaxComments)
+		if resp != nil || grpcCode(err) != codes.Unimplemented {
+			return resp, err
+		}
+	}
+
+	return a.Client.NotifyPushEvent(ctx, e)
+}
+
+// analyzerTimeout returns the timeout set in the analyzer configuration, or
+// the default one if it is not set
+func analyzerTimeout(timeout, def time.Duration) time.Duration {
+	if timeout > 0 {
+		return timeout
+	}
+
+	return def
+}
+
+// capTimeout returns the timeout, or max if it is longer. A max of zero means
+// no limit.
+func capTimeout(timeout, max time.Duration) time.Duration {
+	if max > 0 && timeout > max {
+		return max
+	}
+
+	return timeout
+}
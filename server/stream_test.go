@@ -0,0 +1,263 @@
+package server
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/src-d/lookout"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+	"gopkg.in/src-d/lookout-sdk.v0/pb"
+)
+
+// AnalyzerStreamMock streams Responses, then waits for the request to be
+// canceled if Block is true
+type AnalyzerStreamMock struct {
+	Responses []*lookout.EventResponse
+	Block     bool
+	Err       error
+	calls     int
+}
+
+func (a *AnalyzerStreamMock) NotifyReviewEvent(ctx context.Context, in *pb.ReviewEvent, opts ...grpc.CallOption) (pb.AnalyzerStream_NotifyReviewEventClient, error) {
+	a.calls++
+	return &responseStreamMock{ctx: ctx, mock: a}, nil
+}
+
+func (a *AnalyzerStreamMock) NotifyPushEvent(ctx context.Context, in *pb.PushEvent, opts ...grpc.CallOption) (pb.AnalyzerStream_NotifyPushEventClient, error) {
+	a.calls++
+	return &responseStreamMock{ctx: ctx, mock: a}, nil
+}
+
+type responseStreamMock struct {
+	grpc.ClientStream
+	ctx  context.Context
+	mock *AnalyzerStreamMock
+	sent int
+}
+
+func (s *responseStreamMock) Recv() (*lookout.EventResponse, error) {
+	if s.mock.Err != nil {
+		return nil, s.mock.Err
+	}
+
+	if s.sent < len(s.mock.Responses) {
+		s.sent++
+		return s.mock.Responses[s.sent-1], nil
+	}
+
+	if !s.mock.Block {
+		return nil, io.EOF
+	}
+
+	<-s.ctx.Done()
+	return nil, grpcstatus.FromContextError(s.ctx.Err()).Err()
+}
+
+func streamedResponses() []*lookout.EventResponse {
+	return []*lookout.EventResponse{
+		{AnalyzerVersion: "v1", Comments: []*lookout.Comment{{Text: "first"}}},
+		{Comments: []*lookout.Comment{{Text: "second"}, {Text: "third"}}},
+	}
+}
+
+func TestStreamedResponse(t *testing.T) {
+	require := require.New(t)
+
+	stream := &AnalyzerStreamMock{Responses: streamedResponses()}
+	client := &AnalyzerClientMock{CommentsBuilder: makeComments}
+	poster := &PosterMock{}
+	srv := NewServer(Options{
+		Poster:     poster,
+		FileGetter: &FileGetterMock{},
+		Analyzers: map[string]lookout.Analyzer{
+			"mock": {Client: client, Stream: stream},
+		},
+	})
+
+	require.NoError(srv.HandleEvent(context.Background(), correctReviewEvent()))
+	require.Equal(1, stream.calls)
+	require.Len(client.PopReviewEvents(), 0)
+
+	groups := poster.PopAnalyzerComments()
+	require.Len(groups, 1)
+	require.False(groups[0].Partial)
+	require.Len(groups[0].Comments, 3)
+	require.Equal(lookout.SuccessAnalysisStatus, poster.PopStatus())
+}
+
+func TestStreamPartialResults(t *testing.T) {
+	require := require.New(t)
+
+	stream := &AnalyzerStreamMock{Responses: streamedResponses(), Block: true}
+	poster := &PosterMock{}
+	srv := NewServer(Options{
+		Poster:     poster,
+		FileGetter: &FileGetterMock{},
+		Analyzers: map[string]lookout.Analyzer{
+			"mock": {
+				Client: &AnalyzerClientMock{CommentsBuilder: makeComments},
+				Stream: stream,
+				Config: lookout.AnalyzerConfig{TimeoutReview: 50 * time.Millisecond},
+			},
+		},
+	})
+
+	require.NoError(srv.HandleEvent(context.Background(), correctReviewEvent()))
+
+	groups := poster.PopAnalyzerComments()
+	require.Len(groups, 1)
+	require.True(groups[0].Partial)
+	require.Len(groups[0].Comments, 3)
+
+	sts := poster.PopAnalyzerStatuses()
+	require.Equal(lookout.AnalyzerStatus{
+		Analyzer:    "mock",
+		Status:      lookout.ErrorAnalysisStatus,
+		Description: "The analysis produced 3 comments before timing out",
+	}, sts[len(sts)-1])
+}
+
+func TestStreamUnimplemented(t *testing.T) {
+	require := require.New(t)
+
+	stream := &AnalyzerStreamMock{Err: grpcstatus.Error(codes.Unimplemented, "unknown service")}
+	client := &AnalyzerClientMock{CommentsBuilder: makeComments}
+	poster := &PosterMock{}
+	srv := NewServer(Options{
+		Poster:     poster,
+		FileGetter: &FileGetterMock{},
+		Analyzers: map[string]lookout.Analyzer{
+			"mock": {Client: client, Stream: stream},
+		},
+	})
+
+	require.NoError(srv.HandleEvent(context.Background(), correctPushEvent()))
+	require.Equal(1, stream.calls)
+	require.Len(client.PopPushEvents(), 1)
+	require.Len(poster.PopComments(), 1)
+}
+
+func TestAnalyzerTimeout(t *testing.T) {
+	require := require.New(t)
+
+	client := &AnalyzerClientMock{
+		CommentsBuilder: makeComments,
+		ReviewSleep:     100 * time.Millisecond,
+	}
+	poster := &PosterMock{}
+	srv := NewServer(Options{
+		Poster:     poster,
+		FileGetter: &FileGetterMock{},
+		Analyzers: map[string]lookout.Analyzer{
+			"mock": {
+				Client: client,
+				Config: lookout.AnalyzerConfig{TimeoutReview: 50 * time.Millisecond},
+			},
+		},
+		ReviewTimeout: time.Second,
+	})
+
+	require.NoError(srv.HandleEvent(context.Background(), correctReviewEvent()))
+	require.Len(poster.PopComments(), 0)
+
+	sts := poster.PopAnalyzerStatuses()
+	require.Equal("The analysis timed out", sts[len(sts)-1].Description)
+
+	// the repository configuration overrides the analyzer timeout
+	repoTimeout := func(timeout string) *Server {
+		return NewServer(Options{
+			Poster: poster,
+			FileGetter: &FileGetterMockWithConfig{
+				content: "analyzers:\n  - name: mock\n    timeout_review: " + timeout + "\n",
+			},
+			Analyzers: map[string]lookout.Analyzer{
+				"mock": {Client: client},
+			},
+			ReviewTimeout: time.Second,
+		})
+	}
+
+	require.NoError(repoTimeout("50ms").HandleEvent(context.Background(), correctReviewEvent()))
+	require.Len(poster.PopComments(), 0)
+
+	// but it can't be longer than the server one
+	require.NoError(repoTimeout("1000h").HandleEvent(context.Background(), correctReviewEvent()))
+	require.Len(poster.PopComments(), 1)
+
+	srv = NewServer(Options{
+		Poster: poster,
+		FileGetter: &FileGetterMockWithConfig{
+			content: "analyzers:\n  - name: mock\n    timeout_review: 1s\n",
+		},
+		Analyzers: map[string]lookout.Analyzer{
+			"mock": {
+				Client: client,
+				Config: lookout.AnalyzerConfig{TimeoutReview: 50 * time.Millisecond},
+			},
+		},
+	})
+
+	require.NoError(srv.HandleEvent(context.Background(), correctReviewEvent()))
+	require.Len(poster.PopComments(), 0)
+}
+
+func TestStreamMaxComments(t *testing.T) {
+	require := require.New(t)
+
+	// the stream blocks after the responses, it must not be read until the end
+	stream := &AnalyzerStreamMock{Responses: streamedResponses(), Block: true}
+	poster := &PosterMock{}
+	srv := NewServer(Options{
+		Poster:     poster,
+		FileGetter: &FileGetterMock{},
+		Analyzers: map[string]lookout.Analyzer{
+			"mock": {
+				Client: &AnalyzerClientMock{CommentsBuilder: makeComments},
+				Stream: stream,
+				Config: lookout.AnalyzerConfig{MaxComments: 1},
+			},
+		},
+		ReviewTimeout: time.Second,
+	})
+
+	require.NoError(srv.HandleEvent(context.Background(), correctReviewEvent()))
+
+	groups := poster.PopAnalyzerComments()
+	require.Len(groups, 1)
+	require.False(groups[0].Partial)
+	require.Equal([]*lookout.Comment{{Text: "first"}}, groups[0].Comments)
+
+	sts := poster.PopAnalyzerStatuses()
+	require.Equal(lookout.AnalyzerStatus{
+		Analyzer:    "mock",
+		Status:      lookout.SuccessAnalysisStatus,
+		Description: "The analysis produced 1 comment, the ones over the limit of 1 were dropped",
+	}, sts[len(sts)-1])
+}
+
+func TestUnaryMaxComments(t *testing.T) {
+	require := require.New(t)
+
+	poster := &PosterMock{}
+	srv := NewServer(Options{
+		Poster:     poster,
+		FileGetter: &FileGetterMock{},
+		Analyzers: map[string]lookout.Analyzer{
+			"mock": {
+				Client: &AnalyzerClientMock{CommentsBuilder: func(lookout.Event, lookout.ReferencePointer, lookout.ReferencePointer) []*lookout.Comment {
+					return streamedResponses()[1].Comments
+				}},
+				Config: lookout.AnalyzerConfig{MaxComments: 1},
+			},
+		},
+	})
+
+	require.NoError(srv.HandleEvent(context.Background(), correctPushEvent()))
+	require.Equal([]*lookout.Comment{{Text: "second"}}, poster.PopComments())
+}
@@ -0,0 +1,206 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/src-d/lookout"
+	"github.com/src-d/lookout/util/ctxlog"
+
+	log "gopkg.in/src-d/go-log.v1"
+)
+
+const (
+	// ignoreMarker suppresses the comments on its line or on the next one
+	ignoreMarker = "lookout:ignore"
+	// ignoreFileSuffix turns ignoreMarker into lookout:ignore-file, that
+	// suppresses all the comments on its file
+	ignoreFileSuffix = "-file"
+)
+
+// suppressions are the suppression markers found in a file
+type suppressions struct {
+	// file are the markers of the whole file
+	file []string
+	// lines are the markers of each line, indexed from 1
+	lines map[int][]string
+}
+
+// parseSuppressions returns the suppression markers of the file content.
+// Each marker is stored as the text following it in the line, that decides
+// the analyzers it applies to.
+func parseSuppressions(content []byte) *suppressions {
+	s := &suppressions{lines: make(map[int][]string)}
+	if !bytes.Contains(content, []byte(ignoreMarker)) {
+		return s
+	}
+
+	for i, line := range strings.Split(string(content), "\n") {
+		rest := line
+		for {
+			idx := strings.Index(rest, ignoreMarker)
+			if idx < 0 {
+				break
+			}
+
+			rest = rest[idx+len(ignoreMarker):]
+			if strings.HasPrefix(rest, ignoreFileSuffix) {
+				rest = rest[len(ignoreFileSuffix):]
+				s.file = append(s.file, rest)
+				continue
+			}
+
+			s.lines[i+1] = append(s.lines[i+1], rest)
+		}
+	}
+
+	return s
+}
+
+// Suppressed returns true if the comment of the analyzer is suppressed by a
+// marker in the file, or in its line or the line above it
+func (s *suppressions) Suppressed(analyzer string, c *lookout.Comment) bool {
+	if markersApply(s.file, analyzer) {
+		return true
+	}
+
+	if c.Line <= 0 {
+		return false
+	}
+
+	return markersApply(s.lines[int(c.Line)], analyzer) ||
+		markersApply(s.lines[int(c.Line)-1], analyzer)
+}
+
+func markersApply(markers []string, analyzer string) bool {
+	for _, m := range markers {
+		if markerApplies(m, analyzer) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// markerApplies returns true if the marker, the text that follows it in the
+// line, applies to the analyzer. A marker followed by a name, like
+// "lookout:ignore my-analyzer", applies only to the analyzer with that name,
+// otherwise it applies to all of them.
+func markerApplies(marker, analyzer string) bool {
+	// the marker must be a separate word, lookout:ignored is not a marker
+	if marker != "" && isNameRune(rune(marker[0])) {
+		return false
+	}
+
+	// analyzer names start with a letter or digit, anything else, like the
+	// end of a comment "-->", is not a name
+	target := strings.TrimLeftFunc(marker, unicode.IsSpace)
+	if r := []rune(target); len(r) == 0 || !unicode.IsLetter(r[0]) && !unicode.IsDigit(r[0]) {
+		return true
+	}
+
+	if len(target) < len(analyzer) ||
+		!strings.EqualFold(target[:len(analyzer)], analyzer) {
+		return false
+	}
+
+	rest := []rune(target[len(analyzer):])
+	return len(rest) == 0 || !isNameRune(rest[0])
+}
+
+func isNameRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-'
+}
+
+// filterSuppressed removes from the results the comments suppressed by
+// markers in the source code, reading the files of the event head revision
+func (s *Server) filterSuppressed(ctx context.Context, e lookout.Event, results analyzerResults) {
+	paths := make(map[string]struct{})
+	for _, r := range results {
+		for _, c := range r.comments {
+			if c.File != "" {
+				paths[c.File] = struct{}{}
+			}
+		}
+	}
+
+	if len(paths) == 0 {
+		return
+	}
+
+	files, err := s.suppressions(ctx, e, paths)
+	if err != nil {
+		ctxlog.Get(ctx).Errorf(err, "can't get the files to check suppression markers")
+		return
+	}
+
+	for i, r := range results {
+		var comments []*lookout.Comment
+		for _, c := range r.comments {
+			if sup, ok := files[c.File]; ok && sup.Suppressed(r.name, c) {
+				continue
+			}
+
+			comments = append(comments, c)
+		}
+
+		results[i].suppressed = len(r.comments) - len(comments)
+		results[i].comments = comments
+
+		if results[i].suppressed > 0 {
+			ctxlog.Get(ctx).With(log.Fields{
+				"analyzer":   r.name,
+				"suppressed": results[i].suppressed,
+			}).Infof("comments suppressed by markers in the source code")
+		}
+	}
+}
+
+// suppressions returns the suppression markers of the given files, the files
+// without markers are not included
+func (s *Server) suppressions(
+	ctx context.Context,
+	e lookout.Event,
+	paths map[string]struct{},
+) (map[string]*suppressions, error) {
+	quoted := make([]string, 0, len(paths))
+	for p := range paths {
+		quoted = append(quoted, regexp.QuoteMeta(p))
+	}
+
+	sort.Strings(quoted)
+
+	rev := e.Revision()
+	scanner, err := s.fileGetter.GetFiles(ctx, &lookout.FilesRequest{
+		Revision:       &rev.Head,
+		IncludePattern: "^(" + strings.Join(quoted, "|") + ")$",
+		WantContents:   true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]*suppressions)
+	for scanner.Next() {
+		f := scanner.File()
+		if _, ok := paths[f.Path]; !ok {
+			continue
+		}
+
+		sup := parseSuppressions(f.Content)
+		if len(sup.file) > 0 || len(sup.lines) > 0 {
+			res[f.Path] = sup
+		}
+	}
+
+	scanner.Close()
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
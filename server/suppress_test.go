@@ -0,0 +1,50 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/src-d/lookout"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuppressions(t *testing.T) {
+	require := require.New(t)
+
+	sup := parseSuppressions([]byte(`package main
+
+// lookout:ignore
+var a = 1
+var b = 2 // lookout:ignore style-analyzer
+var c = 3 // lookout:ignore Example name */
+var d = 4 // lookout:ignored is not a marker
+`))
+
+	comment := func(line int32) *lookout.Comment {
+		return &lookout.Comment{File: "main.go", Line: line}
+	}
+
+	// marker in the line above, for all the analyzers
+	require.True(sup.Suppressed("style-analyzer", comment(4)))
+	require.True(sup.Suppressed("other", comment(4)))
+	// marker for a single analyzer
+	require.True(sup.Suppressed("style-analyzer", comment(5)))
+	require.True(sup.Suppressed("Style-Analyzer", comment(5)))
+	require.False(sup.Suppressed("style", comment(5)))
+	require.False(sup.Suppressed("other", comment(5)))
+	// the marker of the line above applies too
+	require.True(sup.Suppressed("style-analyzer", comment(6)))
+	require.True(sup.Suppressed("Example name", comment(6)))
+	require.False(sup.Suppressed("other", comment(7)))
+	require.False(sup.Suppressed("other", comment(8)))
+	// file-level comments are only suppressed by ignore-file
+	require.False(sup.Suppressed("other", comment(0)))
+
+	sup = parseSuppressions([]byte("# lookout:ignore-file style-analyzer\nx = 1\n"))
+	require.True(sup.Suppressed("style-analyzer", comment(0)))
+	require.True(sup.Suppressed("style-analyzer", comment(2)))
+	require.False(sup.Suppressed("other", comment(2)))
+
+	sup = parseSuppressions([]byte("<!-- lookout:ignore-file -->\n"))
+	require.True(sup.Suppressed("other", comment(10)))
+}
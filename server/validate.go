@@ -0,0 +1,297 @@
+package server
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/src-d/lookout"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	// repoConfigSource names the repository configuration in the problems
+	// reported to the users
+	repoConfigSource = ".lookout.yml"
+	// orgConfigSource names the organization configuration in the problems
+	// reported to the users
+	orgConfigSource = "organization configuration"
+
+	// configCommentAuthor is the analyzer name used for the comments about
+	// configuration problems
+	configCommentAuthor = "lookout"
+)
+
+// ConfigError signals that the configuration files of an event can't be
+// used, the analysis is not run
+type ConfigError struct {
+	// Problems maps each configuration file to the problems found in it
+	Problems map[string][]string
+}
+
+func (e *ConfigError) Error() string {
+	var parts []string
+	for _, source := range sortedSources(e.Problems) {
+		parts = append(parts, fmt.Sprintf("%s: %s",
+			source, strings.Join(e.Problems[source], "; ")))
+	}
+
+	return "invalid configuration: " + strings.Join(parts, ", ")
+}
+
+// configProblems maps each configuration file to the problems found in it
+type configProblems map[string][]string
+
+func (p configProblems) add(source string, problems []string) {
+	if len(problems) > 0 {
+		p[source] = append(p[source], problems...)
+	}
+}
+
+// comment returns a global comment describing the problems
+func (p configProblems) comment(intro string) lookout.AnalyzerComments {
+	var b strings.Builder
+	b.WriteString(intro)
+	for _, source := range sortedSources(p) {
+		fmt.Fprintf(&b, "\n\n**%s**\n", source)
+		for _, problem := range p[source] {
+			fmt.Fprintf(&b, "\n- %s", problem)
+		}
+	}
+
+	return lookout.AnalyzerComments{
+		Config: lookout.AnalyzerConfig{Name: configCommentAuthor},
+		Comments: []*lookout.Comment{{
+			Text:     b.String(),
+			Severity: lookout.ErrorSeverity,
+		}},
+	}
+}
+
+func sortedSources(p map[string][]string) []string {
+	sources := make([]string, 0, len(p))
+	for s := range p {
+		sources = append(sources, s)
+	}
+
+	sort.Strings(sources)
+	return sources
+}
+
+// ValidateConfig returns the problems found in the content of a .lookout.yml
+// or organization configuration: syntax errors, unknown keys, values of the
+// wrong type, invalid policy entries and branch patterns. The analyzer names
+// are not checked.
+func ValidateConfig(content []byte) []string {
+	_, problems, _ := decodeConfig(content, nil)
+	return problems
+}
+
+// decodeConfig decodes the configuration content and validates it. If known
+// is not nil, it is used to check the analyzer names. The returned Config
+// holds the valid parts of the configuration, it can't be used if fatal is
+// true.
+func decodeConfig(content []byte, known func(name string) bool) (conf Config, problems []string, fatal bool) {
+	err := yaml.UnmarshalStrict(content, &conf)
+	switch err := err.(type) {
+	case nil:
+	case *yaml.TypeError:
+		for _, e := range err.Errors {
+			problems = append(problems, typeErrorProblem(e))
+		}
+	default:
+		return Config{}, []string{strings.TrimPrefix(err.Error(), "yaml: ")}, true
+	}
+
+	var ps []string
+	conf.Analyzers, ps = validAnalyzers(conf.Analyzers, known, "")
+	problems = append(problems, ps...)
+
+	conf.Policy, ps = conf.Policy.validate()
+	problems = append(problems, ps...)
+
+	for _, pattern := range sortedBranches(conf.Branches) {
+		b := conf.Branches[pattern]
+		prefix := fmt.Sprintf("branches `%s`: ", pattern)
+		if _, err := path.Match(pattern, ""); err != nil {
+			problems = append(problems, prefix+"invalid branch pattern")
+			delete(conf.Branches, pattern)
+			continue
+		}
+
+		b.Analyzers, ps = validAnalyzers(b.Analyzers, known, prefix)
+		problems = append(problems, ps...)
+
+		b.Policy, ps = b.Policy.validate()
+		for _, p := range ps {
+			problems = append(problems, prefix+p)
+		}
+
+		conf.Branches[pattern] = b
+	}
+
+	return conf, problems, false
+}
+
+// validAnalyzers returns the analyzers with a known name, and the problems
+// found in the others prefixed with the given string
+func validAnalyzers(
+	list []lookout.AnalyzerConfig,
+	known func(name string) bool,
+	prefix string,
+) ([]lookout.AnalyzerConfig, []string) {
+	var (
+		res      []lookout.AnalyzerConfig
+		problems []string
+	)
+
+	for _, a := range list {
+		switch {
+		case a.Name == "":
+			problems = append(problems, prefix+"analyzer without name")
+		case known != nil && !known(a.Name):
+			problems = append(problems, fmt.Sprintf(
+				"%sunknown analyzer `%s`, it is not enabled in the server", prefix, a.Name))
+		default:
+			for _, key := range globalOnlyKeys(a) {
+				problems = append(problems, fmt.Sprintf(
+					"%s`%s` of analyzer `%s` can only be set in the server configuration",
+					prefix, key, a.Name))
+			}
+
+			if !validOutOfDiff(a.OutOfDiff) {
+				problems = append(problems, fmt.Sprintf(
+					"%sunknown out_of_diff `%s` for analyzer `%s`", prefix, a.OutOfDiff, a.Name))
+				a.OutOfDiff = ""
+			}
+
+			res = append(res, a)
+		}
+	}
+
+	return res, problems
+}
+
+// globalOnlyKeys returns the keys set in the analyzer configuration that
+// are ignored out of the server configuration
+func globalOnlyKeys(a lookout.AnalyzerConfig) []string {
+	var keys []string
+	if a.Addr != "" {
+		keys = append(keys, "addr")
+	}
+
+	if a.Feedback != "" {
+		keys = append(keys, "feedback")
+	}
+
+	if a.DependsOn != nil {
+		keys = append(keys, "depends_on")
+	}
+
+	if a.MaxComments != 0 {
+		keys = append(keys, "max_comments")
+	}
+
+	if a.Cache != nil {
+		keys = append(keys, "cache")
+	}
+
+	if a.Autofix != "" {
+		keys = append(keys, "autofix")
+	}
+
+	return keys
+}
+
+// ValidateOutOfDiff checks that the mode of posting the comments outside the
+// diff of the analyzers is known
+func ValidateOutOfDiff(analyzers []lookout.AnalyzerConfig) error {
+	for _, a := range analyzers {
+		if !validOutOfDiff(a.OutOfDiff) {
+			return fmt.Errorf("unknown out_of_diff mode for analyzer %s: %s", a.Name, a.OutOfDiff)
+		}
+	}
+
+	return nil
+}
+
+func validOutOfDiff(mode string) bool {
+	switch mode {
+	case "", lookout.SummaryOutOfDiff, lookout.FileOutOfDiff, lookout.DropOutOfDiff:
+		return true
+	default:
+		return false
+	}
+}
+
+func sortedBranches(branches map[string]BranchConfig) []string {
+	patterns := make([]string, 0, len(branches))
+	for p := range branches {
+		patterns = append(patterns, p)
+	}
+
+	sort.Strings(patterns)
+	return patterns
+}
+
+var (
+	unknownKeyRegexp = regexp.MustCompile(`^(line \d+: )field (\S+) not found in type .+$`)
+	wrongTypeRegexp  = regexp.MustCompile("^(line \\d+: )cannot unmarshal (!!\\w+)(.*) into (.+)$")
+)
+
+// typeErrorProblem rewrites the yaml errors about the Go types as problems
+// for the users
+func typeErrorProblem(e string) string {
+	if m := unknownKeyRegexp.FindStringSubmatch(e); m != nil {
+		return fmt.Sprintf("%sunknown key `%s`", m[1], m[2])
+	}
+
+	if m := wrongTypeRegexp.FindStringSubmatch(e); m != nil {
+		return fmt.Sprintf("%swrong type, expected %s but got %s%s",
+			m[1], goTypeName(m[4]), yamlTagName(m[2]), m[3])
+	}
+
+	return e
+}
+
+var yamlTagNames = map[string]string{
+	"!!str":   "a string",
+	"!!int":   "a number",
+	"!!float": "a number",
+	"!!bool":  "a boolean",
+	"!!seq":   "a list",
+	"!!map":   "a map",
+	"!!null":  "null",
+}
+
+func yamlTagName(tag string) string {
+	if name, ok := yamlTagNames[tag]; ok {
+		return name
+	}
+
+	return tag
+}
+
+func goTypeName(t string) string {
+	t = strings.TrimPrefix(t, "*")
+	switch {
+	case t == "time.Duration":
+		return "a duration, like 30s"
+	case strings.HasPrefix(t, "[]"):
+		return "a list"
+	case strings.HasPrefix(t, "map["), strings.Contains(t, "."):
+		return "a map"
+	case t == "string":
+		return "a string"
+	case t == "bool":
+		return "a boolean"
+	case strings.HasPrefix(t, "int"), strings.HasPrefix(t, "uint"),
+		strings.HasPrefix(t, "float"):
+		return "a number"
+	default:
+		return t
+	}
+}
@@ -0,0 +1,190 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/src-d/lookout"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeConfig(t *testing.T) {
+	require := require.New(t)
+
+	known := func(name string) bool { return name == "mock" }
+
+	conf, problems, fatal := decodeConfig([]byte(`analyzers:
+  - name: mock
+    min_confidence: high
+    unknown_key: true
+  - name: other
+  - settings: {}
+policy:
+  status:
+    fatal: failure
+foo: bar
+`), known)
+	require.False(fatal)
+	require.Equal([]string{
+		"line 3: wrong type, expected a number but got a string `high`",
+		"line 4: unknown key `unknown_key`",
+		"line 10: unknown key `foo`",
+		"unknown analyzer `other`, it is not enabled in the server",
+		"analyzer without name",
+		"unknown severity `fatal` in policy status",
+	}, problems)
+
+	// the valid parts are kept
+	require.Equal([]lookout.AnalyzerConfig{{Name: "mock"}}, conf.Analyzers)
+	require.Empty(conf.Policy.Status)
+
+	_, problems, fatal = decodeConfig([]byte("analyzers: [\n"), known)
+	require.True(fatal)
+	require.Len(problems, 1)
+
+	_, problems, fatal = decodeConfig(nil, known)
+	require.False(fatal)
+	require.Empty(problems)
+}
+
+func TestDecodeConfigOutOfDiff(t *testing.T) {
+	require := require.New(t)
+
+	conf, problems, fatal := decodeConfig([]byte(`analyzers:
+  - name: mock
+    out_of_diff: file
+  - name: other
+    out_of_diff: everywhere
+`), nil)
+	require.False(fatal)
+	require.Equal([]string{
+		"unknown out_of_diff `everywhere` for analyzer `other`",
+	}, problems)
+
+	require.Equal([]lookout.AnalyzerConfig{
+		{Name: "mock", OutOfDiff: lookout.FileOutOfDiff},
+		{Name: "other"},
+	}, conf.Analyzers)
+}
+
+func TestDecodeConfigGlobalOnly(t *testing.T) {
+	require := require.New(t)
+
+	_, problems, fatal := decodeConfig([]byte(`analyzers:
+  - name: mock
+    addr: ipv4://localhost:9930
+    feedback: https://example.com
+    depends_on: []
+    max_comments: 10
+    cache: false
+    autofix: push
+    min_confidence: 50
+branches:
+  master:
+    analyzers:
+      - name: mock
+        autofix: pull_request
+`), nil)
+	require.False(fatal)
+	require.Equal([]string{
+		"`addr` of analyzer `mock` can only be set in the server configuration",
+		"`feedback` of analyzer `mock` can only be set in the server configuration",
+		"`depends_on` of analyzer `mock` can only be set in the server configuration",
+		"`max_comments` of analyzer `mock` can only be set in the server configuration",
+		"`cache` of analyzer `mock` can only be set in the server configuration",
+		"`autofix` of analyzer `mock` can only be set in the server configuration",
+		"branches `master`: `autofix` of analyzer `mock` can only be set in the server configuration",
+	}, problems)
+}
+
+func TestValidateOutOfDiff(t *testing.T) {
+	require := require.New(t)
+
+	require.NoError(ValidateOutOfDiff([]lookout.AnalyzerConfig{
+		{Name: "default"},
+		{Name: "summary", OutOfDiff: lookout.SummaryOutOfDiff},
+		{Name: "file", OutOfDiff: lookout.FileOutOfDiff},
+		{Name: "drop", OutOfDiff: lookout.DropOutOfDiff},
+	}))
+
+	require.EqualError(ValidateOutOfDiff([]lookout.AnalyzerConfig{
+		{Name: "mock", OutOfDiff: "everywhere"},
+	}), "unknown out_of_diff mode for analyzer mock: everywhere")
+}
+
+func TestDecodeConfigBranches(t *testing.T) {
+	require := require.New(t)
+
+	known := func(name string) bool { return name == "mock" }
+
+	conf, problems, fatal := decodeConfig([]byte(`branches:
+  release/*:
+    analyzers:
+      - name: mock
+        disabled: true
+      - name: other
+    policy:
+      review:
+        error: reject
+  "[":
+    analyzers:
+      - name: mock
+`), known)
+	require.False(fatal)
+	require.Equal([]string{
+		"branches `[`: invalid branch pattern",
+		"branches `release/*`: unknown analyzer `other`, it is not enabled in the server",
+		"branches `release/*`: unknown review `reject` for severity `error` in policy",
+	}, problems)
+
+	require.Equal(map[string]BranchConfig{
+		"release/*": {
+			Analyzers: []lookout.AnalyzerConfig{{Name: "mock", Disabled: true}},
+			Policy: Policy{
+				Status: map[string]string{},
+				Review: map[string]string{},
+			},
+		},
+	}, conf.Branches)
+}
+
+func TestValidateConfig(t *testing.T) {
+	require := require.New(t)
+
+	// the analyzer names are not checked
+	require.Empty(ValidateConfig([]byte(`analyzers:
+  - name: any
+    settings:
+      key: value
+`)))
+
+	require.Equal([]string{"line 2: wrong type, expected a list but got a map"},
+		ValidateConfig([]byte("analyzers:\n  name: any\n")))
+}
+
+func TestConfigErrorMessage(t *testing.T) {
+	require := require.New(t)
+
+	err := &ConfigError{Problems: map[string][]string{
+		repoConfigSource: {"line 1: unknown key `foo`", "analyzer without name"},
+		orgConfigSource:  {"unknown analyzer `bar`, it is not enabled in the server"},
+	}}
+
+	require.Equal("invalid configuration: "+
+		".lookout.yml: line 1: unknown key `foo`; analyzer without name, "+
+		"organization configuration: unknown analyzer `bar`, it is not enabled in the server",
+		err.Error())
+
+	comment := configProblems(err.Problems).comment("Intro:")
+	require.Equal(configCommentAuthor, comment.Config.Name)
+	require.Equal(`Intro:
+
+**.lookout.yml**
+
+- line 1: unknown key `+"`foo`"+`
+- analyzer without name
+
+**organization configuration**
+
+- unknown analyzer `+"`bar`"+`, it is not enabled in the server`, comment.Comments[0].Text)
+}
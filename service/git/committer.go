@@ -0,0 +1,425 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/src-d/lookout"
+	"github.com/src-d/lookout/util/ctxlog"
+
+	git "gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/config"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/filemode"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/plumbing/storer"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport"
+	log "gopkg.in/src-d/go-log.v1"
+)
+
+// fixesRefPrefix is the prefix of the local references of the commits with
+// fixes, they are needed to push the commits
+const fixesRefPrefix = "refs/lookout/fixes/"
+
+// Committer creates commits with the fixes of comments in the repositories of
+// a Library, and pushes them
+type Committer struct {
+	Library      ReposCollectionHandler
+	Syncer       Syncer
+	authProvider AuthProvider
+}
+
+// NewCommitter returns a Committer for the repositories of the given Library.
+// authProvider can be nil.
+func NewCommitter(l ReposCollectionHandler, s Syncer, authProvider AuthProvider) *Committer {
+	return &Committer{Library: l, Syncer: s, authProvider: authProvider}
+}
+
+// FixCommit is a commit created with the fixes of some comments
+type FixCommit struct {
+	// Hash is the hash of the commit, plumbing.ZeroHash if no fix could be
+	// applied
+	Hash plumbing.Hash
+	// Applied are the comments whose fix is part of the commit
+	Applied []*lookout.Comment
+	// Conflicts are the comments whose fix overlaps with the fix of another
+	// comment, or does not match the file, and was not applied
+	Conflicts []*lookout.Comment
+}
+
+// Commit creates a commit on top of head with the fixes of the comments. The
+// comments without a fix are ignored. When the fixes of several comments
+// overlap, only the first one is applied, the rest are returned as
+// conflicts.
+func (c *Committer) Commit(
+	ctx context.Context,
+	head lookout.ReferencePointer,
+	comments []*lookout.Comment,
+	message string,
+	author object.Signature,
+) (*FixCommit, error) {
+	if err := c.Syncer.Sync(ctx, head); err != nil {
+		return nil, err
+	}
+
+	r, err := c.Library.GetOrInit(ctx, head.Repository())
+	if err != nil {
+		return nil, err
+	}
+
+	parent, err := r.CommitObject(plumbing.NewHash(head.Hash))
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := parent.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &FixCommit{}
+	blobs := make(map[string]plumbing.Hash)
+	for _, file := range fixedFiles(comments) {
+		fixes, conflicts := selectFixes(file.comments)
+		res.Conflicts = append(res.Conflicts, conflicts...)
+
+		f, err := tree.File(file.path)
+		if err == object.ErrFileNotFound {
+			logFixConflicts(ctx, file.path, fixes, "the file does not exist")
+			res.Conflicts = append(res.Conflicts, fixes...)
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := f.Contents()
+		if err != nil {
+			return nil, err
+		}
+
+		fixed, ok := applyFixes(content, fixes)
+		if !ok {
+			logFixConflicts(ctx, file.path, fixes, "the lines are out of the file")
+			res.Conflicts = append(res.Conflicts, fixes...)
+			continue
+		}
+
+		h, err := writeBlob(r.Storer, fixed)
+		if err != nil {
+			return nil, err
+		}
+
+		blobs[file.path] = h
+		res.Applied = append(res.Applied, fixes...)
+	}
+
+	if len(blobs) == 0 {
+		return res, nil
+	}
+
+	treeHash, err := updateTree(r.Storer, tree, blobs)
+	if err != nil {
+		return nil, err
+	}
+
+	author.When = time.Now()
+	commit := &object.Commit{
+		Author:       author,
+		Committer:    author,
+		Message:      message,
+		TreeHash:     treeHash,
+		ParentHashes: []plumbing.Hash{parent.Hash},
+	}
+
+	res.Hash, err = writeObject(r.Storer, commit)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Push pushes the commit to the branch of the repository. If force is false,
+// the push fails if the branch is not an ancestor of the commit.
+func (c *Committer) Push(
+	ctx context.Context,
+	repoInfo *lookout.RepositoryInfo,
+	hash plumbing.Hash,
+	branch string,
+	force bool,
+) error {
+	r, err := c.Library.Get(ctx, repoInfo)
+	if err != nil {
+		return err
+	}
+
+	local := plumbing.ReferenceName(fixesRefPrefix + branch)
+	if err := r.Storer.SetReference(plumbing.NewHashReference(local, hash)); err != nil {
+		return err
+	}
+	defer r.Storer.RemoveReference(local)
+
+	refspec := fmt.Sprintf("%s:%s", local, plumbing.NewBranchReferenceName(branch))
+	if force {
+		refspec = "+" + refspec
+	}
+
+	var auth transport.AuthMethod
+	if c.authProvider != nil {
+		auth = c.authProvider.GitAuth(ctx, repoInfo)
+	}
+
+	ctxlog.Get(ctx).With(log.Fields{
+		"commit": hash.String(),
+		"branch": branch,
+	}).Infof("pushing fixes to repository %s", repoInfo.CloneURL)
+
+	err = r.PushContext(ctx, &git.PushOptions{
+		RemoteName: defaultRemoteName,
+		RefSpecs:   []config.RefSpec{config.RefSpec(refspec)},
+		Auth:       auth,
+	})
+	if err == git.NoErrAlreadyUpToDate {
+		return nil
+	}
+
+	return err
+}
+
+type fileComments struct {
+	path     string
+	comments []*lookout.Comment
+}
+
+// fixedFiles returns the comments with a fix grouped by file, in the order of
+// the files
+func fixedFiles(comments []*lookout.Comment) []*fileComments {
+	byPath := make(map[string]*fileComments)
+	var files []*fileComments
+	for _, c := range comments {
+		if c.Fix == nil || c.File == "" {
+			continue
+		}
+
+		f, ok := byPath[c.File]
+		if !ok {
+			f = &fileComments{path: c.File}
+			byPath[c.File] = f
+			files = append(files, f)
+		}
+
+		f.comments = append(f.comments, c)
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].path < files[j].path })
+	return files
+}
+
+func fixRange(f *lookout.Fix) (start, end int) {
+	start, end = int(f.StartLine), int(f.EndLine)
+	if end == 0 {
+		end = start
+	}
+
+	return start, end
+}
+
+// selectFixes returns the comments of a file with fixes that can be applied
+// together, sorted by line, and the ones that overlap with them. Identical
+// fixes are applied once.
+func selectFixes(comments []*lookout.Comment) (fixes, conflicts []*lookout.Comment) {
+	sorted := make([]*lookout.Comment, len(comments))
+	copy(sorted, comments)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Fix.StartLine < sorted[j].Fix.StartLine
+	})
+
+	var last *lookout.Comment
+	for _, c := range sorted {
+		start, end := fixRange(c.Fix)
+		if start < 1 || end < start {
+			conflicts = append(conflicts, c)
+			continue
+		}
+
+		if last != nil {
+			lastStart, lastEnd := fixRange(last.Fix)
+			if start == lastStart && end == lastEnd && c.Fix.Text == last.Fix.Text {
+				// the same fix from another comment, it is applied with it
+				fixes = append(fixes, c)
+				continue
+			}
+
+			if start <= lastEnd {
+				conflicts = append(conflicts, c)
+				continue
+			}
+		}
+
+		fixes = append(fixes, c)
+		last = c
+	}
+
+	return fixes, conflicts
+}
+
+// applyFixes returns the content with the fixes applied. The fixes must be
+// sorted by line and must not overlap, but the same fix can be repeated. It
+// returns false if a fix is out of the lines of the content.
+func applyFixes(content string, fixes []*lookout.Comment) (string, bool) {
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	var b strings.Builder
+	next := 1
+	for _, c := range fixes {
+		start, end := fixRange(c.Fix)
+		if start < next {
+			// repeated fix
+			continue
+		}
+
+		if end > len(lines) {
+			return "", false
+		}
+
+		for _, l := range lines[next-1 : start-1] {
+			b.WriteString(l)
+		}
+
+		// an empty text removes the lines
+		if c.Fix.Text != "" {
+			b.WriteString(strings.TrimSuffix(c.Fix.Text, "\n"))
+			// the last line keeps its missing newline
+			if strings.HasSuffix(lines[end-1], "\n") {
+				b.WriteString("\n")
+			}
+		}
+
+		next = end + 1
+	}
+
+	for _, l := range lines[next-1:] {
+		b.WriteString(l)
+	}
+
+	return b.String(), true
+}
+
+func logFixConflicts(ctx context.Context, file string, comments []*lookout.Comment, reason string) {
+	if len(comments) == 0 {
+		return
+	}
+
+	ctxlog.Get(ctx).With(log.Fields{
+		"file":  file,
+		"fixes": len(comments),
+	}).Warningf("fixes not applied, %s", reason)
+}
+
+func writeBlob(s storer.EncodedObjectStorer, content string) (plumbing.Hash, error) {
+	obj := s.NewEncodedObject()
+	obj.SetType(plumbing.BlobObject)
+
+	w, err := obj.Writer()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	if _, err := w.Write([]byte(content)); err != nil {
+		w.Close()
+		return plumbing.ZeroHash, err
+	}
+
+	if err := w.Close(); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	return s.SetEncodedObject(obj)
+}
+
+type encoder interface {
+	Encode(plumbing.EncodedObject) error
+}
+
+func writeObject(s storer.EncodedObjectStorer, o encoder) (plumbing.Hash, error) {
+	obj := s.NewEncodedObject()
+	if err := o.Encode(obj); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	return s.SetEncodedObject(obj)
+}
+
+// updateTree writes a copy of the tree with the blobs of the given paths
+// replaced, and returns its hash
+func updateTree(
+	s storer.EncodedObjectStorer,
+	tree *object.Tree,
+	blobs map[string]plumbing.Hash,
+) (plumbing.Hash, error) {
+	// the blobs of the entries of the tree, and the blobs of the subtrees
+	files := make(map[string]plumbing.Hash)
+	dirs := make(map[string]map[string]plumbing.Hash)
+	for p, h := range blobs {
+		parts := strings.SplitN(p, "/", 2)
+		if len(parts) == 1 {
+			files[p] = h
+			continue
+		}
+
+		if dirs[parts[0]] == nil {
+			dirs[parts[0]] = make(map[string]plumbing.Hash)
+		}
+
+		dirs[parts[0]][parts[1]] = h
+	}
+
+	entries := make([]object.TreeEntry, len(tree.Entries))
+	for i, e := range tree.Entries {
+		entries[i] = e
+
+		if h, ok := files[e.Name]; ok && e.Mode != filemode.Dir {
+			entries[i].Hash = h
+			delete(files, e.Name)
+			continue
+		}
+
+		sub, ok := dirs[e.Name]
+		if !ok || e.Mode != filemode.Dir {
+			continue
+		}
+
+		subtree, err := object.GetTree(s, e.Hash)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+
+		h, err := updateTree(s, subtree, sub)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+
+		entries[i].Hash = h
+		delete(dirs, e.Name)
+	}
+
+	// the blobs come from files of the tree, all of them must be found
+	for p := range files {
+		return plumbing.ZeroHash, fmt.Errorf("file %s not found in tree", p)
+	}
+
+	for p := range dirs {
+		return plumbing.ZeroHash, fmt.Errorf("directory %s not found in tree", path.Clean(p))
+	}
+
+	return writeObject(s, &object.Tree{Entries: entries})
+}
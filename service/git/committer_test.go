@@ -0,0 +1,254 @@
+package git
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/src-d/lookout"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	fixtures "gopkg.in/src-d/go-git-fixtures.v3"
+	git "gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/config"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/cache"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/filesystem"
+	"gopkg.in/src-d/lookout-sdk.v0/pb"
+)
+
+func fixComment(file string, start, end int32, text string) *lookout.Comment {
+	return &lookout.Comment{
+		File: file,
+		Line: start,
+		Text: "style issue",
+		Fix:  &lookout.Fix{StartLine: start, EndLine: end, Text: text},
+	}
+}
+
+func TestSelectFixes(t *testing.T) {
+	require := require.New(t)
+
+	first := fixComment("a.go", 1, 2, "a\n")
+	overlapping := fixComment("a.go", 2, 3, "b\n")
+	duplicate := fixComment("a.go", 1, 2, "a\n")
+	single := fixComment("a.go", 5, 0, "c\n")
+	invalid := fixComment("a.go", 7, 6, "d\n")
+
+	fixes, conflicts := selectFixes([]*lookout.Comment{
+		single, overlapping, first, invalid, duplicate,
+	})
+
+	require.Equal([]*lookout.Comment{first, duplicate, single}, fixes)
+	require.Equal([]*lookout.Comment{overlapping, invalid}, conflicts)
+}
+
+func TestApplyFixes(t *testing.T) {
+	content := "1\n2\n3\n4\n5"
+	cases := []struct {
+		name     string
+		fixes    []*lookout.Comment
+		expected string
+		ok       bool
+	}{{
+		"single line",
+		[]*lookout.Comment{fixComment("a", 2, 0, "two")},
+		"1\ntwo\n3\n4\n5", true,
+	}, {
+		"several lines",
+		[]*lookout.Comment{fixComment("a", 2, 3, "two\nthree\nmore\n")},
+		"1\ntwo\nthree\nmore\n4\n5", true,
+	}, {
+		"delete lines",
+		[]*lookout.Comment{fixComment("a", 1, 2, "")},
+		"3\n4\n5", true,
+	}, {
+		"last line without newline",
+		[]*lookout.Comment{fixComment("a", 5, 5, "five\n")},
+		"1\n2\n3\n4\nfive", true,
+	}, {
+		"several fixes",
+		[]*lookout.Comment{
+			fixComment("a", 1, 0, "one"),
+			fixComment("a", 1, 0, "one"),
+			fixComment("a", 4, 5, "end\n"),
+		},
+		"one\n2\n3\nend", true,
+	}, {
+		"out of the file",
+		[]*lookout.Comment{fixComment("a", 5, 6, "x")},
+		"", false,
+	}}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			fixed, ok := applyFixes(content, c.fixes)
+			require.Equal(t, c.ok, ok)
+			require.Equal(t, c.expected, fixed)
+		})
+	}
+}
+
+type CommitterSuite struct {
+	suite.Suite
+	repo   *git.Repository
+	remote *git.Repository
+	head   lookout.ReferencePointer
+	dir    string
+}
+
+func (s *CommitterSuite) SetupTest() {
+	require := s.Require()
+	require.NoError(fixtures.Init())
+
+	fixture := fixtures.Basic().One()
+	sto := filesystem.NewStorage(fixture.DotGit(), cache.NewObjectLRU(cache.DefaultMaxSize))
+
+	var err error
+	s.repo, err = git.Open(sto, nil)
+	require.NoError(err)
+
+	s.dir, err = ioutil.TempDir("", "lookout-committer")
+	require.NoError(err)
+
+	s.remote, err = git.PlainInit(s.dir, true)
+	require.NoError(err)
+
+	// the fixture has its own origin
+	require.NoError(s.repo.DeleteRemote(defaultRemoteName))
+	_, err = s.repo.CreateRemote(&config.RemoteConfig{
+		Name: defaultRemoteName,
+		URLs: []string{s.dir},
+	})
+	require.NoError(err)
+
+	s.head = lookout.ReferencePointer{
+		InternalRepositoryURL: "https://github.com/foo/bar",
+		ReferenceName:         "refs/heads/master",
+		Hash:                  fixture.Head.String(),
+	}
+}
+
+func (s *CommitterSuite) TearDownTest() {
+	s.Require().NoError(fixtures.Clean())
+	s.Require().NoError(os.RemoveAll(s.dir))
+}
+
+func (s *CommitterSuite) committer() *Committer {
+	ms := new(MockSyncer)
+	ms.On("Sync", mock.Anything, mock.Anything).Return(nil)
+
+	ml := new(MockLibrary)
+	ml.On("GetOrInit", mock.Anything, mock.Anything).Return(s.repo, nil)
+	ml.On("Get", mock.Anything, mock.Anything).Return(s.repo, nil)
+
+	return NewCommitter(ml, ms, nil)
+}
+
+func (s *CommitterSuite) fileContents(c *object.Commit, path string) string {
+	f, err := c.File(path)
+	s.Require().NoError(err)
+
+	content, err := f.Contents()
+	s.Require().NoError(err)
+
+	return content
+}
+
+func (s *CommitterSuite) TestCommit() {
+	require := s.Require()
+
+	applied := fixComment("CHANGELOG", 1, 0, "Initial changelog, fixed\n")
+	nested := fixComment("go/example.go", 1, 1, "package fixed\n")
+	conflict := fixComment("go/example.go", 1, 2, "package other\n")
+	missing := fixComment("missing.go", 1, 0, "package missing\n")
+	noFix := &lookout.Comment{File: "CHANGELOG", Line: 1, Text: "no fix"}
+
+	author := object.Signature{Name: "lookout", Email: "lookout@example.com"}
+	res, err := s.committer().Commit(context.TODO(), s.head,
+		[]*lookout.Comment{applied, nested, conflict, missing, noFix},
+		"Apply fixes", author)
+	require.NoError(err)
+
+	require.Equal([]*lookout.Comment{applied, nested}, res.Applied)
+	require.Equal([]*lookout.Comment{conflict, missing}, res.Conflicts)
+	require.NotEqual(plumbing.ZeroHash, res.Hash)
+
+	commit, err := s.repo.CommitObject(res.Hash)
+	require.NoError(err)
+	require.Equal("Apply fixes", commit.Message)
+	require.Equal("lookout", commit.Author.Name)
+	require.Equal([]plumbing.Hash{plumbing.NewHash(s.head.Hash)}, commit.ParentHashes)
+
+	require.Equal("Initial changelog, fixed\n", s.fileContents(commit, "CHANGELOG"))
+	require.True(strings.HasPrefix(s.fileContents(commit, "go/example.go"), "package fixed\n\n"))
+
+	// the rest of the tree is not changed
+	parent, err := commit.Parent(0)
+	require.NoError(err)
+	require.Equal(s.fileContents(parent, "LICENSE"), s.fileContents(commit, "LICENSE"))
+	require.Equal(s.fileContents(parent, "php/crappy.php"), s.fileContents(commit, "php/crappy.php"))
+
+	stats, err := commit.Stats()
+	require.NoError(err)
+	require.Len(stats, 2)
+}
+
+func (s *CommitterSuite) TestCommitNothingApplied() {
+	require := s.Require()
+
+	conflict := fixComment("CHANGELOG", 10, 0, "out of the file\n")
+	res, err := s.committer().Commit(context.TODO(), s.head,
+		[]*lookout.Comment{conflict}, "Apply fixes", object.Signature{})
+	require.NoError(err)
+
+	require.Equal(plumbing.ZeroHash, res.Hash)
+	require.Len(res.Applied, 0)
+	require.Equal([]*lookout.Comment{conflict}, res.Conflicts)
+}
+
+func (s *CommitterSuite) TestPush() {
+	require := s.Require()
+
+	c := s.committer()
+	res, err := c.Commit(context.TODO(), s.head,
+		[]*lookout.Comment{fixComment("CHANGELOG", 1, 0, "fixed\n")},
+		"Apply fixes", object.Signature{})
+	require.NoError(err)
+
+	repoInfo, _ := pb.ParseRepositoryInfo(s.head.InternalRepositoryURL)
+	require.NoError(c.Push(context.TODO(), repoInfo, res.Hash, "lookout/fixes", false))
+
+	ref, err := s.remote.Reference("refs/heads/lookout/fixes", false)
+	require.NoError(err)
+	require.Equal(res.Hash, ref.Hash())
+
+	// the local reference is removed
+	_, err = s.repo.Reference(fixesRefPrefix+"lookout/fixes", false)
+	require.Equal(plumbing.ErrReferenceNotFound, err)
+
+	// pushing again is not an error
+	require.NoError(c.Push(context.TODO(), repoInfo, res.Hash, "lookout/fixes", false))
+
+	// a commit that does not descend from the branch needs force
+	other, err := c.Commit(context.TODO(), s.head,
+		[]*lookout.Comment{fixComment("CHANGELOG", 1, 0, "other fix\n")},
+		"Apply other fixes", object.Signature{})
+	require.NoError(err)
+
+	require.Error(c.Push(context.TODO(), repoInfo, other.Hash, "lookout/fixes", false))
+	require.NoError(c.Push(context.TODO(), repoInfo, other.Hash, "lookout/fixes", true))
+
+	ref, err = s.remote.Reference("refs/heads/lookout/fixes", false)
+	require.NoError(err)
+	require.Equal(other.Hash, ref.Hash())
+}
+
+func TestCommitterSuite(t *testing.T) {
+	suite.Run(t, new(CommitterSuite))
+}
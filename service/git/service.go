@@ -51,14 +51,21 @@ func validateReferences(ctx context.Context, validateRefName bool, refs ...*look
 }
 
 // GetChanges returns a ChangeScanner that scans all changes according to the request.
+// If the base is missing, or has no hash, like in scheduled push events, all
+// the files of the head are returned as changes.
 func (r *Service) GetChanges(ctx context.Context, req *lookout.ChangesRequest) (
 	lookout.ChangeScanner, error) {
-	err := validateReferences(ctx, true, req.Base, req.Head)
+	baseRef := req.Base
+	if baseRef != nil && baseRef.Hash == "" {
+		baseRef = nil
+	}
+
+	err := validateReferences(ctx, true, baseRef, req.Head)
 	if err != nil {
 		return nil, err
 	}
 
-	base, head, err := r.loadTrees(ctx, req.Base, req.Head)
+	base, head, err := r.loadTrees(ctx, baseRef, req.Head)
 	if err != nil {
 		return nil, err
 	}
@@ -168,9 +168,14 @@ func (s *ServiceGetChangesSuite) TestNoParams() {
 		Base: s.buildRefPointer("file:///myrepo", "referenceName", baseHash),
 	}
 
-	changesRequests := [2]*lookout.ChangesRequest{changesRequestNoBase, changesRequestWithBase}
-	testNames := [2]string{"without base", "with base"}
-	expectedChanges := [2]int{9, 5}
+	changesRequestBaseNoHash := &lookout.ChangesRequest{
+		Head: s.buildRefPointer("file:///myrepo", "referenceName", headHash),
+		Base: s.buildRefPointer("file:///myrepo", "referenceName", ""),
+	}
+
+	changesRequests := [3]*lookout.ChangesRequest{changesRequestNoBase, changesRequestWithBase, changesRequestBaseNoHash}
+	testNames := [3]string{"without base", "with base", "base without hash"}
+	expectedChanges := [3]int{9, 5, 9}
 
 	for i, changesReq := range changesRequests {
 		s.T().Run(testNames[i], func(t *testing.T) {
@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/src-d/lookout"
 	"github.com/src-d/lookout/store/models"
@@ -55,6 +56,96 @@ func (o *DBEventOperator) UpdateStatus(ctx context.Context, e lookout.Event, sta
 	}
 }
 
+// Attempts implements EventOperator interface
+func (o *DBEventOperator) Attempts(ctx context.Context, e lookout.Event) (int, time.Time, error) {
+	switch ev := e.(type) {
+	case *lookout.ReviewEvent:
+		m, err := o.getReview(ctx, ev)
+		if err != nil {
+			return 0, time.Time{}, err
+		}
+
+		return m.Attempts, m.NextRetryAt, nil
+	case *lookout.PushEvent:
+		m, err := o.getPush(ctx, ev)
+		if err != nil {
+			return 0, time.Time{}, err
+		}
+
+		return m.Attempts, m.NextRetryAt, nil
+	default:
+		ctxlog.Get(ctx).Debugf("ignoring unsupported event: %s", ev)
+		return 0, time.Time{}, nil
+	}
+}
+
+// UpdateAttempts implements EventOperator interface
+func (o *DBEventOperator) UpdateAttempts(ctx context.Context, e lookout.Event, attempts int, nextRetryAt time.Time) error {
+	switch ev := e.(type) {
+	case *lookout.ReviewEvent:
+		m, err := o.getReview(ctx, ev)
+		if err != nil {
+			return err
+		}
+
+		m.Attempts = attempts
+		m.NextRetryAt = nextRetryAt
+
+		_, err = o.reviewsStore.Update(m,
+			models.Schema.ReviewEvent.Attempts,
+			models.Schema.ReviewEvent.NextRetryAt)
+		return err
+	case *lookout.PushEvent:
+		m, err := o.getPush(ctx, ev)
+		if err != nil {
+			return err
+		}
+
+		m.Attempts = attempts
+		m.NextRetryAt = nextRetryAt
+
+		_, err = o.pushStore.Update(m,
+			models.Schema.PushEvent.Attempts,
+			models.Schema.PushEvent.NextRetryAt)
+		return err
+	default:
+		ctxlog.Get(ctx).Debugf("ignoring unsupported event: %s", ev)
+		return nil
+	}
+}
+
+// Superseded implements EventOperator interface
+func (o *DBEventOperator) Superseded(ctx context.Context, e lookout.Event) (bool, error) {
+	ev, ok := e.(*lookout.ReviewEvent)
+	if !ok {
+		return false, nil
+	}
+
+	target, err := o.getReviewTarget(ctx, ev)
+	if err == kallax.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	q := models.NewReviewEventQuery().
+		FindByReviewTarget(target.ID).
+		FindByUpdatedAt(kallax.Gt, ev.UpdatedAt)
+	newer, err := o.reviewsStore.FindAll(q)
+	if err != nil {
+		return false, err
+	}
+
+	for _, m := range newer {
+		if m.Head.Hash != ev.Head.Hash {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 func (o *DBEventOperator) saveReview(ctx context.Context, e *lookout.ReviewEvent) (models.EventStatus, error) {
 	m, err := o.getReview(ctx, e)
 	if err == kallax.ErrNotFound {
@@ -157,6 +248,8 @@ type DBCommentOperator struct {
 	store             *models.CommentStore
 	reviewsStore      *models.ReviewEventStore
 	reviewTargetStore *models.ReviewTargetStore
+	pushStore         *models.PushEventStore
+	filteredStore     *models.FilteredCommentStore
 }
 
 // NewDBCommentOperator creates new DBCommentOperator using kallax as storage
@@ -164,20 +257,24 @@ func NewDBCommentOperator(
 	c *models.CommentStore,
 	r *models.ReviewEventStore,
 	rt *models.ReviewTargetStore,
+	p *models.PushEventStore,
+	f *models.FilteredCommentStore,
 ) *DBCommentOperator {
-	return &DBCommentOperator{c, r, rt}
+	return &DBCommentOperator{c, r, rt, p, f}
 }
 
 var _ CommentOperator = &DBCommentOperator{}
 
 // Save implements EventOperator interface
 func (o *DBCommentOperator) Save(ctx context.Context, e lookout.Event, c *lookout.Comment, analyzerName string) error {
-	ev, ok := e.(*lookout.ReviewEvent)
-	if !ok {
-		return fmt.Errorf("comments can belong only to review event but %v is given", e.Type())
+	switch ev := e.(type) {
+	case *lookout.ReviewEvent:
+		return o.save(ctx, ev, c, analyzerName)
+	case *lookout.PushEvent:
+		return o.savePush(ctx, ev, c, analyzerName)
+	default:
+		return fmt.Errorf("comments can belong only to review or push event but %v is given", e.Type())
 	}
-
-	return o.save(ctx, ev, c, analyzerName)
 }
 
 // Posted implements EventOperator interface
@@ -190,6 +287,20 @@ func (o *DBCommentOperator) Posted(ctx context.Context, e lookout.Event, c *look
 	return o.posted(ctx, ev, c)
 }
 
+// SaveFiltered implements CommentOperator interface
+func (o *DBCommentOperator) SaveFiltered(ctx context.Context, c *models.FilteredComment) error {
+	return o.filteredStore.Insert(c)
+}
+
+// Filtered implements CommentOperator interface
+func (o *DBCommentOperator) Filtered(ctx context.Context, e lookout.Event) ([]*models.FilteredComment, error) {
+	q := models.NewFilteredCommentQuery().
+		FindByEventType(kallax.Eq, e.Type()).
+		FindByEventID(e.ID().String())
+
+	return o.filteredStore.FindAll(q)
+}
+
 func (o *DBCommentOperator) save(ctx context.Context, e *lookout.ReviewEvent, c *lookout.Comment, analyzerName string) error {
 	q := models.NewReviewEventQuery().FindByInternalID(e.ID().String())
 
@@ -204,6 +315,23 @@ func (o *DBCommentOperator) save(ctx context.Context, e *lookout.ReviewEvent, c
 	return err
 }
 
+func (o *DBCommentOperator) savePush(ctx context.Context, e *lookout.PushEvent, c *lookout.Comment, analyzerName string) error {
+	q := models.NewPushEventQuery().
+		FindByProvider(e.Provider).
+		FindByInternalID(e.InternalID)
+
+	p, err := o.pushStore.FindOne(q)
+	if err != nil {
+		return err
+	}
+
+	m := models.NewComment(nil, c)
+	m.PushEvent = p
+	m.Analyzer = analyzerName
+	_, err = o.store.Save(m)
+	return err
+}
+
 func (o *DBCommentOperator) posted(ctx context.Context, e *lookout.ReviewEvent, c *lookout.Comment) (bool, error) {
 	// select with joins don't work in kallax
 	// https://github.com/src-d/go-kallax/issues/250
@@ -247,6 +375,99 @@ func (o *DBCommentOperator) posted(ctx context.Context, e *lookout.ReviewEvent,
 	return count > 0, nil
 }
 
+// DBAnalyzerRunOperator operates on analyzer runs database store
+type DBAnalyzerRunOperator struct {
+	store *models.AnalyzerRunStore
+}
+
+// NewDBAnalyzerRunOperator creates new DBAnalyzerRunOperator using kallax as storage
+func NewDBAnalyzerRunOperator(store *models.AnalyzerRunStore) *DBAnalyzerRunOperator {
+	return &DBAnalyzerRunOperator{store}
+}
+
+var _ AnalyzerRunOperator = &DBAnalyzerRunOperator{}
+
+// Save implements AnalyzerRunOperator interface
+func (o *DBAnalyzerRunOperator) Save(ctx context.Context, r *models.AnalyzerRun) error {
+	return o.store.Insert(r)
+}
+
+// Runs implements AnalyzerRunOperator interface
+func (o *DBAnalyzerRunOperator) Runs(ctx context.Context, e lookout.Event) ([]*models.AnalyzerRun, error) {
+	q := models.NewAnalyzerRunQuery().
+		FindByEventType(kallax.Eq, e.Type()).
+		FindByEventID(e.ID().String()).
+		Order(kallax.Asc(models.Schema.AnalyzerRun.StartedAt))
+
+	return o.store.FindAll(q)
+}
+
+// DBResponseCacheOperator operates on cached responses database store
+type DBResponseCacheOperator struct {
+	store *models.CachedResponseStore
+}
+
+// NewDBResponseCacheOperator creates new DBResponseCacheOperator using kallax
+// as storage
+func NewDBResponseCacheOperator(store *models.CachedResponseStore) *DBResponseCacheOperator {
+	return &DBResponseCacheOperator{store}
+}
+
+var _ ResponseCacheOperator = &DBResponseCacheOperator{}
+
+// Get implements ResponseCacheOperator interface
+func (o *DBResponseCacheOperator) Get(ctx context.Context, key string) (*lookout.EventResponse, error) {
+	q := models.NewCachedResponseQuery().
+		FindByCacheKey(key).
+		FindByExpiresAt(kallax.Gt, time.Now())
+
+	m, err := o.store.FindOne(q)
+	if err == kallax.ErrNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var resp lookout.EventResponse
+	if err := resp.Unmarshal(m.Response); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Save implements ResponseCacheOperator interface. The response replaces the
+// one cached with the same key, if any, and the expired responses are deleted.
+func (o *DBResponseCacheOperator) Save(ctx context.Context, key string, resp *lookout.EventResponse, expiresAt time.Time) error {
+	data, err := resp.Marshal()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if _, err := o.store.RawExec(
+		"DELETE FROM cached_response WHERE expires_at <= $1",
+		now,
+	); err != nil {
+		return err
+	}
+
+	// the same key can be saved concurrently by several workers
+	m := models.NewCachedResponse(key, data, now, expiresAt)
+	_, err = o.store.RawExec(
+		`INSERT INTO cached_response (id, cache_key, response, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (cache_key) DO UPDATE SET
+			response = EXCLUDED.response,
+			created_at = EXCLUDED.created_at,
+			expires_at = EXCLUDED.expires_at`,
+		m.ID, m.CacheKey, m.Response, m.CreatedAt, m.ExpiresAt,
+	)
+	return err
+}
+
 // DBOrganizationOperator operates on an organization database store
 type DBOrganizationOperator struct {
 	organizationStore *models.OrganizationStore
@@ -296,3 +517,167 @@ func (o *DBOrganizationOperator) Config(ctx context.Context, provider string, or
 
 	return m.Config, nil
 }
+
+// DBFeedbackOperator operates on comment feedback database store
+type DBFeedbackOperator struct {
+	store         *models.CommentFeedbackStore
+	commentsStore *models.CommentStore
+	reviewsStore  *models.ReviewEventStore
+}
+
+// NewDBFeedbackOperator creates new DBFeedbackOperator using kallax as storage
+func NewDBFeedbackOperator(
+	f *models.CommentFeedbackStore,
+	c *models.CommentStore,
+	r *models.ReviewEventStore,
+) *DBFeedbackOperator {
+	return &DBFeedbackOperator{f, c, r}
+}
+
+var _ FeedbackOperator = &DBFeedbackOperator{}
+
+// Posted implements FeedbackOperator interface
+func (o *DBFeedbackOperator) Posted(ctx context.Context, since time.Time) ([]*PostedComments, error) {
+	// select with joins don't work in kallax
+	// https://github.com/src-d/go-kallax/issues/250
+
+	q := models.NewCommentQuery().
+		FindByCreatedAt(kallax.GtOrEq, since).
+		FindByLine(kallax.Gt, 0).
+		WithReviewEvent().
+		Order(kallax.Asc(models.Schema.Comment.ID))
+	comments, err := o.commentsStore.FindAll(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(comments) == 0 {
+		return nil, nil
+	}
+
+	reviewIds := make([]kallax.ULID, 0, len(comments))
+	seen := make(map[kallax.ULID]bool)
+	for _, c := range comments {
+		if c.ReviewEvent == nil {
+			continue
+		}
+
+		id := c.ReviewEvent.ID
+		if !seen[id] {
+			seen[id] = true
+			reviewIds = append(reviewIds, id)
+		}
+	}
+
+	reviews, err := o.reviewsStore.FindAll(models.NewReviewEventQuery().
+		FindByID(reviewIds...).
+		WithReviewTarget())
+	if err != nil {
+		return nil, err
+	}
+
+	reviewsByID := make(map[kallax.ULID]*models.ReviewEvent, len(reviews))
+	for _, r := range reviews {
+		reviewsByID[r.ID] = r
+	}
+
+	var result []*PostedComments
+	byTarget := make(map[kallax.ULID]*PostedComments)
+	for _, c := range comments {
+		if c.ReviewEvent == nil {
+			continue
+		}
+
+		r, ok := reviewsByID[c.ReviewEvent.ID]
+		if !ok || r.ReviewTarget == nil {
+			continue
+		}
+
+		p, ok := byTarget[r.ReviewTarget.ID]
+		if !ok {
+			repo := r.Head.Repository()
+			if repo == nil {
+				continue
+			}
+
+			p = &PostedComments{
+				Provider:   r.ReviewTarget.Provider,
+				Repository: repo,
+				Number:     r.ReviewTarget.Number,
+			}
+
+			byTarget[r.ReviewTarget.ID] = p
+			result = append(result, p)
+		}
+
+		p.Comments = append(p.Comments, c)
+	}
+
+	return result, nil
+}
+
+// Save implements FeedbackOperator interface
+func (o *DBFeedbackOperator) Save(ctx context.Context, f *models.CommentFeedback) error {
+	q := models.NewCommentFeedbackQuery().FindByComment(f.Comment.ID)
+	m, err := o.store.FindOne(q)
+	if err == kallax.ErrNotFound {
+		return o.store.Insert(f)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	m.InternalID = f.InternalID
+	m.ThumbsUp = f.ThumbsUp
+	m.ThumbsDown = f.ThumbsDown
+	m.Confused = f.Confused
+	m.Replies = f.Replies
+
+	_, err = o.store.Update(m,
+		models.Schema.CommentFeedback.InternalID,
+		models.Schema.CommentFeedback.ThumbsUp,
+		models.Schema.CommentFeedback.ThumbsDown,
+		models.Schema.CommentFeedback.Confused,
+		models.Schema.CommentFeedback.Replies)
+	return err
+}
+
+// the organization is the owner in the clone URL of the base repository of
+// the pull request, like https://github.com/<org>/<repo>.git
+const acceptanceQuery = `SELECT c.analyzer, c.rule, COUNT(*),
+	COALESCE(SUM(f.thumbs_up), 0), COALESCE(SUM(f.thumbs_down), 0),
+	COALESCE(SUM(f.confused), 0), COALESCE(SUM(f.replies), 0)
+FROM comment c
+	JOIN review_event e ON e.id = c.review_event_id
+	LEFT JOIN comment_feedback f ON f.comment_id = c.id
+WHERE c.line > 0
+	AND lower(split_part(e.base->>'internal_repository_url', '/', 4)) = lower($1)
+	AND ($2::text = '' OR c.analyzer = $2)
+GROUP BY c.analyzer, c.rule
+ORDER BY c.analyzer, c.rule`
+
+// Acceptance implements FeedbackOperator interface
+func (o *DBFeedbackOperator) Acceptance(ctx context.Context, org, analyzer string) ([]*Acceptance, error) {
+	rs, err := o.commentsStore.RawQuery(acceptanceQuery, org, analyzer)
+	if err != nil {
+		return nil, err
+	}
+	defer rs.Close()
+
+	var result []*Acceptance
+	for rs.Next() {
+		var (
+			name, rule                            string
+			comments, up, down, confused, replies int
+		)
+
+		if err := rs.RawScan(&name, &rule, &comments, &up, &down, &confused, &replies); err != nil {
+			return nil, err
+		}
+
+		result = append(result, NewAcceptance(name, rule, comments, up, down, confused, replies))
+	}
+
+	return result, nil
+}
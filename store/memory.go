@@ -3,6 +3,9 @@ package store
 import (
 	"context"
 	"errors"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/src-d/lookout"
 	"github.com/src-d/lookout/store/models"
@@ -10,23 +13,52 @@ import (
 
 // MemEventOperator satisfies EventOperator interface keeps events in memory
 type MemEventOperator struct {
-	events map[string]models.EventStatus
+	mu       sync.Mutex
+	events   map[string]models.EventStatus
+	attempts map[string]memAttempts
+	// reviews are the heads of the review events of each review target
+	reviews map[string][]memReview
+}
+
+type memReview struct {
+	head      string
+	updatedAt time.Time
+}
+
+type memAttempts struct {
+	attempts    int
+	nextRetryAt time.Time
 }
 
 // NewMemEventOperator creates new MemEventOperator
 func NewMemEventOperator() *MemEventOperator {
-	return &MemEventOperator{events: make(map[string]models.EventStatus)}
+	return &MemEventOperator{
+		events:   make(map[string]models.EventStatus),
+		attempts: make(map[string]memAttempts),
+		reviews:  make(map[string][]memReview),
+	}
 }
 
 var _ EventOperator = &MemEventOperator{}
 
 // Save implements EventOperator interface
 func (o *MemEventOperator) Save(ctx context.Context, e lookout.Event) (models.EventStatus, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
 	id := e.ID().String()
 	s, ok := o.events[id]
 	if !ok {
 		s = models.EventStatusNew
 		o.events[id] = s
+
+		if ev, ok := e.(*lookout.ReviewEvent); ok {
+			key := memReviewTarget(ev)
+			o.reviews[key] = append(o.reviews[key], memReview{
+				head:      ev.Head.Hash,
+				updatedAt: ev.UpdatedAt,
+			})
+		}
 	}
 
 	return s, nil
@@ -34,6 +66,9 @@ func (o *MemEventOperator) Save(ctx context.Context, e lookout.Event) (models.Ev
 
 // UpdateStatus implements EventOperator interface
 func (o *MemEventOperator) UpdateStatus(ctx context.Context, e lookout.Event, s models.EventStatus) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
 	id := e.ID().String()
 	if _, ok := o.events[id]; !ok {
 		return errors.New("event not found")
@@ -43,22 +78,85 @@ func (o *MemEventOperator) UpdateStatus(ctx context.Context, e lookout.Event, s
 	return nil
 }
 
+// Attempts implements EventOperator interface
+func (o *MemEventOperator) Attempts(ctx context.Context, e lookout.Event) (int, time.Time, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	id := e.ID().String()
+	if _, ok := o.events[id]; !ok {
+		return 0, time.Time{}, errors.New("event not found")
+	}
+
+	a := o.attempts[id]
+	return a.attempts, a.nextRetryAt, nil
+}
+
+// UpdateAttempts implements EventOperator interface
+func (o *MemEventOperator) UpdateAttempts(ctx context.Context, e lookout.Event, attempts int, nextRetryAt time.Time) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	id := e.ID().String()
+	if _, ok := o.events[id]; !ok {
+		return errors.New("event not found")
+	}
+
+	o.attempts[id] = memAttempts{attempts: attempts, nextRetryAt: nextRetryAt}
+	return nil
+}
+
+// Superseded implements EventOperator interface
+func (o *MemEventOperator) Superseded(ctx context.Context, e lookout.Event) (bool, error) {
+	ev, ok := e.(*lookout.ReviewEvent)
+	if !ok {
+		return false, nil
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for _, r := range o.reviews[memReviewTarget(ev)] {
+		if r.updatedAt.After(ev.UpdatedAt) && r.head != ev.Head.Hash {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func memReviewTarget(e *lookout.ReviewEvent) string {
+	return e.Provider + "/" + e.InternalID
+}
+
 // MemCommentOperator satisfies CommentOperator interface but does nothing
 type MemCommentOperator struct {
 	comments map[string][]*lookout.Comment
+
+	mu       sync.Mutex
+	filtered map[string][]*models.FilteredComment
 }
 
 // NewMemCommentOperator creates new MemCommentOperator
 func NewMemCommentOperator() *MemCommentOperator {
-	return &MemCommentOperator{comments: make(map[string][]*lookout.Comment)}
+	return &MemCommentOperator{
+		comments: make(map[string][]*lookout.Comment),
+		filtered: make(map[string][]*models.FilteredComment),
+	}
 }
 
 var _ CommentOperator = &MemCommentOperator{}
 
 // Save implements EventOperator interface
 func (o *MemCommentOperator) Save(ctx context.Context, e lookout.Event, c *lookout.Comment, analyzerName string) error {
-	re := e.(*lookout.ReviewEvent)
-	o.comments[re.InternalID] = append(o.comments[re.InternalID], c)
+	// the comments of a push are not checked by Posted, they are kept by
+	// event to not mix them with the ones of the pull requests
+	key := e.ID().String()
+	if re, ok := e.(*lookout.ReviewEvent); ok {
+		key = re.InternalID
+	}
+
+	o.comments[key] = append(o.comments[key], c)
 
 	return nil
 }
@@ -80,3 +178,125 @@ func (o *MemCommentOperator) Posted(ctx context.Context, e lookout.Event, c *loo
 
 	return false, nil
 }
+
+// SaveFiltered implements CommentOperator interface
+func (o *MemCommentOperator) SaveFiltered(ctx context.Context, c *models.FilteredComment) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.filtered[c.EventID] = append(o.filtered[c.EventID], c)
+	return nil
+}
+
+// Filtered implements CommentOperator interface
+func (o *MemCommentOperator) Filtered(ctx context.Context, e lookout.Event) ([]*models.FilteredComment, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var res []*models.FilteredComment
+	for _, c := range o.filtered[e.ID().String()] {
+		if c.EventType == e.Type() {
+			res = append(res, c)
+		}
+	}
+
+	return res, nil
+}
+
+// MemAnalyzerRunOperator satisfies AnalyzerRunOperator interface keeps analyzer
+// runs in memory
+type MemAnalyzerRunOperator struct {
+	mu   sync.Mutex
+	runs map[string][]*models.AnalyzerRun
+}
+
+// NewMemAnalyzerRunOperator creates new MemAnalyzerRunOperator
+func NewMemAnalyzerRunOperator() *MemAnalyzerRunOperator {
+	return &MemAnalyzerRunOperator{runs: make(map[string][]*models.AnalyzerRun)}
+}
+
+var _ AnalyzerRunOperator = &MemAnalyzerRunOperator{}
+
+// Save implements AnalyzerRunOperator interface
+func (o *MemAnalyzerRunOperator) Save(ctx context.Context, r *models.AnalyzerRun) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.runs[r.EventID] = append(o.runs[r.EventID], r)
+	return nil
+}
+
+// Runs implements AnalyzerRunOperator interface
+func (o *MemAnalyzerRunOperator) Runs(ctx context.Context, e lookout.Event) ([]*models.AnalyzerRun, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var res []*models.AnalyzerRun
+	for _, r := range o.runs[e.ID().String()] {
+		if r.EventType == e.Type() {
+			res = append(res, r)
+		}
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].StartedAt.Before(res[j].StartedAt)
+	})
+
+	return res, nil
+}
+
+type memCachedResponse struct {
+	response  []byte
+	expiresAt time.Time
+}
+
+// MemResponseCacheOperator satisfies ResponseCacheOperator interface keeps
+// cached responses in memory
+type MemResponseCacheOperator struct {
+	mu        sync.Mutex
+	responses map[string]memCachedResponse
+}
+
+// NewMemResponseCacheOperator creates new MemResponseCacheOperator
+func NewMemResponseCacheOperator() *MemResponseCacheOperator {
+	return &MemResponseCacheOperator{responses: make(map[string]memCachedResponse)}
+}
+
+var _ ResponseCacheOperator = &MemResponseCacheOperator{}
+
+// Get implements ResponseCacheOperator interface
+func (o *MemResponseCacheOperator) Get(ctx context.Context, key string) (*lookout.EventResponse, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	r, ok := o.responses[key]
+	if !ok {
+		return nil, nil
+	}
+
+	if time.Now().After(r.expiresAt) {
+		delete(o.responses, key)
+		return nil, nil
+	}
+
+	var resp lookout.EventResponse
+	if err := resp.Unmarshal(r.response); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Save implements ResponseCacheOperator interface
+func (o *MemResponseCacheOperator) Save(ctx context.Context, key string, resp *lookout.EventResponse, expiresAt time.Time) error {
+	data, err := resp.Marshal()
+	if err != nil {
+		return err
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.responses[key] = memCachedResponse{response: data, expiresAt: expiresAt}
+	return nil
+}
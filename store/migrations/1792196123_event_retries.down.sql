@@ -0,0 +1,11 @@
+BEGIN;
+
+ALTER TABLE push_event DROP COLUMN attempts;
+
+ALTER TABLE push_event DROP COLUMN next_retry_at;
+
+ALTER TABLE review_event DROP COLUMN attempts;
+
+ALTER TABLE review_event DROP COLUMN next_retry_at;
+
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+
+ALTER TABLE push_event ADD COLUMN attempts bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE push_event ADD COLUMN next_retry_at timestamptz NOT NULL DEFAULT '1970-01-01 00:00:00+00';
+
+ALTER TABLE review_event ADD COLUMN attempts bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE review_event ADD COLUMN next_retry_at timestamptz NOT NULL DEFAULT '1970-01-01 00:00:00+00';
+
+COMMIT;
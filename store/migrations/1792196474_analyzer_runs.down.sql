@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE analyzer_run;
+
+COMMIT;
@@ -0,0 +1,21 @@
+BEGIN;
+
+CREATE TABLE analyzer_run (
+	id uuid NOT NULL PRIMARY KEY,
+	event_type bigint NOT NULL,
+	event_id text NOT NULL,
+	analyzer text NOT NULL,
+	version text NOT NULL,
+	started_at timestamptz NOT NULL,
+	finished_at timestamptz NOT NULL,
+	status_code bigint NOT NULL,
+	comments bigint NOT NULL
+);
+
+CREATE INDEX analyzer_run_event_idx
+	ON analyzer_run (event_id);
+
+CREATE INDEX analyzer_run_analyzer_started_at_idx
+	ON analyzer_run (analyzer, started_at);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE comment ADD COLUMN severity integer NOT NULL DEFAULT 0;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE cached_response;
+
+COMMIT;
@@ -0,0 +1,17 @@
+BEGIN;
+
+CREATE TABLE cached_response (
+	id uuid NOT NULL PRIMARY KEY,
+	cache_key text NOT NULL,
+	response bytea NOT NULL,
+	created_at timestamptz NOT NULL,
+	expires_at timestamptz NOT NULL
+);
+
+CREATE UNIQUE INDEX cached_response_cache_key_idx
+	ON cached_response (cache_key);
+
+CREATE INDEX cached_response_expires_at_idx
+	ON cached_response (expires_at);
+
+COMMIT;
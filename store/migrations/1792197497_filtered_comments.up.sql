@@ -0,0 +1,24 @@
+BEGIN;
+
+CREATE TABLE filtered_comment (
+	id uuid NOT NULL PRIMARY KEY,
+	created_at timestamptz NOT NULL,
+	updated_at timestamptz NOT NULL,
+	event_type bigint NOT NULL,
+	event_id text NOT NULL,
+	file text NOT NULL,
+	line integer NOT NULL,
+	text text NOT NULL,
+	confidence bigint NOT NULL,
+	severity integer NOT NULL,
+	analyzer text NOT NULL,
+	min_confidence bigint NOT NULL
+);
+
+CREATE INDEX filtered_comment_event_idx
+	ON filtered_comment (event_id);
+
+CREATE INDEX filtered_comment_analyzer_idx
+	ON filtered_comment (analyzer, confidence);
+
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+
+DROP TABLE comment_feedback;
+
+DROP INDEX comment_analyzer_rule_idx;
+
+ALTER TABLE comment DROP COLUMN rule;
+
+ALTER TABLE filtered_comment DROP COLUMN rule;
+
+COMMIT;
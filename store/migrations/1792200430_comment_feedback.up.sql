@@ -0,0 +1,25 @@
+BEGIN;
+
+CREATE TABLE comment_feedback (
+	id uuid NOT NULL PRIMARY KEY,
+	created_at timestamptz NOT NULL,
+	updated_at timestamptz NOT NULL,
+	comment_id uuid REFERENCES comment(id),
+	internal_id text NOT NULL,
+	thumbs_up bigint NOT NULL,
+	thumbs_down bigint NOT NULL,
+	confused bigint NOT NULL,
+	replies bigint NOT NULL
+);
+
+CREATE UNIQUE INDEX comment_feedback_comment_idx
+	ON comment_feedback (comment_id);
+
+ALTER TABLE comment ADD COLUMN rule text NOT NULL DEFAULT '';
+
+CREATE INDEX comment_analyzer_rule_idx
+	ON comment (analyzer, rule);
+
+ALTER TABLE filtered_comment ADD COLUMN rule text NOT NULL DEFAULT '';
+
+COMMIT;
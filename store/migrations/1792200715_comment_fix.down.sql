@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE comment DROP COLUMN fix;
+
+ALTER TABLE filtered_comment DROP COLUMN fix;
+
+COMMIT;
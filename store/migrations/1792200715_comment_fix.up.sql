@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE comment ADD COLUMN fix jsonb;
+
+ALTER TABLE filtered_comment ADD COLUMN fix jsonb;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE comment DROP COLUMN end_line;
+
+ALTER TABLE filtered_comment DROP COLUMN end_line;
+
+COMMIT;
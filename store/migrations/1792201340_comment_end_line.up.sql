@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE comment ADD COLUMN end_line integer NOT NULL DEFAULT 0;
+
+ALTER TABLE filtered_comment ADD COLUMN end_line integer NOT NULL DEFAULT 0;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE comment DROP COLUMN push_event_id;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE comment ADD COLUMN push_event_id uuid REFERENCES push_event(id);
+
+COMMIT;
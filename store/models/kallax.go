@@ -20,18 +20,2223 @@ var _ fmt.Formatter
 
 type modelSaveFunc func(*kallax.Store) error
 
+// NewAnalyzerRun returns a new instance of AnalyzerRun.
+func NewAnalyzerRun(e lookout.Event, analyzer string, startedAt time.Time) (record *AnalyzerRun) {
+	return newAnalyzerRun(e, analyzer, startedAt)
+}
+
+// GetID returns the primary key of the model.
+func (r *AnalyzerRun) GetID() kallax.Identifier {
+	return (*kallax.ULID)(&r.ID)
+}
+
+// ColumnAddress returns the pointer to the value of the given column.
+func (r *AnalyzerRun) ColumnAddress(col string) (interface{}, error) {
+	switch col {
+	case "id":
+		return (*kallax.ULID)(&r.ID), nil
+	case "event_type":
+		return (*int)(&r.EventType), nil
+	case "event_id":
+		return &r.EventID, nil
+	case "analyzer":
+		return &r.Analyzer, nil
+	case "version":
+		return &r.Version, nil
+	case "started_at":
+		return &r.StartedAt, nil
+	case "finished_at":
+		return &r.FinishedAt, nil
+	case "status_code":
+		return &r.StatusCode, nil
+	case "comments":
+		return &r.Comments, nil
+
+	default:
+		return nil, fmt.Errorf("kallax: invalid column in AnalyzerRun: %s", col)
+	}
+}
+
+// Value returns the value of the given column.
+func (r *AnalyzerRun) Value(col string) (interface{}, error) {
+	switch col {
+	case "id":
+		return r.ID, nil
+	case "event_type":
+		return (int)(r.EventType), nil
+	case "event_id":
+		return r.EventID, nil
+	case "analyzer":
+		return r.Analyzer, nil
+	case "version":
+		return r.Version, nil
+	case "started_at":
+		return r.StartedAt, nil
+	case "finished_at":
+		return r.FinishedAt, nil
+	case "status_code":
+		return r.StatusCode, nil
+	case "comments":
+		return r.Comments, nil
+
+	default:
+		return nil, fmt.Errorf("kallax: invalid column in AnalyzerRun: %s", col)
+	}
+}
+
+// NewRelationshipRecord returns a new record for the relatiobship in the given
+// field.
+func (r *AnalyzerRun) NewRelationshipRecord(field string) (kallax.Record, error) {
+	return nil, fmt.Errorf("kallax: model AnalyzerRun has no relationships")
+}
+
+// SetRelationship sets the given relationship in the given field.
+func (r *AnalyzerRun) SetRelationship(field string, rel interface{}) error {
+	return fmt.Errorf("kallax: model AnalyzerRun has no relationships")
+}
+
+// AnalyzerRunStore is the entity to access the records of the type AnalyzerRun
+// in the database.
+type AnalyzerRunStore struct {
+	*kallax.Store
+}
+
+// NewAnalyzerRunStore creates a new instance of AnalyzerRunStore
+// using a SQL database.
+func NewAnalyzerRunStore(db *sql.DB) *AnalyzerRunStore {
+	return &AnalyzerRunStore{kallax.NewStore(db)}
+}
+
+// GenericStore returns the generic store of this store.
+func (s *AnalyzerRunStore) GenericStore() *kallax.Store {
+	return s.Store
+}
+
+// SetGenericStore changes the generic store of this store.
+func (s *AnalyzerRunStore) SetGenericStore(store *kallax.Store) {
+	s.Store = store
+}
+
+// Debug returns a new store that will print all SQL statements to stdout using
+// the log.Printf function.
+func (s *AnalyzerRunStore) Debug() *AnalyzerRunStore {
+	return &AnalyzerRunStore{s.Store.Debug()}
+}
+
+// DebugWith returns a new store that will print all SQL statements using the
+// given logger function.
+func (s *AnalyzerRunStore) DebugWith(logger kallax.LoggerFunc) *AnalyzerRunStore {
+	return &AnalyzerRunStore{s.Store.DebugWith(logger)}
+}
+
+// DisableCacher turns off prepared statements, which can be useful in some scenarios.
+func (s *AnalyzerRunStore) DisableCacher() *AnalyzerRunStore {
+	return &AnalyzerRunStore{s.Store.DisableCacher()}
+}
+
+// Insert inserts a AnalyzerRun in the database. A non-persisted object is
+// required for this operation.
+func (s *AnalyzerRunStore) Insert(record *AnalyzerRun) error {
+	record.SetSaving(true)
+	defer record.SetSaving(false)
+
+	record.StartedAt = record.StartedAt.Truncate(time.Microsecond)
+	record.FinishedAt = record.FinishedAt.Truncate(time.Microsecond)
+
+	return s.Store.Insert(Schema.AnalyzerRun.BaseSchema, record)
+}
+
+// Update updates the given record on the database. If the columns are given,
+// only these columns will be updated. Otherwise all of them will be.
+// Be very careful with this, as you will have a potentially different object
+// in memory but not on the database.
+// Only writable records can be updated. Writable objects are those that have
+// been just inserted or retrieved using a query with no custom select fields.
+func (s *AnalyzerRunStore) Update(record *AnalyzerRun, cols ...kallax.SchemaField) (updated int64, err error) {
+	record.StartedAt = record.StartedAt.Truncate(time.Microsecond)
+	record.FinishedAt = record.FinishedAt.Truncate(time.Microsecond)
+
+	record.SetSaving(true)
+	defer record.SetSaving(false)
+
+	return s.Store.Update(Schema.AnalyzerRun.BaseSchema, record, cols...)
+}
+
+// Save inserts the object if the record is not persisted, otherwise it updates
+// it. Same rules of Update and Insert apply depending on the case.
+func (s *AnalyzerRunStore) Save(record *AnalyzerRun) (updated bool, err error) {
+	if !record.IsPersisted() {
+		return false, s.Insert(record)
+	}
+
+	rowsUpdated, err := s.Update(record)
+	if err != nil {
+		return false, err
+	}
+
+	return rowsUpdated > 0, nil
+}
+
+// Delete removes the given record from the database.
+func (s *AnalyzerRunStore) Delete(record *AnalyzerRun) error {
+	return s.Store.Delete(Schema.AnalyzerRun.BaseSchema, record)
+}
+
+// Find returns the set of results for the given query.
+func (s *AnalyzerRunStore) Find(q *AnalyzerRunQuery) (*AnalyzerRunResultSet, error) {
+	rs, err := s.Store.Find(q)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewAnalyzerRunResultSet(rs), nil
+}
+
+// MustFind returns the set of results for the given query, but panics if there
+// is any error.
+func (s *AnalyzerRunStore) MustFind(q *AnalyzerRunQuery) *AnalyzerRunResultSet {
+	return NewAnalyzerRunResultSet(s.Store.MustFind(q))
+}
+
+// Count returns the number of rows that would be retrieved with the given
+// query.
+func (s *AnalyzerRunStore) Count(q *AnalyzerRunQuery) (int64, error) {
+	return s.Store.Count(q)
+}
+
+// MustCount returns the number of rows that would be retrieved with the given
+// query, but panics if there is an error.
+func (s *AnalyzerRunStore) MustCount(q *AnalyzerRunQuery) int64 {
+	return s.Store.MustCount(q)
+}
+
+// FindOne returns the first row returned by the given query.
+// `ErrNotFound` is returned if there are no results.
+func (s *AnalyzerRunStore) FindOne(q *AnalyzerRunQuery) (*AnalyzerRun, error) {
+	q.Limit(1)
+	q.Offset(0)
+	rs, err := s.Find(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if !rs.Next() {
+		return nil, kallax.ErrNotFound
+	}
+
+	record, err := rs.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rs.Close(); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// FindAll returns a list of all the rows returned by the given query.
+func (s *AnalyzerRunStore) FindAll(q *AnalyzerRunQuery) ([]*AnalyzerRun, error) {
+	rs, err := s.Find(q)
+	if err != nil {
+		return nil, err
+	}
+
+	return rs.All()
+}
+
+// MustFindOne returns the first row retrieved by the given query. It panics
+// if there is an error or if there are no rows.
+func (s *AnalyzerRunStore) MustFindOne(q *AnalyzerRunQuery) *AnalyzerRun {
+	record, err := s.FindOne(q)
+	if err != nil {
+		panic(err)
+	}
+	return record
+}
+
+// Reload refreshes the AnalyzerRun with the data in the database and
+// makes it writable.
+func (s *AnalyzerRunStore) Reload(record *AnalyzerRun) error {
+	return s.Store.Reload(Schema.AnalyzerRun.BaseSchema, record)
+}
+
+// Transaction executes the given callback in a transaction and rollbacks if
+// an error is returned.
+// The transaction is only open in the store passed as a parameter to the
+// callback.
+func (s *AnalyzerRunStore) Transaction(callback func(*AnalyzerRunStore) error) error {
+	if callback == nil {
+		return kallax.ErrInvalidTxCallback
+	}
+
+	return s.Store.Transaction(func(store *kallax.Store) error {
+		return callback(&AnalyzerRunStore{store})
+	})
+}
+
+// AnalyzerRunQuery is the object used to create queries for the AnalyzerRun
+// entity.
+type AnalyzerRunQuery struct {
+	*kallax.BaseQuery
+}
+
+// NewAnalyzerRunQuery returns a new instance of AnalyzerRunQuery.
+func NewAnalyzerRunQuery() *AnalyzerRunQuery {
+	return &AnalyzerRunQuery{
+		BaseQuery: kallax.NewBaseQuery(Schema.AnalyzerRun.BaseSchema),
+	}
+}
+
+// Select adds columns to select in the query.
+func (q *AnalyzerRunQuery) Select(columns ...kallax.SchemaField) *AnalyzerRunQuery {
+	if len(columns) == 0 {
+		return q
+	}
+	q.BaseQuery.Select(columns...)
+	return q
+}
+
+// SelectNot excludes columns from being selected in the query.
+func (q *AnalyzerRunQuery) SelectNot(columns ...kallax.SchemaField) *AnalyzerRunQuery {
+	q.BaseQuery.SelectNot(columns...)
+	return q
+}
+
+// Copy returns a new identical copy of the query. Remember queries are mutable
+// so make a copy any time you need to reuse them.
+func (q *AnalyzerRunQuery) Copy() *AnalyzerRunQuery {
+	return &AnalyzerRunQuery{
+		BaseQuery: q.BaseQuery.Copy(),
+	}
+}
+
+// Order adds order clauses to the query for the given columns.
+func (q *AnalyzerRunQuery) Order(cols ...kallax.ColumnOrder) *AnalyzerRunQuery {
+	q.BaseQuery.Order(cols...)
+	return q
+}
+
+// BatchSize sets the number of items to fetch per batch when there are 1:N
+// relationships selected in the query.
+func (q *AnalyzerRunQuery) BatchSize(size uint64) *AnalyzerRunQuery {
+	q.BaseQuery.BatchSize(size)
+	return q
+}
+
+// Limit sets the max number of items to retrieve.
+func (q *AnalyzerRunQuery) Limit(n uint64) *AnalyzerRunQuery {
+	q.BaseQuery.Limit(n)
+	return q
+}
+
+// Offset sets the number of items to skip from the result set of items.
+func (q *AnalyzerRunQuery) Offset(n uint64) *AnalyzerRunQuery {
+	q.BaseQuery.Offset(n)
+	return q
+}
+
+// Where adds a condition to the query. All conditions added are concatenated
+// using a logical AND.
+func (q *AnalyzerRunQuery) Where(cond kallax.Condition) *AnalyzerRunQuery {
+	q.BaseQuery.Where(cond)
+	return q
+}
+
+// FindByID adds a new filter to the query that will require that
+// the ID property is equal to one of the passed values; if no passed values,
+// it will do nothing.
+func (q *AnalyzerRunQuery) FindByID(v ...kallax.ULID) *AnalyzerRunQuery {
+	if len(v) == 0 {
+		return q
+	}
+	values := make([]interface{}, len(v))
+	for i, val := range v {
+		values[i] = val
+	}
+	return q.Where(kallax.In(Schema.AnalyzerRun.ID, values...))
+}
+
+// FindByEventType adds a new filter to the query that will require that
+// the EventType property is equal to the passed value.
+func (q *AnalyzerRunQuery) FindByEventType(cond kallax.ScalarCond, v pb.EventType) *AnalyzerRunQuery {
+	return q.Where(cond(Schema.AnalyzerRun.EventType, v))
+}
+
+// FindByEventID adds a new filter to the query that will require that
+// the EventID property is equal to the passed value.
+func (q *AnalyzerRunQuery) FindByEventID(v string) *AnalyzerRunQuery {
+	return q.Where(kallax.Eq(Schema.AnalyzerRun.EventID, v))
+}
+
+// FindByAnalyzer adds a new filter to the query that will require that
+// the Analyzer property is equal to the passed value.
+func (q *AnalyzerRunQuery) FindByAnalyzer(v string) *AnalyzerRunQuery {
+	return q.Where(kallax.Eq(Schema.AnalyzerRun.Analyzer, v))
+}
+
+// FindByVersion adds a new filter to the query that will require that
+// the Version property is equal to the passed value.
+func (q *AnalyzerRunQuery) FindByVersion(v string) *AnalyzerRunQuery {
+	return q.Where(kallax.Eq(Schema.AnalyzerRun.Version, v))
+}
+
+// FindByStartedAt adds a new filter to the query that will require that
+// the StartedAt property is equal to the passed value.
+func (q *AnalyzerRunQuery) FindByStartedAt(cond kallax.ScalarCond, v time.Time) *AnalyzerRunQuery {
+	return q.Where(cond(Schema.AnalyzerRun.StartedAt, v))
+}
+
+// FindByFinishedAt adds a new filter to the query that will require that
+// the FinishedAt property is equal to the passed value.
+func (q *AnalyzerRunQuery) FindByFinishedAt(cond kallax.ScalarCond, v time.Time) *AnalyzerRunQuery {
+	return q.Where(cond(Schema.AnalyzerRun.FinishedAt, v))
+}
+
+// FindByStatusCode adds a new filter to the query that will require that
+// the StatusCode property is equal to the passed value.
+func (q *AnalyzerRunQuery) FindByStatusCode(cond kallax.ScalarCond, v uint32) *AnalyzerRunQuery {
+	return q.Where(cond(Schema.AnalyzerRun.StatusCode, v))
+}
+
+// FindByComments adds a new filter to the query that will require that
+// the Comments property is equal to the passed value.
+func (q *AnalyzerRunQuery) FindByComments(cond kallax.ScalarCond, v int) *AnalyzerRunQuery {
+	return q.Where(cond(Schema.AnalyzerRun.Comments, v))
+}
+
+// AnalyzerRunResultSet is the set of results returned by a query to the
+// database.
+type AnalyzerRunResultSet struct {
+	ResultSet kallax.ResultSet
+	last      *AnalyzerRun
+	lastErr   error
+}
+
+// NewAnalyzerRunResultSet creates a new result set for rows of the type
+// AnalyzerRun.
+func NewAnalyzerRunResultSet(rs kallax.ResultSet) *AnalyzerRunResultSet {
+	return &AnalyzerRunResultSet{ResultSet: rs}
+}
+
+// Next fetches the next item in the result set and returns true if there is
+// a next item.
+// The result set is closed automatically when there are no more items.
+func (rs *AnalyzerRunResultSet) Next() bool {
+	if !rs.ResultSet.Next() {
+		rs.lastErr = rs.ResultSet.Close()
+		rs.last = nil
+		return false
+	}
+
+	var record kallax.Record
+	record, rs.lastErr = rs.ResultSet.Get(Schema.AnalyzerRun.BaseSchema)
+	if rs.lastErr != nil {
+		rs.last = nil
+	} else {
+		var ok bool
+		rs.last, ok = record.(*AnalyzerRun)
+		if !ok {
+			rs.lastErr = fmt.Errorf("kallax: unable to convert record to *AnalyzerRun")
+			rs.last = nil
+		}
+	}
+
+	return true
+}
+
+// Get retrieves the last fetched item from the result set and the last error.
+func (rs *AnalyzerRunResultSet) Get() (*AnalyzerRun, error) {
+	return rs.last, rs.lastErr
+}
+
+// ForEach iterates over the complete result set passing every record found to
+// the given callback. It is possible to stop the iteration by returning
+// `kallax.ErrStop` in the callback.
+// Result set is always closed at the end.
+func (rs *AnalyzerRunResultSet) ForEach(fn func(*AnalyzerRun) error) error {
+	for rs.Next() {
+		record, err := rs.Get()
+		if err != nil {
+			return err
+		}
+
+		if err := fn(record); err != nil {
+			if err == kallax.ErrStop {
+				return rs.Close()
+			}
+
+			return err
+		}
+	}
+	return nil
+}
+
+// All returns all records on the result set and closes the result set.
+func (rs *AnalyzerRunResultSet) All() ([]*AnalyzerRun, error) {
+	var result []*AnalyzerRun
+	for rs.Next() {
+		record, err := rs.Get()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, record)
+	}
+	return result, nil
+}
+
+// One returns the first record on the result set and closes the result set.
+func (rs *AnalyzerRunResultSet) One() (*AnalyzerRun, error) {
+	if !rs.Next() {
+		return nil, kallax.ErrNotFound
+	}
+
+	record, err := rs.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rs.Close(); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// Err returns the last error occurred.
+func (rs *AnalyzerRunResultSet) Err() error {
+	return rs.lastErr
+}
+
+// Close closes the result set.
+func (rs *AnalyzerRunResultSet) Close() error {
+	return rs.ResultSet.Close()
+}
+
+// NewCachedResponse returns a new instance of CachedResponse.
+func NewCachedResponse(key string, response []byte, createdAt time.Time, expiresAt time.Time) (record *CachedResponse) {
+	return newCachedResponse(key, response, createdAt, expiresAt)
+}
+
+// GetID returns the primary key of the model.
+func (r *CachedResponse) GetID() kallax.Identifier {
+	return (*kallax.ULID)(&r.ID)
+}
+
+// ColumnAddress returns the pointer to the value of the given column.
+func (r *CachedResponse) ColumnAddress(col string) (interface{}, error) {
+	switch col {
+	case "id":
+		return (*kallax.ULID)(&r.ID), nil
+	case "cache_key":
+		return &r.CacheKey, nil
+	case "response":
+		return types.Slice(&r.Response), nil
+	case "created_at":
+		return &r.CreatedAt, nil
+	case "expires_at":
+		return &r.ExpiresAt, nil
+
+	default:
+		return nil, fmt.Errorf("kallax: invalid column in CachedResponse: %s", col)
+	}
+}
+
+// Value returns the value of the given column.
+func (r *CachedResponse) Value(col string) (interface{}, error) {
+	switch col {
+	case "id":
+		return r.ID, nil
+	case "cache_key":
+		return r.CacheKey, nil
+	case "response":
+		return types.Slice(r.Response), nil
+	case "created_at":
+		return r.CreatedAt, nil
+	case "expires_at":
+		return r.ExpiresAt, nil
+
+	default:
+		return nil, fmt.Errorf("kallax: invalid column in CachedResponse: %s", col)
+	}
+}
+
+// NewRelationshipRecord returns a new record for the relatiobship in the given
+// field.
+func (r *CachedResponse) NewRelationshipRecord(field string) (kallax.Record, error) {
+	return nil, fmt.Errorf("kallax: model CachedResponse has no relationships")
+}
+
+// SetRelationship sets the given relationship in the given field.
+func (r *CachedResponse) SetRelationship(field string, rel interface{}) error {
+	return fmt.Errorf("kallax: model CachedResponse has no relationships")
+}
+
+// CachedResponseStore is the entity to access the records of the type CachedResponse
+// in the database.
+type CachedResponseStore struct {
+	*kallax.Store
+}
+
+// NewCachedResponseStore creates a new instance of CachedResponseStore
+// using a SQL database.
+func NewCachedResponseStore(db *sql.DB) *CachedResponseStore {
+	return &CachedResponseStore{kallax.NewStore(db)}
+}
+
+// GenericStore returns the generic store of this store.
+func (s *CachedResponseStore) GenericStore() *kallax.Store {
+	return s.Store
+}
+
+// SetGenericStore changes the generic store of this store.
+func (s *CachedResponseStore) SetGenericStore(store *kallax.Store) {
+	s.Store = store
+}
+
+// Debug returns a new store that will print all SQL statements to stdout using
+// the log.Printf function.
+func (s *CachedResponseStore) Debug() *CachedResponseStore {
+	return &CachedResponseStore{s.Store.Debug()}
+}
+
+// DebugWith returns a new store that will print all SQL statements using the
+// given logger function.
+func (s *CachedResponseStore) DebugWith(logger kallax.LoggerFunc) *CachedResponseStore {
+	return &CachedResponseStore{s.Store.DebugWith(logger)}
+}
+
+// DisableCacher turns off prepared statements, which can be useful in some scenarios.
+func (s *CachedResponseStore) DisableCacher() *CachedResponseStore {
+	return &CachedResponseStore{s.Store.DisableCacher()}
+}
+
+// Insert inserts a CachedResponse in the database. A non-persisted object is
+// required for this operation.
+func (s *CachedResponseStore) Insert(record *CachedResponse) error {
+	record.SetSaving(true)
+	defer record.SetSaving(false)
+
+	record.CreatedAt = record.CreatedAt.Truncate(time.Microsecond)
+	record.ExpiresAt = record.ExpiresAt.Truncate(time.Microsecond)
+
+	return s.Store.Insert(Schema.CachedResponse.BaseSchema, record)
+}
+
+// Update updates the given record on the database. If the columns are given,
+// only these columns will be updated. Otherwise all of them will be.
+// Be very careful with this, as you will have a potentially different object
+// in memory but not on the database.
+// Only writable records can be updated. Writable objects are those that have
+// been just inserted or retrieved using a query with no custom select fields.
+func (s *CachedResponseStore) Update(record *CachedResponse, cols ...kallax.SchemaField) (updated int64, err error) {
+	record.CreatedAt = record.CreatedAt.Truncate(time.Microsecond)
+	record.ExpiresAt = record.ExpiresAt.Truncate(time.Microsecond)
+
+	record.SetSaving(true)
+	defer record.SetSaving(false)
+
+	return s.Store.Update(Schema.CachedResponse.BaseSchema, record, cols...)
+}
+
+// Save inserts the object if the record is not persisted, otherwise it updates
+// it. Same rules of Update and Insert apply depending on the case.
+func (s *CachedResponseStore) Save(record *CachedResponse) (updated bool, err error) {
+	if !record.IsPersisted() {
+		return false, s.Insert(record)
+	}
+
+	rowsUpdated, err := s.Update(record)
+	if err != nil {
+		return false, err
+	}
+
+	return rowsUpdated > 0, nil
+}
+
+// Delete removes the given record from the database.
+func (s *CachedResponseStore) Delete(record *CachedResponse) error {
+	return s.Store.Delete(Schema.CachedResponse.BaseSchema, record)
+}
+
+// Find returns the set of results for the given query.
+func (s *CachedResponseStore) Find(q *CachedResponseQuery) (*CachedResponseResultSet, error) {
+	rs, err := s.Store.Find(q)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCachedResponseResultSet(rs), nil
+}
+
+// MustFind returns the set of results for the given query, but panics if there
+// is any error.
+func (s *CachedResponseStore) MustFind(q *CachedResponseQuery) *CachedResponseResultSet {
+	return NewCachedResponseResultSet(s.Store.MustFind(q))
+}
+
+// Count returns the number of rows that would be retrieved with the given
+// query.
+func (s *CachedResponseStore) Count(q *CachedResponseQuery) (int64, error) {
+	return s.Store.Count(q)
+}
+
+// MustCount returns the number of rows that would be retrieved with the given
+// query, but panics if there is an error.
+func (s *CachedResponseStore) MustCount(q *CachedResponseQuery) int64 {
+	return s.Store.MustCount(q)
+}
+
+// FindOne returns the first row returned by the given query.
+// `ErrNotFound` is returned if there are no results.
+func (s *CachedResponseStore) FindOne(q *CachedResponseQuery) (*CachedResponse, error) {
+	q.Limit(1)
+	q.Offset(0)
+	rs, err := s.Find(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if !rs.Next() {
+		return nil, kallax.ErrNotFound
+	}
+
+	record, err := rs.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rs.Close(); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// FindAll returns a list of all the rows returned by the given query.
+func (s *CachedResponseStore) FindAll(q *CachedResponseQuery) ([]*CachedResponse, error) {
+	rs, err := s.Find(q)
+	if err != nil {
+		return nil, err
+	}
+
+	return rs.All()
+}
+
+// MustFindOne returns the first row retrieved by the given query. It panics
+// if there is an error or if there are no rows.
+func (s *CachedResponseStore) MustFindOne(q *CachedResponseQuery) *CachedResponse {
+	record, err := s.FindOne(q)
+	if err != nil {
+		panic(err)
+	}
+	return record
+}
+
+// Reload refreshes the CachedResponse with the data in the database and
+// makes it writable.
+func (s *CachedResponseStore) Reload(record *CachedResponse) error {
+	return s.Store.Reload(Schema.CachedResponse.BaseSchema, record)
+}
+
+// Transaction executes the given callback in a transaction and rollbacks if
+// an error is returned.
+// The transaction is only open in the store passed as a parameter to the
+// callback.
+func (s *CachedResponseStore) Transaction(callback func(*CachedResponseStore) error) error {
+	if callback == nil {
+		return kallax.ErrInvalidTxCallback
+	}
+
+	return s.Store.Transaction(func(store *kallax.Store) error {
+		return callback(&CachedResponseStore{store})
+	})
+}
+
+// CachedResponseQuery is the object used to create queries for the CachedResponse
+// entity.
+type CachedResponseQuery struct {
+	*kallax.BaseQuery
+}
+
+// NewCachedResponseQuery returns a new instance of CachedResponseQuery.
+func NewCachedResponseQuery() *CachedResponseQuery {
+	return &CachedResponseQuery{
+		BaseQuery: kallax.NewBaseQuery(Schema.CachedResponse.BaseSchema),
+	}
+}
+
+// Select adds columns to select in the query.
+func (q *CachedResponseQuery) Select(columns ...kallax.SchemaField) *CachedResponseQuery {
+	if len(columns) == 0 {
+		return q
+	}
+	q.BaseQuery.Select(columns...)
+	return q
+}
+
+// SelectNot excludes columns from being selected in the query.
+func (q *CachedResponseQuery) SelectNot(columns ...kallax.SchemaField) *CachedResponseQuery {
+	q.BaseQuery.SelectNot(columns...)
+	return q
+}
+
+// Copy returns a new identical copy of the query. Remember queries are mutable
+// so make a copy any time you need to reuse them.
+func (q *CachedResponseQuery) Copy() *CachedResponseQuery {
+	return &CachedResponseQuery{
+		BaseQuery: q.BaseQuery.Copy(),
+	}
+}
+
+// Order adds order clauses to the query for the given columns.
+func (q *CachedResponseQuery) Order(cols ...kallax.ColumnOrder) *CachedResponseQuery {
+	q.BaseQuery.Order(cols...)
+	return q
+}
+
+// BatchSize sets the number of items to fetch per batch when there are 1:N
+// relationships selected in the query.
+func (q *CachedResponseQuery) BatchSize(size uint64) *CachedResponseQuery {
+	q.BaseQuery.BatchSize(size)
+	return q
+}
+
+// Limit sets the max number of items to retrieve.
+func (q *CachedResponseQuery) Limit(n uint64) *CachedResponseQuery {
+	q.BaseQuery.Limit(n)
+	return q
+}
+
+// Offset sets the number of items to skip from the result set of items.
+func (q *CachedResponseQuery) Offset(n uint64) *CachedResponseQuery {
+	q.BaseQuery.Offset(n)
+	return q
+}
+
+// Where adds a condition to the query. All conditions added are concatenated
+// using a logical AND.
+func (q *CachedResponseQuery) Where(cond kallax.Condition) *CachedResponseQuery {
+	q.BaseQuery.Where(cond)
+	return q
+}
+
+// FindByID adds a new filter to the query that will require that
+// the ID property is equal to one of the passed values; if no passed values,
+// it will do nothing.
+func (q *CachedResponseQuery) FindByID(v ...kallax.ULID) *CachedResponseQuery {
+	if len(v) == 0 {
+		return q
+	}
+	values := make([]interface{}, len(v))
+	for i, val := range v {
+		values[i] = val
+	}
+	return q.Where(kallax.In(Schema.CachedResponse.ID, values...))
+}
+
+// FindByCacheKey adds a new filter to the query that will require that
+// the CacheKey property is equal to the passed value.
+func (q *CachedResponseQuery) FindByCacheKey(v string) *CachedResponseQuery {
+	return q.Where(kallax.Eq(Schema.CachedResponse.CacheKey, v))
+}
+
+// FindByResponse adds a new filter to the query that will require that
+// the Response property contains all the passed values; if no passed values,
+// it will do nothing.
+func (q *CachedResponseQuery) FindByResponse(v ...byte) *CachedResponseQuery {
+	if len(v) == 0 {
+		return q
+	}
+	values := make([]interface{}, len(v))
+	for i, val := range v {
+		values[i] = val
+	}
+	return q.Where(kallax.ArrayContains(Schema.CachedResponse.Response, values...))
+}
+
+// FindByCreatedAt adds a new filter to the query that will require that
+// the CreatedAt property is equal to the passed value.
+func (q *CachedResponseQuery) FindByCreatedAt(cond kallax.ScalarCond, v time.Time) *CachedResponseQuery {
+	return q.Where(cond(Schema.CachedResponse.CreatedAt, v))
+}
+
+// FindByExpiresAt adds a new filter to the query that will require that
+// the ExpiresAt property is equal to the passed value.
+func (q *CachedResponseQuery) FindByExpiresAt(cond kallax.ScalarCond, v time.Time) *CachedResponseQuery {
+	return q.Where(cond(Schema.CachedResponse.ExpiresAt, v))
+}
+
+// CachedResponseResultSet is the set of results returned by a query to the
+// database.
+type CachedResponseResultSet struct {
+	ResultSet kallax.ResultSet
+	last      *CachedResponse
+	lastErr   error
+}
+
+// NewCachedResponseResultSet creates a new result set for rows of the type
+// CachedResponse.
+func NewCachedResponseResultSet(rs kallax.ResultSet) *CachedResponseResultSet {
+	return &CachedResponseResultSet{ResultSet: rs}
+}
+
+// Next fetches the next item in the result set and returns true if there is
+// a next item.
+// The result set is closed automatically when there are no more items.
+func (rs *CachedResponseResultSet) Next() bool {
+	if !rs.ResultSet.Next() {
+		rs.lastErr = rs.ResultSet.Close()
+		rs.last = nil
+		return false
+	}
+
+	var record kallax.Record
+	record, rs.lastErr = rs.ResultSet.Get(Schema.CachedResponse.BaseSchema)
+	if rs.lastErr != nil {
+		rs.last = nil
+	} else {
+		var ok bool
+		rs.last, ok = record.(*CachedResponse)
+		if !ok {
+			rs.lastErr = fmt.Errorf("kallax: unable to convert record to *CachedResponse")
+			rs.last = nil
+		}
+	}
+
+	return true
+}
+
+// Get retrieves the last fetched item from the result set and the last error.
+func (rs *CachedResponseResultSet) Get() (*CachedResponse, error) {
+	return rs.last, rs.lastErr
+}
+
+// ForEach iterates over the complete result set passing every record found to
+// the given callback. It is possible to stop the iteration by returning
+// `kallax.ErrStop` in the callback.
+// Result set is always closed at the end.
+func (rs *CachedResponseResultSet) ForEach(fn func(*CachedResponse) error) error {
+	for rs.Next() {
+		record, err := rs.Get()
+		if err != nil {
+			return err
+		}
+
+		if err := fn(record); err != nil {
+			if err == kallax.ErrStop {
+				return rs.Close()
+			}
+
+			return err
+		}
+	}
+	return nil
+}
+
+// All returns all records on the result set and closes the result set.
+func (rs *CachedResponseResultSet) All() ([]*CachedResponse, error) {
+	var result []*CachedResponse
+	for rs.Next() {
+		record, err := rs.Get()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, record)
+	}
+	return result, nil
+}
+
+// One returns the first record on the result set and closes the result set.
+func (rs *CachedResponseResultSet) One() (*CachedResponse, error) {
+	if !rs.Next() {
+		return nil, kallax.ErrNotFound
+	}
+
+	record, err := rs.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rs.Close(); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// Err returns the last error occurred.
+func (rs *CachedResponseResultSet) Err() error {
+	return rs.lastErr
+}
+
+// Close closes the result set.
+func (rs *CachedResponseResultSet) Close() error {
+	return rs.ResultSet.Close()
+}
+
 // NewComment returns a new instance of Comment.
 func NewComment(r *ReviewEvent, c *pb.Comment) (record *Comment) {
 	return newComment(r, c)
 }
 
 // GetID returns the primary key of the model.
-func (r *Comment) GetID() kallax.Identifier {
+func (r *Comment) GetID() kallax.Identifier {
+	return (*kallax.ULID)(&r.ID)
+}
+
+// ColumnAddress returns the pointer to the value of the given column.
+func (r *Comment) ColumnAddress(col string) (interface{}, error) {
+	switch col {
+	case "id":
+		return (*kallax.ULID)(&r.ID), nil
+	case "created_at":
+		return &r.Timestamps.CreatedAt, nil
+	case "updated_at":
+		return &r.Timestamps.UpdatedAt, nil
+	case "review_event_id":
+		return types.Nullable(kallax.VirtualColumn("review_event_id", r, new(kallax.ULID))), nil
+	case "push_event_id":
+		return types.Nullable(kallax.VirtualColumn("push_event_id", r, new(kallax.ULID))), nil
+	case "file":
+		return &r.Comment.File, nil
+	case "line":
+		return &r.Comment.Line, nil
+	case "text":
+		return &r.Comment.Text, nil
+	case "confidence":
+		return &r.Comment.Confidence, nil
+	case "severity":
+		return (*int32)(&r.Comment.Severity), nil
+	case "rule":
+		return &r.Comment.Rule, nil
+	case "fix":
+		if r.Fix == nil {
+			r.Fix = new(pb.Fix)
+		}
+		return types.JSON(r.Comment.Fix), nil
+	case "end_line":
+		return &r.Comment.EndLine, nil
+	case "analyzer":
+		return &r.Analyzer, nil
+
+	default:
+		return nil, fmt.Errorf("kallax: invalid column in Comment: %s", col)
+	}
+}
+
+// Value returns the value of the given column.
+func (r *Comment) Value(col string) (interface{}, error) {
+	switch col {
+	case "id":
+		return r.ID, nil
+	case "created_at":
+		return r.Timestamps.CreatedAt, nil
+	case "updated_at":
+		return r.Timestamps.UpdatedAt, nil
+	case "review_event_id":
+		v := r.Model.VirtualColumn(col)
+		if v == nil {
+			return nil, kallax.ErrEmptyVirtualColumn
+		}
+		return v, nil
+	case "push_event_id":
+		v := r.Model.VirtualColumn(col)
+		if v == nil {
+			return nil, kallax.ErrEmptyVirtualColumn
+		}
+		return v, nil
+	case "file":
+		return r.Comment.File, nil
+	case "line":
+		return r.Comment.Line, nil
+	case "text":
+		return r.Comment.Text, nil
+	case "confidence":
+		return r.Comment.Confidence, nil
+	case "severity":
+		return (int32)(r.Comment.Severity), nil
+	case "rule":
+		return r.Comment.Rule, nil
+	case "fix":
+		if r.Comment.Fix == (*pb.Fix)(nil) {
+			return nil, nil
+		}
+		return types.JSON(r.Comment.Fix), nil
+	case "end_line":
+		return r.Comment.EndLine, nil
+	case "analyzer":
+		return r.Analyzer, nil
+
+	default:
+		return nil, fmt.Errorf("kallax: invalid column in Comment: %s", col)
+	}
+}
+
+// NewRelationshipRecord returns a new record for the relatiobship in the given
+// field.
+func (r *Comment) NewRelationshipRecord(field string) (kallax.Record, error) {
+	switch field {
+	case "ReviewEvent":
+		return new(ReviewEvent), nil
+	case "PushEvent":
+		return new(PushEvent), nil
+
+	}
+	return nil, fmt.Errorf("kallax: model Comment has no relationship %s", field)
+}
+
+// SetRelationship sets the given relationship in the given field.
+func (r *Comment) SetRelationship(field string, rel interface{}) error {
+	switch field {
+	case "ReviewEvent":
+		val, ok := rel.(*ReviewEvent)
+		if !ok {
+			return fmt.Errorf("kallax: record of type %t can't be assigned to relationship ReviewEvent", rel)
+		}
+		if !val.GetID().IsEmpty() {
+			r.ReviewEvent = val
+		}
+
+		return nil
+	case "PushEvent":
+		val, ok := rel.(*PushEvent)
+		if !ok {
+			return fmt.Errorf("kallax: record of type %t can't be assigned to relationship PushEvent", rel)
+		}
+		if !val.GetID().IsEmpty() {
+			r.PushEvent = val
+		}
+
+		return nil
+
+	}
+	return fmt.Errorf("kallax: model Comment has no relationship %s", field)
+}
+
+// CommentStore is the entity to access the records of the type Comment
+// in the database.
+type CommentStore struct {
+	*kallax.Store
+}
+
+// NewCommentStore creates a new instance of CommentStore
+// using a SQL database.
+func NewCommentStore(db *sql.DB) *CommentStore {
+	return &CommentStore{kallax.NewStore(db)}
+}
+
+// GenericStore returns the generic store of this store.
+func (s *CommentStore) GenericStore() *kallax.Store {
+	return s.Store
+}
+
+// SetGenericStore changes the generic store of this store.
+func (s *CommentStore) SetGenericStore(store *kallax.Store) {
+	s.Store = store
+}
+
+// Debug returns a new store that will print all SQL statements to stdout using
+// the log.Printf function.
+func (s *CommentStore) Debug() *CommentStore {
+	return &CommentStore{s.Store.Debug()}
+}
+
+// DebugWith returns a new store that will print all SQL statements using the
+// given logger function.
+func (s *CommentStore) DebugWith(logger kallax.LoggerFunc) *CommentStore {
+	return &CommentStore{s.Store.DebugWith(logger)}
+}
+
+// DisableCacher turns off prepared statements, which can be useful in some scenarios.
+func (s *CommentStore) DisableCacher() *CommentStore {
+	return &CommentStore{s.Store.DisableCacher()}
+}
+
+func (s *CommentStore) inverseRecords(record *Comment) []modelSaveFunc {
+	var result []modelSaveFunc
+
+	if record.ReviewEvent != nil && !record.ReviewEvent.IsSaving() {
+		record.AddVirtualColumn("review_event_id", record.ReviewEvent.GetID())
+		result = append(result, func(store *kallax.Store) error {
+			_, err := (&ReviewEventStore{store}).Save(record.ReviewEvent)
+			return err
+		})
+	}
+
+	if record.PushEvent != nil && !record.PushEvent.IsSaving() {
+		record.AddVirtualColumn("push_event_id", record.PushEvent.GetID())
+		result = append(result, func(store *kallax.Store) error {
+			_, err := (&PushEventStore{store}).Save(record.PushEvent)
+			return err
+		})
+	}
+
+	return result
+}
+
+// Insert inserts a Comment in the database. A non-persisted object is
+// required for this operation.
+func (s *CommentStore) Insert(record *Comment) error {
+	record.SetSaving(true)
+	defer record.SetSaving(false)
+
+	record.CreatedAt = record.CreatedAt.Truncate(time.Microsecond)
+	record.UpdatedAt = record.UpdatedAt.Truncate(time.Microsecond)
+
+	if err := record.BeforeSave(); err != nil {
+		return err
+	}
+
+	inverseRecords := s.inverseRecords(record)
+
+	if len(inverseRecords) > 0 {
+		return s.Store.Transaction(func(s *kallax.Store) error {
+			for _, r := range inverseRecords {
+				if err := r(s); err != nil {
+					return err
+				}
+			}
+
+			if err := s.Insert(Schema.Comment.BaseSchema, record); err != nil {
+				return err
+			}
+
+			return nil
+		})
+	}
+
+	return s.Store.Insert(Schema.Comment.BaseSchema, record)
+}
+
+// Update updates the given record on the database. If the columns are given,
+// only these columns will be updated. Otherwise all of them will be.
+// Be very careful with this, as you will have a potentially different object
+// in memory but not on the database.
+// Only writable records can be updated. Writable objects are those that have
+// been just inserted or retrieved using a query with no custom select fields.
+func (s *CommentStore) Update(record *Comment, cols ...kallax.SchemaField) (updated int64, err error) {
+	record.CreatedAt = record.CreatedAt.Truncate(time.Microsecond)
+	record.UpdatedAt = record.UpdatedAt.Truncate(time.Microsecond)
+
+	record.SetSaving(true)
+	defer record.SetSaving(false)
+
+	if err := record.BeforeSave(); err != nil {
+		return 0, err
+	}
+
+	inverseRecords := s.inverseRecords(record)
+
+	if len(inverseRecords) > 0 {
+		err = s.Store.Transaction(func(s *kallax.Store) error {
+			for _, r := range inverseRecords {
+				if err := r(s); err != nil {
+					return err
+				}
+			}
+
+			updated, err = s.Update(Schema.Comment.BaseSchema, record, cols...)
+			if err != nil {
+				return err
+			}
+
+			return nil
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		return updated, nil
+	}
+
+	return s.Store.Update(Schema.Comment.BaseSchema, record, cols...)
+}
+
+// Save inserts the object if the record is not persisted, otherwise it updates
+// it. Same rules of Update and Insert apply depending on the case.
+func (s *CommentStore) Save(record *Comment) (updated bool, err error) {
+	if !record.IsPersisted() {
+		return false, s.Insert(record)
+	}
+
+	rowsUpdated, err := s.Update(record)
+	if err != nil {
+		return false, err
+	}
+
+	return rowsUpdated > 0, nil
+}
+
+// Delete removes the given record from the database.
+func (s *CommentStore) Delete(record *Comment) error {
+	return s.Store.Delete(Schema.Comment.BaseSchema, record)
+}
+
+// Find returns the set of results for the given query.
+func (s *CommentStore) Find(q *CommentQuery) (*CommentResultSet, error) {
+	rs, err := s.Store.Find(q)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCommentResultSet(rs), nil
+}
+
+// MustFind returns the set of results for the given query, but panics if there
+// is any error.
+func (s *CommentStore) MustFind(q *CommentQuery) *CommentResultSet {
+	return NewCommentResultSet(s.Store.MustFind(q))
+}
+
+// Count returns the number of rows that would be retrieved with the given
+// query.
+func (s *CommentStore) Count(q *CommentQuery) (int64, error) {
+	return s.Store.Count(q)
+}
+
+// MustCount returns the number of rows that would be retrieved with the given
+// query, but panics if there is an error.
+func (s *CommentStore) MustCount(q *CommentQuery) int64 {
+	return s.Store.MustCount(q)
+}
+
+// FindOne returns the first row returned by the given query.
+// `ErrNotFound` is returned if there are no results.
+func (s *CommentStore) FindOne(q *CommentQuery) (*Comment, error) {
+	q.Limit(1)
+	q.Offset(0)
+	rs, err := s.Find(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if !rs.Next() {
+		return nil, kallax.ErrNotFound
+	}
+
+	record, err := rs.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rs.Close(); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// FindAll returns a list of all the rows returned by the given query.
+func (s *CommentStore) FindAll(q *CommentQuery) ([]*Comment, error) {
+	rs, err := s.Find(q)
+	if err != nil {
+		return nil, err
+	}
+
+	return rs.All()
+}
+
+// MustFindOne returns the first row retrieved by the given query. It panics
+// if there is an error or if there are no rows.
+func (s *CommentStore) MustFindOne(q *CommentQuery) *Comment {
+	record, err := s.FindOne(q)
+	if err != nil {
+		panic(err)
+	}
+	return record
+}
+
+// Reload refreshes the Comment with the data in the database and
+// makes it writable.
+func (s *CommentStore) Reload(record *Comment) error {
+	return s.Store.Reload(Schema.Comment.BaseSchema, record)
+}
+
+// Transaction executes the given callback in a transaction and rollbacks if
+// an error is returned.
+// The transaction is only open in the store passed as a parameter to the
+// callback.
+func (s *CommentStore) Transaction(callback func(*CommentStore) error) error {
+	if callback == nil {
+		return kallax.ErrInvalidTxCallback
+	}
+
+	return s.Store.Transaction(func(store *kallax.Store) error {
+		return callback(&CommentStore{store})
+	})
+}
+
+// CommentQuery is the object used to create queries for the Comment
+// entity.
+type CommentQuery struct {
+	*kallax.BaseQuery
+}
+
+// NewCommentQuery returns a new instance of CommentQuery.
+func NewCommentQuery() *CommentQuery {
+	return &CommentQuery{
+		BaseQuery: kallax.NewBaseQuery(Schema.Comment.BaseSchema),
+	}
+}
+
+// Select adds columns to select in the query.
+func (q *CommentQuery) Select(columns ...kallax.SchemaField) *CommentQuery {
+	if len(columns) == 0 {
+		return q
+	}
+	q.BaseQuery.Select(columns...)
+	return q
+}
+
+// SelectNot excludes columns from being selected in the query.
+func (q *CommentQuery) SelectNot(columns ...kallax.SchemaField) *CommentQuery {
+	q.BaseQuery.SelectNot(columns...)
+	return q
+}
+
+// Copy returns a new identical copy of the query. Remember queries are mutable
+// so make a copy any time you need to reuse them.
+func (q *CommentQuery) Copy() *CommentQuery {
+	return &CommentQuery{
+		BaseQuery: q.BaseQuery.Copy(),
+	}
+}
+
+// Order adds order clauses to the query for the given columns.
+func (q *CommentQuery) Order(cols ...kallax.ColumnOrder) *CommentQuery {
+	q.BaseQuery.Order(cols...)
+	return q
+}
+
+// BatchSize sets the number of items to fetch per batch when there are 1:N
+// relationships selected in the query.
+func (q *CommentQuery) BatchSize(size uint64) *CommentQuery {
+	q.BaseQuery.BatchSize(size)
+	return q
+}
+
+// Limit sets the max number of items to retrieve.
+func (q *CommentQuery) Limit(n uint64) *CommentQuery {
+	q.BaseQuery.Limit(n)
+	return q
+}
+
+// Offset sets the number of items to skip from the result set of items.
+func (q *CommentQuery) Offset(n uint64) *CommentQuery {
+	q.BaseQuery.Offset(n)
+	return q
+}
+
+// Where adds a condition to the query. All conditions added are concatenated
+// using a logical AND.
+func (q *CommentQuery) Where(cond kallax.Condition) *CommentQuery {
+	q.BaseQuery.Where(cond)
+	return q
+}
+
+func (q *CommentQuery) WithReviewEvent() *CommentQuery {
+	q.AddRelation(Schema.ReviewEvent.BaseSchema, "ReviewEvent", kallax.OneToOne, nil)
+	return q
+}
+
+func (q *CommentQuery) WithPushEvent() *CommentQuery {
+	q.AddRelation(Schema.PushEvent.BaseSchema, "PushEvent", kallax.OneToOne, nil)
+	return q
+}
+
+// FindByID adds a new filter to the query that will require that
+// the ID property is equal to one of the passed values; if no passed values,
+// it will do nothing.
+func (q *CommentQuery) FindByID(v ...kallax.ULID) *CommentQuery {
+	if len(v) == 0 {
+		return q
+	}
+	values := make([]interface{}, len(v))
+	for i, val := range v {
+		values[i] = val
+	}
+	return q.Where(kallax.In(Schema.Comment.ID, values...))
+}
+
+// FindByCreatedAt adds a new filter to the query that will require that
+// the CreatedAt property is equal to the passed value.
+func (q *CommentQuery) FindByCreatedAt(cond kallax.ScalarCond, v time.Time) *CommentQuery {
+	return q.Where(cond(Schema.Comment.CreatedAt, v))
+}
+
+// FindByUpdatedAt adds a new filter to the query that will require that
+// the UpdatedAt property is equal to the passed value.
+func (q *CommentQuery) FindByUpdatedAt(cond kallax.ScalarCond, v time.Time) *CommentQuery {
+	return q.Where(cond(Schema.Comment.UpdatedAt, v))
+}
+
+// FindByReviewEvent adds a new filter to the query that will require that
+// the foreign key of ReviewEvent is equal to the passed value.
+func (q *CommentQuery) FindByReviewEvent(v kallax.ULID) *CommentQuery {
+	return q.Where(kallax.Eq(Schema.Comment.ReviewEventFK, v))
+}
+
+// FindByPushEvent adds a new filter to the query that will require that
+// the foreign key of PushEvent is equal to the passed value.
+func (q *CommentQuery) FindByPushEvent(v kallax.ULID) *CommentQuery {
+	return q.Where(kallax.Eq(Schema.Comment.PushEventFK, v))
+}
+
+// FindByFile adds a new filter to the query that will require that
+// the File property is equal to the passed value.
+func (q *CommentQuery) FindByFile(v string) *CommentQuery {
+	return q.Where(kallax.Eq(Schema.Comment.File, v))
+}
+
+// FindByLine adds a new filter to the query that will require that
+// the Line property is equal to the passed value.
+func (q *CommentQuery) FindByLine(cond kallax.ScalarCond, v int32) *CommentQuery {
+	return q.Where(cond(Schema.Comment.Line, v))
+}
+
+// FindByText adds a new filter to the query that will require that
+// the Text property is equal to the passed value.
+func (q *CommentQuery) FindByText(v string) *CommentQuery {
+	return q.Where(kallax.Eq(Schema.Comment.Text, v))
+}
+
+// FindByConfidence adds a new filter to the query that will require that
+// the Confidence property is equal to the passed value.
+func (q *CommentQuery) FindByConfidence(cond kallax.ScalarCond, v uint32) *CommentQuery {
+	return q.Where(cond(Schema.Comment.Confidence, v))
+}
+
+// FindBySeverity adds a new filter to the query that will require that
+// the Severity property is equal to the passed value.
+func (q *CommentQuery) FindBySeverity(cond kallax.ScalarCond, v pb.Severity) *CommentQuery {
+	return q.Where(cond(Schema.Comment.Severity, v))
+}
+
+// FindByRule adds a new filter to the query that will require that
+// the Rule property is equal to the passed value.
+func (q *CommentQuery) FindByRule(v string) *CommentQuery {
+	return q.Where(kallax.Eq(Schema.Comment.Rule, v))
+}
+
+// FindByEndLine adds a new filter to the query that will require that
+// the EndLine property is equal to the passed value.
+func (q *CommentQuery) FindByEndLine(cond kallax.ScalarCond, v int32) *CommentQuery {
+	return q.Where(cond(Schema.Comment.EndLine, v))
+}
+
+// FindByAnalyzer adds a new filter to the query that will require that
+// the Analyzer property is equal to the passed value.
+func (q *CommentQuery) FindByAnalyzer(v string) *CommentQuery {
+	return q.Where(kallax.Eq(Schema.Comment.Analyzer, v))
+}
+
+// CommentResultSet is the set of results returned by a query to the
+// database.
+type CommentResultSet struct {
+	ResultSet kallax.ResultSet
+	last      *Comment
+	lastErr   error
+}
+
+// NewCommentResultSet creates a new result set for rows of the type
+// Comment.
+func NewCommentResultSet(rs kallax.ResultSet) *CommentResultSet {
+	return &CommentResultSet{ResultSet: rs}
+}
+
+// Next fetches the next item in the result set and returns true if there is
+// a next item.
+// The result set is closed automatically when there are no more items.
+func (rs *CommentResultSet) Next() bool {
+	if !rs.ResultSet.Next() {
+		rs.lastErr = rs.ResultSet.Close()
+		rs.last = nil
+		return false
+	}
+
+	var record kallax.Record
+	record, rs.lastErr = rs.ResultSet.Get(Schema.Comment.BaseSchema)
+	if rs.lastErr != nil {
+		rs.last = nil
+	} else {
+		var ok bool
+		rs.last, ok = record.(*Comment)
+		if !ok {
+			rs.lastErr = fmt.Errorf("kallax: unable to convert record to *Comment")
+			rs.last = nil
+		}
+	}
+
+	return true
+}
+
+// Get retrieves the last fetched item from the result set and the last error.
+func (rs *CommentResultSet) Get() (*Comment, error) {
+	return rs.last, rs.lastErr
+}
+
+// ForEach iterates over the complete result set passing every record found to
+// the given callback. It is possible to stop the iteration by returning
+// `kallax.ErrStop` in the callback.
+// Result set is always closed at the end.
+func (rs *CommentResultSet) ForEach(fn func(*Comment) error) error {
+	for rs.Next() {
+		record, err := rs.Get()
+		if err != nil {
+			return err
+		}
+
+		if err := fn(record); err != nil {
+			if err == kallax.ErrStop {
+				return rs.Close()
+			}
+
+			return err
+		}
+	}
+	return nil
+}
+
+// All returns all records on the result set and closes the result set.
+func (rs *CommentResultSet) All() ([]*Comment, error) {
+	var result []*Comment
+	for rs.Next() {
+		record, err := rs.Get()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, record)
+	}
+	return result, nil
+}
+
+// One returns the first record on the result set and closes the result set.
+func (rs *CommentResultSet) One() (*Comment, error) {
+	if !rs.Next() {
+		return nil, kallax.ErrNotFound
+	}
+
+	record, err := rs.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rs.Close(); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// Err returns the last error occurred.
+func (rs *CommentResultSet) Err() error {
+	return rs.lastErr
+}
+
+// Close closes the result set.
+func (rs *CommentResultSet) Close() error {
+	return rs.ResultSet.Close()
+}
+
+// NewCommentFeedback returns a new instance of CommentFeedback.
+func NewCommentFeedback(c *Comment, internalID string) (record *CommentFeedback) {
+	return newCommentFeedback(c, internalID)
+}
+
+// GetID returns the primary key of the model.
+func (r *CommentFeedback) GetID() kallax.Identifier {
+	return (*kallax.ULID)(&r.ID)
+}
+
+// ColumnAddress returns the pointer to the value of the given column.
+func (r *CommentFeedback) ColumnAddress(col string) (interface{}, error) {
+	switch col {
+	case "id":
+		return (*kallax.ULID)(&r.ID), nil
+	case "created_at":
+		return &r.Timestamps.CreatedAt, nil
+	case "updated_at":
+		return &r.Timestamps.UpdatedAt, nil
+	case "comment_id":
+		return types.Nullable(kallax.VirtualColumn("comment_id", r, new(kallax.ULID))), nil
+	case "internal_id":
+		return &r.InternalID, nil
+	case "thumbs_up":
+		return &r.ThumbsUp, nil
+	case "thumbs_down":
+		return &r.ThumbsDown, nil
+	case "confused":
+		return &r.Confused, nil
+	case "replies":
+		return &r.Replies, nil
+
+	default:
+		return nil, fmt.Errorf("kallax: invalid column in CommentFeedback: %s", col)
+	}
+}
+
+// Value returns the value of the given column.
+func (r *CommentFeedback) Value(col string) (interface{}, error) {
+	switch col {
+	case "id":
+		return r.ID, nil
+	case "created_at":
+		return r.Timestamps.CreatedAt, nil
+	case "updated_at":
+		return r.Timestamps.UpdatedAt, nil
+	case "comment_id":
+		v := r.Model.VirtualColumn(col)
+		if v == nil {
+			return nil, kallax.ErrEmptyVirtualColumn
+		}
+		return v, nil
+	case "internal_id":
+		return r.InternalID, nil
+	case "thumbs_up":
+		return r.ThumbsUp, nil
+	case "thumbs_down":
+		return r.ThumbsDown, nil
+	case "confused":
+		return r.Confused, nil
+	case "replies":
+		return r.Replies, nil
+
+	default:
+		return nil, fmt.Errorf("kallax: invalid column in CommentFeedback: %s", col)
+	}
+}
+
+// NewRelationshipRecord returns a new record for the relatiobship in the given
+// field.
+func (r *CommentFeedback) NewRelationshipRecord(field string) (kallax.Record, error) {
+	switch field {
+	case "Comment":
+		return new(Comment), nil
+
+	}
+	return nil, fmt.Errorf("kallax: model CommentFeedback has no relationship %s", field)
+}
+
+// SetRelationship sets the given relationship in the given field.
+func (r *CommentFeedback) SetRelationship(field string, rel interface{}) error {
+	switch field {
+	case "Comment":
+		val, ok := rel.(*Comment)
+		if !ok {
+			return fmt.Errorf("kallax: record of type %t can't be assigned to relationship Comment", rel)
+		}
+		if !val.GetID().IsEmpty() {
+			r.Comment = val
+		}
+
+		return nil
+
+	}
+	return fmt.Errorf("kallax: model CommentFeedback has no relationship %s", field)
+}
+
+// CommentFeedbackStore is the entity to access the records of the type CommentFeedback
+// in the database.
+type CommentFeedbackStore struct {
+	*kallax.Store
+}
+
+// NewCommentFeedbackStore creates a new instance of CommentFeedbackStore
+// using a SQL database.
+func NewCommentFeedbackStore(db *sql.DB) *CommentFeedbackStore {
+	return &CommentFeedbackStore{kallax.NewStore(db)}
+}
+
+// GenericStore returns the generic store of this store.
+func (s *CommentFeedbackStore) GenericStore() *kallax.Store {
+	return s.Store
+}
+
+// SetGenericStore changes the generic store of this store.
+func (s *CommentFeedbackStore) SetGenericStore(store *kallax.Store) {
+	s.Store = store
+}
+
+// Debug returns a new store that will print all SQL statements to stdout using
+// the log.Printf function.
+func (s *CommentFeedbackStore) Debug() *CommentFeedbackStore {
+	return &CommentFeedbackStore{s.Store.Debug()}
+}
+
+// DebugWith returns a new store that will print all SQL statements using the
+// given logger function.
+func (s *CommentFeedbackStore) DebugWith(logger kallax.LoggerFunc) *CommentFeedbackStore {
+	return &CommentFeedbackStore{s.Store.DebugWith(logger)}
+}
+
+// DisableCacher turns off prepared statements, which can be useful in some scenarios.
+func (s *CommentFeedbackStore) DisableCacher() *CommentFeedbackStore {
+	return &CommentFeedbackStore{s.Store.DisableCacher()}
+}
+
+func (s *CommentFeedbackStore) inverseRecords(record *CommentFeedback) []modelSaveFunc {
+	var result []modelSaveFunc
+
+	if record.Comment != nil && !record.Comment.IsSaving() {
+		record.AddVirtualColumn("comment_id", record.Comment.GetID())
+		result = append(result, func(store *kallax.Store) error {
+			_, err := (&CommentStore{store}).Save(record.Comment)
+			return err
+		})
+	}
+
+	return result
+}
+
+// Insert inserts a CommentFeedback in the database. A non-persisted object is
+// required for this operation.
+func (s *CommentFeedbackStore) Insert(record *CommentFeedback) error {
+	record.SetSaving(true)
+	defer record.SetSaving(false)
+
+	record.CreatedAt = record.CreatedAt.Truncate(time.Microsecond)
+	record.UpdatedAt = record.UpdatedAt.Truncate(time.Microsecond)
+
+	if err := record.BeforeSave(); err != nil {
+		return err
+	}
+
+	inverseRecords := s.inverseRecords(record)
+
+	if len(inverseRecords) > 0 {
+		return s.Store.Transaction(func(s *kallax.Store) error {
+			for _, r := range inverseRecords {
+				if err := r(s); err != nil {
+					return err
+				}
+			}
+
+			if err := s.Insert(Schema.CommentFeedback.BaseSchema, record); err != nil {
+				return err
+			}
+
+			return nil
+		})
+	}
+
+	return s.Store.Insert(Schema.CommentFeedback.BaseSchema, record)
+}
+
+// Update updates the given record on the database. If the columns are given,
+// only these columns will be updated. Otherwise all of them will be.
+// Be very careful with this, as you will have a potentially different object
+// in memory but not on the database.
+// Only writable records can be updated. Writable objects are those that have
+// been just inserted or retrieved using a query with no custom select fields.
+func (s *CommentFeedbackStore) Update(record *CommentFeedback, cols ...kallax.SchemaField) (updated int64, err error) {
+	record.CreatedAt = record.CreatedAt.Truncate(time.Microsecond)
+	record.UpdatedAt = record.UpdatedAt.Truncate(time.Microsecond)
+
+	record.SetSaving(true)
+	defer record.SetSaving(false)
+
+	if err := record.BeforeSave(); err != nil {
+		return 0, err
+	}
+
+	inverseRecords := s.inverseRecords(record)
+
+	if len(inverseRecords) > 0 {
+		err = s.Store.Transaction(func(s *kallax.Store) error {
+			for _, r := range inverseRecords {
+				if err := r(s); err != nil {
+					return err
+				}
+			}
+
+			updated, err = s.Update(Schema.CommentFeedback.BaseSchema, record, cols...)
+			if err != nil {
+				return err
+			}
+
+			return nil
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		return updated, nil
+	}
+
+	return s.Store.Update(Schema.CommentFeedback.BaseSchema, record, cols...)
+}
+
+// Save inserts the object if the record is not persisted, otherwise it updates
+// it. Same rules of Update and Insert apply depending on the case.
+func (s *CommentFeedbackStore) Save(record *CommentFeedback) (updated bool, err error) {
+	if !record.IsPersisted() {
+		return false, s.Insert(record)
+	}
+
+	rowsUpdated, err := s.Update(record)
+	if err != nil {
+		return false, err
+	}
+
+	return rowsUpdated > 0, nil
+}
+
+// Delete removes the given record from the database.
+func (s *CommentFeedbackStore) Delete(record *CommentFeedback) error {
+	return s.Store.Delete(Schema.CommentFeedback.BaseSchema, record)
+}
+
+// Find returns the set of results for the given query.
+func (s *CommentFeedbackStore) Find(q *CommentFeedbackQuery) (*CommentFeedbackResultSet, error) {
+	rs, err := s.Store.Find(q)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCommentFeedbackResultSet(rs), nil
+}
+
+// MustFind returns the set of results for the given query, but panics if there
+// is any error.
+func (s *CommentFeedbackStore) MustFind(q *CommentFeedbackQuery) *CommentFeedbackResultSet {
+	return NewCommentFeedbackResultSet(s.Store.MustFind(q))
+}
+
+// Count returns the number of rows that would be retrieved with the given
+// query.
+func (s *CommentFeedbackStore) Count(q *CommentFeedbackQuery) (int64, error) {
+	return s.Store.Count(q)
+}
+
+// MustCount returns the number of rows that would be retrieved with the given
+// query, but panics if there is an error.
+func (s *CommentFeedbackStore) MustCount(q *CommentFeedbackQuery) int64 {
+	return s.Store.MustCount(q)
+}
+
+// FindOne returns the first row returned by the given query.
+// `ErrNotFound` is returned if there are no results.
+func (s *CommentFeedbackStore) FindOne(q *CommentFeedbackQuery) (*CommentFeedback, error) {
+	q.Limit(1)
+	q.Offset(0)
+	rs, err := s.Find(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if !rs.Next() {
+		return nil, kallax.ErrNotFound
+	}
+
+	record, err := rs.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rs.Close(); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// FindAll returns a list of all the rows returned by the given query.
+func (s *CommentFeedbackStore) FindAll(q *CommentFeedbackQuery) ([]*CommentFeedback, error) {
+	rs, err := s.Find(q)
+	if err != nil {
+		return nil, err
+	}
+
+	return rs.All()
+}
+
+// MustFindOne returns the first row retrieved by the given query. It panics
+// if there is an error or if there are no rows.
+func (s *CommentFeedbackStore) MustFindOne(q *CommentFeedbackQuery) *CommentFeedback {
+	record, err := s.FindOne(q)
+	if err != nil {
+		panic(err)
+	}
+	return record
+}
+
+// Reload refreshes the CommentFeedback with the data in the database and
+// makes it writable.
+func (s *CommentFeedbackStore) Reload(record *CommentFeedback) error {
+	return s.Store.Reload(Schema.CommentFeedback.BaseSchema, record)
+}
+
+// Transaction executes the given callback in a transaction and rollbacks if
+// an error is returned.
+// The transaction is only open in the store passed as a parameter to the
+// callback.
+func (s *CommentFeedbackStore) Transaction(callback func(*CommentFeedbackStore) error) error {
+	if callback == nil {
+		return kallax.ErrInvalidTxCallback
+	}
+
+	return s.Store.Transaction(func(store *kallax.Store) error {
+		return callback(&CommentFeedbackStore{store})
+	})
+}
+
+// CommentFeedbackQuery is the object used to create queries for the CommentFeedback
+// entity.
+type CommentFeedbackQuery struct {
+	*kallax.BaseQuery
+}
+
+// NewCommentFeedbackQuery returns a new instance of CommentFeedbackQuery.
+func NewCommentFeedbackQuery() *CommentFeedbackQuery {
+	return &CommentFeedbackQuery{
+		BaseQuery: kallax.NewBaseQuery(Schema.CommentFeedback.BaseSchema),
+	}
+}
+
+// Select adds columns to select in the query.
+func (q *CommentFeedbackQuery) Select(columns ...kallax.SchemaField) *CommentFeedbackQuery {
+	if len(columns) == 0 {
+		return q
+	}
+	q.BaseQuery.Select(columns...)
+	return q
+}
+
+// SelectNot excludes columns from being selected in the query.
+func (q *CommentFeedbackQuery) SelectNot(columns ...kallax.SchemaField) *CommentFeedbackQuery {
+	q.BaseQuery.SelectNot(columns...)
+	return q
+}
+
+// Copy returns a new identical copy of the query. Remember queries are mutable
+// so make a copy any time you need to reuse them.
+func (q *CommentFeedbackQuery) Copy() *CommentFeedbackQuery {
+	return &CommentFeedbackQuery{
+		BaseQuery: q.BaseQuery.Copy(),
+	}
+}
+
+// Order adds order clauses to the query for the given columns.
+func (q *CommentFeedbackQuery) Order(cols ...kallax.ColumnOrder) *CommentFeedbackQuery {
+	q.BaseQuery.Order(cols...)
+	return q
+}
+
+// BatchSize sets the number of items to fetch per batch when there are 1:N
+// relationships selected in the query.
+func (q *CommentFeedbackQuery) BatchSize(size uint64) *CommentFeedbackQuery {
+	q.BaseQuery.BatchSize(size)
+	return q
+}
+
+// Limit sets the max number of items to retrieve.
+func (q *CommentFeedbackQuery) Limit(n uint64) *CommentFeedbackQuery {
+	q.BaseQuery.Limit(n)
+	return q
+}
+
+// Offset sets the number of items to skip from the result set of items.
+func (q *CommentFeedbackQuery) Offset(n uint64) *CommentFeedbackQuery {
+	q.BaseQuery.Offset(n)
+	return q
+}
+
+// Where adds a condition to the query. All conditions added are concatenated
+// using a logical AND.
+func (q *CommentFeedbackQuery) Where(cond kallax.Condition) *CommentFeedbackQuery {
+	q.BaseQuery.Where(cond)
+	return q
+}
+
+func (q *CommentFeedbackQuery) WithComment() *CommentFeedbackQuery {
+	q.AddRelation(Schema.Comment.BaseSchema, "Comment", kallax.OneToOne, nil)
+	return q
+}
+
+// FindByID adds a new filter to the query that will require that
+// the ID property is equal to one of the passed values; if no passed values,
+// it will do nothing.
+func (q *CommentFeedbackQuery) FindByID(v ...kallax.ULID) *CommentFeedbackQuery {
+	if len(v) == 0 {
+		return q
+	}
+	values := make([]interface{}, len(v))
+	for i, val := range v {
+		values[i] = val
+	}
+	return q.Where(kallax.In(Schema.CommentFeedback.ID, values...))
+}
+
+// FindByCreatedAt adds a new filter to the query that will require that
+// the CreatedAt property is equal to the passed value.
+func (q *CommentFeedbackQuery) FindByCreatedAt(cond kallax.ScalarCond, v time.Time) *CommentFeedbackQuery {
+	return q.Where(cond(Schema.CommentFeedback.CreatedAt, v))
+}
+
+// FindByUpdatedAt adds a new filter to the query that will require that
+// the UpdatedAt property is equal to the passed value.
+func (q *CommentFeedbackQuery) FindByUpdatedAt(cond kallax.ScalarCond, v time.Time) *CommentFeedbackQuery {
+	return q.Where(cond(Schema.CommentFeedback.UpdatedAt, v))
+}
+
+// FindByComment adds a new filter to the query that will require that
+// the foreign key of Comment is equal to the passed value.
+func (q *CommentFeedbackQuery) FindByComment(v kallax.ULID) *CommentFeedbackQuery {
+	return q.Where(kallax.Eq(Schema.CommentFeedback.CommentFK, v))
+}
+
+// FindByInternalID adds a new filter to the query that will require that
+// the InternalID property is equal to the passed value.
+func (q *CommentFeedbackQuery) FindByInternalID(v string) *CommentFeedbackQuery {
+	return q.Where(kallax.Eq(Schema.CommentFeedback.InternalID, v))
+}
+
+// FindByThumbsUp adds a new filter to the query that will require that
+// the ThumbsUp property is equal to the passed value.
+func (q *CommentFeedbackQuery) FindByThumbsUp(cond kallax.ScalarCond, v int) *CommentFeedbackQuery {
+	return q.Where(cond(Schema.CommentFeedback.ThumbsUp, v))
+}
+
+// FindByThumbsDown adds a new filter to the query that will require that
+// the ThumbsDown property is equal to the passed value.
+func (q *CommentFeedbackQuery) FindByThumbsDown(cond kallax.ScalarCond, v int) *CommentFeedbackQuery {
+	return q.Where(cond(Schema.CommentFeedback.ThumbsDown, v))
+}
+
+// FindByConfused adds a new filter to the query that will require that
+// the Confused property is equal to the passed value.
+func (q *CommentFeedbackQuery) FindByConfused(cond kallax.ScalarCond, v int) *CommentFeedbackQuery {
+	return q.Where(cond(Schema.CommentFeedback.Confused, v))
+}
+
+// FindByReplies adds a new filter to the query that will require that
+// the Replies property is equal to the passed value.
+func (q *CommentFeedbackQuery) FindByReplies(cond kallax.ScalarCond, v int) *CommentFeedbackQuery {
+	return q.Where(cond(Schema.CommentFeedback.Replies, v))
+}
+
+// CommentFeedbackResultSet is the set of results returned by a query to the
+// database.
+type CommentFeedbackResultSet struct {
+	ResultSet kallax.ResultSet
+	last      *CommentFeedback
+	lastErr   error
+}
+
+// NewCommentFeedbackResultSet creates a new result set for rows of the type
+// CommentFeedback.
+func NewCommentFeedbackResultSet(rs kallax.ResultSet) *CommentFeedbackResultSet {
+	return &CommentFeedbackResultSet{ResultSet: rs}
+}
+
+// Next fetches the next item in the result set and returns true if there is
+// a next item.
+// The result set is closed automatically when there are no more items.
+func (rs *CommentFeedbackResultSet) Next() bool {
+	if !rs.ResultSet.Next() {
+		rs.lastErr = rs.ResultSet.Close()
+		rs.last = nil
+		return false
+	}
+
+	var record kallax.Record
+	record, rs.lastErr = rs.ResultSet.Get(Schema.CommentFeedback.BaseSchema)
+	if rs.lastErr != nil {
+		rs.last = nil
+	} else {
+		var ok bool
+		rs.last, ok = record.(*CommentFeedback)
+		if !ok {
+			rs.lastErr = fmt.Errorf("kallax: unable to convert record to *CommentFeedback")
+			rs.last = nil
+		}
+	}
+
+	return true
+}
+
+// Get retrieves the last fetched item from the result set and the last error.
+func (rs *CommentFeedbackResultSet) Get() (*CommentFeedback, error) {
+	return rs.last, rs.lastErr
+}
+
+// ForEach iterates over the complete result set passing every record found to
+// the given callback. It is possible to stop the iteration by returning
+// `kallax.ErrStop` in the callback.
+// Result set is always closed at the end.
+func (rs *CommentFeedbackResultSet) ForEach(fn func(*CommentFeedback) error) error {
+	for rs.Next() {
+		record, err := rs.Get()
+		if err != nil {
+			return err
+		}
+
+		if err := fn(record); err != nil {
+			if err == kallax.ErrStop {
+				return rs.Close()
+			}
+
+			return err
+		}
+	}
+	return nil
+}
+
+// All returns all records on the result set and closes the result set.
+func (rs *CommentFeedbackResultSet) All() ([]*CommentFeedback, error) {
+	var result []*CommentFeedback
+	for rs.Next() {
+		record, err := rs.Get()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, record)
+	}
+	return result, nil
+}
+
+// One returns the first record on the result set and closes the result set.
+func (rs *CommentFeedbackResultSet) One() (*CommentFeedback, error) {
+	if !rs.Next() {
+		return nil, kallax.ErrNotFound
+	}
+
+	record, err := rs.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rs.Close(); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// Err returns the last error occurred.
+func (rs *CommentFeedbackResultSet) Err() error {
+	return rs.lastErr
+}
+
+// Close closes the result set.
+func (rs *CommentFeedbackResultSet) Close() error {
+	return rs.ResultSet.Close()
+}
+
+// NewFilteredComment returns a new instance of FilteredComment.
+func NewFilteredComment(e lookout.Event, c *pb.Comment, analyzer string, minConfidence uint32) (record *FilteredComment) {
+	return newFilteredComment(e, c, analyzer, minConfidence)
+}
+
+// GetID returns the primary key of the model.
+func (r *FilteredComment) GetID() kallax.Identifier {
 	return (*kallax.ULID)(&r.ID)
 }
 
 // ColumnAddress returns the pointer to the value of the given column.
-func (r *Comment) ColumnAddress(col string) (interface{}, error) {
+func (r *FilteredComment) ColumnAddress(col string) (interface{}, error) {
 	switch col {
 	case "id":
 		return (*kallax.ULID)(&r.ID), nil
@@ -39,8 +2244,10 @@ func (r *Comment) ColumnAddress(col string) (interface{}, error) {
 		return &r.Timestamps.CreatedAt, nil
 	case "updated_at":
 		return &r.Timestamps.UpdatedAt, nil
-	case "review_event_id":
-		return types.Nullable(kallax.VirtualColumn("review_event_id", r, new(kallax.ULID))), nil
+	case "event_type":
+		return (*int)(&r.EventType), nil
+	case "event_id":
+		return &r.EventID, nil
 	case "file":
 		return &r.Comment.File, nil
 	case "line":
@@ -49,16 +2256,29 @@ func (r *Comment) ColumnAddress(col string) (interface{}, error) {
 		return &r.Comment.Text, nil
 	case "confidence":
 		return &r.Comment.Confidence, nil
+	case "severity":
+		return (*int32)(&r.Comment.Severity), nil
+	case "rule":
+		return &r.Comment.Rule, nil
+	case "fix":
+		if r.Fix == nil {
+			r.Fix = new(pb.Fix)
+		}
+		return types.JSON(r.Comment.Fix), nil
+	case "end_line":
+		return &r.Comment.EndLine, nil
 	case "analyzer":
 		return &r.Analyzer, nil
+	case "min_confidence":
+		return &r.MinConfidence, nil
 
 	default:
-		return nil, fmt.Errorf("kallax: invalid column in Comment: %s", col)
+		return nil, fmt.Errorf("kallax: invalid column in FilteredComment: %s", col)
 	}
 }
 
 // Value returns the value of the given column.
-func (r *Comment) Value(col string) (interface{}, error) {
+func (r *FilteredComment) Value(col string) (interface{}, error) {
 	switch col {
 	case "id":
 		return r.ID, nil
@@ -66,12 +2286,10 @@ func (r *Comment) Value(col string) (interface{}, error) {
 		return r.Timestamps.CreatedAt, nil
 	case "updated_at":
 		return r.Timestamps.UpdatedAt, nil
-	case "review_event_id":
-		v := r.Model.VirtualColumn(col)
-		if v == nil {
-			return nil, kallax.ErrEmptyVirtualColumn
-		}
-		return v, nil
+	case "event_type":
+		return (int)(r.EventType), nil
+	case "event_id":
+		return r.EventID, nil
 	case "file":
 		return r.Comment.File, nil
 	case "line":
@@ -80,99 +2298,80 @@ func (r *Comment) Value(col string) (interface{}, error) {
 		return r.Comment.Text, nil
 	case "confidence":
 		return r.Comment.Confidence, nil
+	case "severity":
+		return (int32)(r.Comment.Severity), nil
+	case "rule":
+		return r.Comment.Rule, nil
+	case "fix":
+		if r.Comment.Fix == (*pb.Fix)(nil) {
+			return nil, nil
+		}
+		return types.JSON(r.Comment.Fix), nil
+	case "end_line":
+		return r.Comment.EndLine, nil
 	case "analyzer":
 		return r.Analyzer, nil
+	case "min_confidence":
+		return r.MinConfidence, nil
 
 	default:
-		return nil, fmt.Errorf("kallax: invalid column in Comment: %s", col)
-	}
-}
-
-// NewRelationshipRecord returns a new record for the relatiobship in the given
-// field.
-func (r *Comment) NewRelationshipRecord(field string) (kallax.Record, error) {
-	switch field {
-	case "ReviewEvent":
-		return new(ReviewEvent), nil
-
+		return nil, fmt.Errorf("kallax: invalid column in FilteredComment: %s", col)
 	}
-	return nil, fmt.Errorf("kallax: model Comment has no relationship %s", field)
 }
 
-// SetRelationship sets the given relationship in the given field.
-func (r *Comment) SetRelationship(field string, rel interface{}) error {
-	switch field {
-	case "ReviewEvent":
-		val, ok := rel.(*ReviewEvent)
-		if !ok {
-			return fmt.Errorf("kallax: record of type %t can't be assigned to relationship ReviewEvent", rel)
-		}
-		if !val.GetID().IsEmpty() {
-			r.ReviewEvent = val
-		}
-
-		return nil
+// NewRelationshipRecord returns a new record for the relatiobship in the given
+// field.
+func (r *FilteredComment) NewRelationshipRecord(field string) (kallax.Record, error) {
+	return nil, fmt.Errorf("kallax: model FilteredComment has no relationships")
+}
 
-	}
-	return fmt.Errorf("kallax: model Comment has no relationship %s", field)
+// SetRelationship sets the given relationship in the given field.
+func (r *FilteredComment) SetRelationship(field string, rel interface{}) error {
+	return fmt.Errorf("kallax: model FilteredComment has no relationships")
 }
 
-// CommentStore is the entity to access the records of the type Comment
+// FilteredCommentStore is the entity to access the records of the type FilteredComment
 // in the database.
-type CommentStore struct {
+type FilteredCommentStore struct {
 	*kallax.Store
 }
 
-// NewCommentStore creates a new instance of CommentStore
+// NewFilteredCommentStore creates a new instance of FilteredCommentStore
 // using a SQL database.
-func NewCommentStore(db *sql.DB) *CommentStore {
-	return &CommentStore{kallax.NewStore(db)}
+func NewFilteredCommentStore(db *sql.DB) *FilteredCommentStore {
+	return &FilteredCommentStore{kallax.NewStore(db)}
 }
 
 // GenericStore returns the generic store of this store.
-func (s *CommentStore) GenericStore() *kallax.Store {
+func (s *FilteredCommentStore) GenericStore() *kallax.Store {
 	return s.Store
 }
 
 // SetGenericStore changes the generic store of this store.
-func (s *CommentStore) SetGenericStore(store *kallax.Store) {
+func (s *FilteredCommentStore) SetGenericStore(store *kallax.Store) {
 	s.Store = store
 }
 
 // Debug returns a new store that will print all SQL statements to stdout using
 // the log.Printf function.
-func (s *CommentStore) Debug() *CommentStore {
-	return &CommentStore{s.Store.Debug()}
+func (s *FilteredCommentStore) Debug() *FilteredCommentStore {
+	return &FilteredCommentStore{s.Store.Debug()}
 }
 
 // DebugWith returns a new store that will print all SQL statements using the
 // given logger function.
-func (s *CommentStore) DebugWith(logger kallax.LoggerFunc) *CommentStore {
-	return &CommentStore{s.Store.DebugWith(logger)}
+func (s *FilteredCommentStore) DebugWith(logger kallax.LoggerFunc) *FilteredCommentStore {
+	return &FilteredCommentStore{s.Store.DebugWith(logger)}
 }
 
 // DisableCacher turns off prepared statements, which can be useful in some scenarios.
-func (s *CommentStore) DisableCacher() *CommentStore {
-	return &CommentStore{s.Store.DisableCacher()}
-}
-
-func (s *CommentStore) inverseRecords(record *Comment) []modelSaveFunc {
-	var result []modelSaveFunc
-
-	if record.ReviewEvent != nil && !record.ReviewEvent.IsSaving() {
-		record.AddVirtualColumn("review_event_id", record.ReviewEvent.GetID())
-		result = append(result, func(store *kallax.Store) error {
-			_, err := (&ReviewEventStore{store}).Save(record.ReviewEvent)
-			return err
-		})
-	}
-
-	return result
+func (s *FilteredCommentStore) DisableCacher() *FilteredCommentStore {
+	return &FilteredCommentStore{s.Store.DisableCacher()}
 }
 
-// Insert inserts a Comment in the database. A non-persisted object is
+// Insert inserts a FilteredComment in the database. A non-persisted object is
 // required for this operation.
-func (s *CommentStore) Insert(record *Comment) error {
+func (s *FilteredCommentStore) Insert(record *FilteredComment) error {
 	record.SetSaving(true)
 	defer record.SetSaving(false)
 
@@ -183,25 +2382,7 @@ func (s *CommentStore) Insert(record *Comment) error {
 		return err
 	}
 
-	inverseRecords := s.inverseRecords(record)
-
-	if len(inverseRecords) > 0 {
-		return s.Store.Transaction(func(s *kallax.Store) error {
-			for _, r := range inverseRecords {
-				if err := r(s); err != nil {
-					return err
-				}
-			}
-
-			if err := s.Insert(Schema.Comment.BaseSchema, record); err != nil {
-				return err
-			}
-
-			return nil
-		})
-	}
-
-	return s.Store.Insert(Schema.Comment.BaseSchema, record)
+	return s.Store.Insert(Schema.FilteredComment.BaseSchema, record)
 }
 
 // Update updates the given record on the database. If the columns are given,
@@ -210,7 +2391,7 @@ func (s *CommentStore) Insert(record *Comment) error {
 // in memory but not on the database.
 // Only writable records can be updated. Writable objects are those that have
 // been just inserted or retrieved using a query with no custom select fields.
-func (s *CommentStore) Update(record *Comment, cols ...kallax.SchemaField) (updated int64, err error) {
+func (s *FilteredCommentStore) Update(record *FilteredComment, cols ...kallax.SchemaField) (updated int64, err error) {
 	record.CreatedAt = record.CreatedAt.Truncate(time.Microsecond)
 	record.UpdatedAt = record.UpdatedAt.Truncate(time.Microsecond)
 
@@ -221,36 +2402,12 @@ func (s *CommentStore) Update(record *Comment, cols ...kallax.SchemaField) (upda
 		return 0, err
 	}
 
-	inverseRecords := s.inverseRecords(record)
-
-	if len(inverseRecords) > 0 {
-		err = s.Store.Transaction(func(s *kallax.Store) error {
-			for _, r := range inverseRecords {
-				if err := r(s); err != nil {
-					return err
-				}
-			}
-
-			updated, err = s.Update(Schema.Comment.BaseSchema, record, cols...)
-			if err != nil {
-				return err
-			}
-
-			return nil
-		})
-		if err != nil {
-			return 0, err
-		}
-
-		return updated, nil
-	}
-
-	return s.Store.Update(Schema.Comment.BaseSchema, record, cols...)
+	return s.Store.Update(Schema.FilteredComment.BaseSchema, record, cols...)
 }
 
 // Save inserts the object if the record is not persisted, otherwise it updates
 // it. Same rules of Update and Insert apply depending on the case.
-func (s *CommentStore) Save(record *Comment) (updated bool, err error) {
+func (s *FilteredCommentStore) Save(record *FilteredComment) (updated bool, err error) {
 	if !record.IsPersisted() {
 		return false, s.Insert(record)
 	}
@@ -264,41 +2421,41 @@ func (s *CommentStore) Save(record *Comment) (updated bool, err error) {
 }
 
 // Delete removes the given record from the database.
-func (s *CommentStore) Delete(record *Comment) error {
-	return s.Store.Delete(Schema.Comment.BaseSchema, record)
+func (s *FilteredCommentStore) Delete(record *FilteredComment) error {
+	return s.Store.Delete(Schema.FilteredComment.BaseSchema, record)
 }
 
 // Find returns the set of results for the given query.
-func (s *CommentStore) Find(q *CommentQuery) (*CommentResultSet, error) {
+func (s *FilteredCommentStore) Find(q *FilteredCommentQuery) (*FilteredCommentResultSet, error) {
 	rs, err := s.Store.Find(q)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewCommentResultSet(rs), nil
+	return NewFilteredCommentResultSet(rs), nil
 }
 
 // MustFind returns the set of results for the given query, but panics if there
 // is any error.
-func (s *CommentStore) MustFind(q *CommentQuery) *CommentResultSet {
-	return NewCommentResultSet(s.Store.MustFind(q))
+func (s *FilteredCommentStore) MustFind(q *FilteredCommentQuery) *FilteredCommentResultSet {
+	return NewFilteredCommentResultSet(s.Store.MustFind(q))
 }
 
 // Count returns the number of rows that would be retrieved with the given
 // query.
-func (s *CommentStore) Count(q *CommentQuery) (int64, error) {
+func (s *FilteredCommentStore) Count(q *FilteredCommentQuery) (int64, error) {
 	return s.Store.Count(q)
 }
 
 // MustCount returns the number of rows that would be retrieved with the given
 // query, but panics if there is an error.
-func (s *CommentStore) MustCount(q *CommentQuery) int64 {
+func (s *FilteredCommentStore) MustCount(q *FilteredCommentQuery) int64 {
 	return s.Store.MustCount(q)
 }
 
 // FindOne returns the first row returned by the given query.
 // `ErrNotFound` is returned if there are no results.
-func (s *CommentStore) FindOne(q *CommentQuery) (*Comment, error) {
+func (s *FilteredCommentStore) FindOne(q *FilteredCommentQuery) (*FilteredComment, error) {
 	q.Limit(1)
 	q.Offset(0)
 	rs, err := s.Find(q)
@@ -323,7 +2480,7 @@ func (s *CommentStore) FindOne(q *CommentQuery) (*Comment, error) {
 }
 
 // FindAll returns a list of all the rows returned by the given query.
-func (s *CommentStore) FindAll(q *CommentQuery) ([]*Comment, error) {
+func (s *FilteredCommentStore) FindAll(q *FilteredCommentQuery) ([]*FilteredComment, error) {
 	rs, err := s.Find(q)
 	if err != nil {
 		return nil, err
@@ -334,7 +2491,7 @@ func (s *CommentStore) FindAll(q *CommentQuery) ([]*Comment, error) {
 
 // MustFindOne returns the first row retrieved by the given query. It panics
 // if there is an error or if there are no rows.
-func (s *CommentStore) MustFindOne(q *CommentQuery) *Comment {
+func (s *FilteredCommentStore) MustFindOne(q *FilteredCommentQuery) *FilteredComment {
 	record, err := s.FindOne(q)
 	if err != nil {
 		panic(err)
@@ -342,41 +2499,41 @@ func (s *CommentStore) MustFindOne(q *CommentQuery) *Comment {
 	return record
 }
 
-// Reload refreshes the Comment with the data in the database and
+// Reload refreshes the FilteredComment with the data in the database and
 // makes it writable.
-func (s *CommentStore) Reload(record *Comment) error {
-	return s.Store.Reload(Schema.Comment.BaseSchema, record)
+func (s *FilteredCommentStore) Reload(record *FilteredComment) error {
+	return s.Store.Reload(Schema.FilteredComment.BaseSchema, record)
 }
 
 // Transaction executes the given callback in a transaction and rollbacks if
 // an error is returned.
 // The transaction is only open in the store passed as a parameter to the
 // callback.
-func (s *CommentStore) Transaction(callback func(*CommentStore) error) error {
+func (s *FilteredCommentStore) Transaction(callback func(*FilteredCommentStore) error) error {
 	if callback == nil {
 		return kallax.ErrInvalidTxCallback
 	}
 
 	return s.Store.Transaction(func(store *kallax.Store) error {
-		return callback(&CommentStore{store})
+		return callback(&FilteredCommentStore{store})
 	})
 }
 
-// CommentQuery is the object used to create queries for the Comment
+// FilteredCommentQuery is the object used to create queries for the FilteredComment
 // entity.
-type CommentQuery struct {
+type FilteredCommentQuery struct {
 	*kallax.BaseQuery
 }
 
-// NewCommentQuery returns a new instance of CommentQuery.
-func NewCommentQuery() *CommentQuery {
-	return &CommentQuery{
-		BaseQuery: kallax.NewBaseQuery(Schema.Comment.BaseSchema),
+// NewFilteredCommentQuery returns a new instance of FilteredCommentQuery.
+func NewFilteredCommentQuery() *FilteredCommentQuery {
+	return &FilteredCommentQuery{
+		BaseQuery: kallax.NewBaseQuery(Schema.FilteredComment.BaseSchema),
 	}
 }
 
 // Select adds columns to select in the query.
-func (q *CommentQuery) Select(columns ...kallax.SchemaField) *CommentQuery {
+func (q *FilteredCommentQuery) Select(columns ...kallax.SchemaField) *FilteredCommentQuery {
 	if len(columns) == 0 {
 		return q
 	}
@@ -385,60 +2542,55 @@ func (q *CommentQuery) Select(columns ...kallax.SchemaField) *CommentQuery {
 }
 
 // SelectNot excludes columns from being selected in the query.
-func (q *CommentQuery) SelectNot(columns ...kallax.SchemaField) *CommentQuery {
+func (q *FilteredCommentQuery) SelectNot(columns ...kallax.SchemaField) *FilteredCommentQuery {
 	q.BaseQuery.SelectNot(columns...)
 	return q
 }
 
 // Copy returns a new identical copy of the query. Remember queries are mutable
 // so make a copy any time you need to reuse them.
-func (q *CommentQuery) Copy() *CommentQuery {
-	return &CommentQuery{
+func (q *FilteredCommentQuery) Copy() *FilteredCommentQuery {
+	return &FilteredCommentQuery{
 		BaseQuery: q.BaseQuery.Copy(),
 	}
 }
 
 // Order adds order clauses to the query for the given columns.
-func (q *CommentQuery) Order(cols ...kallax.ColumnOrder) *CommentQuery {
+func (q *FilteredCommentQuery) Order(cols ...kallax.ColumnOrder) *FilteredCommentQuery {
 	q.BaseQuery.Order(cols...)
 	return q
 }
 
 // BatchSize sets the number of items to fetch per batch when there are 1:N
 // relationships selected in the query.
-func (q *CommentQuery) BatchSize(size uint64) *CommentQuery {
+func (q *FilteredCommentQuery) BatchSize(size uint64) *FilteredCommentQuery {
 	q.BaseQuery.BatchSize(size)
 	return q
 }
 
 // Limit sets the max number of items to retrieve.
-func (q *CommentQuery) Limit(n uint64) *CommentQuery {
+func (q *FilteredCommentQuery) Limit(n uint64) *FilteredCommentQuery {
 	q.BaseQuery.Limit(n)
 	return q
 }
 
 // Offset sets the number of items to skip from the result set of items.
-func (q *CommentQuery) Offset(n uint64) *CommentQuery {
+func (q *FilteredCommentQuery) Offset(n uint64) *FilteredCommentQuery {
 	q.BaseQuery.Offset(n)
 	return q
 }
 
 // Where adds a condition to the query. All conditions added are concatenated
 // using a logical AND.
-func (q *CommentQuery) Where(cond kallax.Condition) *CommentQuery {
+func (q *FilteredCommentQuery) Where(cond kallax.Condition) *FilteredCommentQuery {
 	q.BaseQuery.Where(cond)
 	return q
 }
 
-func (q *CommentQuery) WithReviewEvent() *CommentQuery {
-	q.AddRelation(Schema.ReviewEvent.BaseSchema, "ReviewEvent", kallax.OneToOne, nil)
-	return q
-}
-
 // FindByID adds a new filter to the query that will require that
 // the ID property is equal to one of the passed values; if no passed values,
 // it will do nothing.
-func (q *CommentQuery) FindByID(v ...kallax.ULID) *CommentQuery {
+func (q *FilteredCommentQuery) FindByID(v ...kallax.ULID) *FilteredCommentQuery {
 	if len(v) == 0 {
 		return q
 	}
@@ -446,75 +2598,105 @@ func (q *CommentQuery) FindByID(v ...kallax.ULID) *CommentQuery {
 	for i, val := range v {
 		values[i] = val
 	}
-	return q.Where(kallax.In(Schema.Comment.ID, values...))
+	return q.Where(kallax.In(Schema.FilteredComment.ID, values...))
 }
 
 // FindByCreatedAt adds a new filter to the query that will require that
 // the CreatedAt property is equal to the passed value.
-func (q *CommentQuery) FindByCreatedAt(cond kallax.ScalarCond, v time.Time) *CommentQuery {
-	return q.Where(cond(Schema.Comment.CreatedAt, v))
+func (q *FilteredCommentQuery) FindByCreatedAt(cond kallax.ScalarCond, v time.Time) *FilteredCommentQuery {
+	return q.Where(cond(Schema.FilteredComment.CreatedAt, v))
 }
 
 // FindByUpdatedAt adds a new filter to the query that will require that
 // the UpdatedAt property is equal to the passed value.
-func (q *CommentQuery) FindByUpdatedAt(cond kallax.ScalarCond, v time.Time) *CommentQuery {
-	return q.Where(cond(Schema.Comment.UpdatedAt, v))
+func (q *FilteredCommentQuery) FindByUpdatedAt(cond kallax.ScalarCond, v time.Time) *FilteredCommentQuery {
+	return q.Where(cond(Schema.FilteredComment.UpdatedAt, v))
 }
 
-// FindByReviewEvent adds a new filter to the query that will require that
-// the foreign key of ReviewEvent is equal to the passed value.
-func (q *CommentQuery) FindByReviewEvent(v kallax.ULID) *CommentQuery {
-	return q.Where(kallax.Eq(Schema.Comment.ReviewEventFK, v))
+// FindByEventType adds a new filter to the query that will require that
+// the EventType property is equal to the passed value.
+func (q *FilteredCommentQuery) FindByEventType(cond kallax.ScalarCond, v pb.EventType) *FilteredCommentQuery {
+	return q.Where(cond(Schema.FilteredComment.EventType, v))
+}
+
+// FindByEventID adds a new filter to the query that will require that
+// the EventID property is equal to the passed value.
+func (q *FilteredCommentQuery) FindByEventID(v string) *FilteredCommentQuery {
+	return q.Where(kallax.Eq(Schema.FilteredComment.EventID, v))
 }
 
 // FindByFile adds a new filter to the query that will require that
 // the File property is equal to the passed value.
-func (q *CommentQuery) FindByFile(v string) *CommentQuery {
-	return q.Where(kallax.Eq(Schema.Comment.File, v))
+func (q *FilteredCommentQuery) FindByFile(v string) *FilteredCommentQuery {
+	return q.Where(kallax.Eq(Schema.FilteredComment.File, v))
 }
 
 // FindByLine adds a new filter to the query that will require that
 // the Line property is equal to the passed value.
-func (q *CommentQuery) FindByLine(cond kallax.ScalarCond, v int32) *CommentQuery {
-	return q.Where(cond(Schema.Comment.Line, v))
+func (q *FilteredCommentQuery) FindByLine(cond kallax.ScalarCond, v int32) *FilteredCommentQuery {
+	return q.Where(cond(Schema.FilteredComment.Line, v))
 }
 
 // FindByText adds a new filter to the query that will require that
 // the Text property is equal to the passed value.
-func (q *CommentQuery) FindByText(v string) *CommentQuery {
-	return q.Where(kallax.Eq(Schema.Comment.Text, v))
+func (q *FilteredCommentQuery) FindByText(v string) *FilteredCommentQuery {
+	return q.Where(kallax.Eq(Schema.FilteredComment.Text, v))
 }
 
 // FindByConfidence adds a new filter to the query that will require that
 // the Confidence property is equal to the passed value.
-func (q *CommentQuery) FindByConfidence(cond kallax.ScalarCond, v uint32) *CommentQuery {
-	return q.Where(cond(Schema.Comment.Confidence, v))
+func (q *FilteredCommentQuery) FindByConfidence(cond kallax.ScalarCond, v uint32) *FilteredCommentQuery {
+	return q.Where(cond(Schema.FilteredComment.Confidence, v))
+}
+
+// FindBySeverity adds a new filter to the query that will require that
+// the Severity property is equal to the passed value.
+func (q *FilteredCommentQuery) FindBySeverity(cond kallax.ScalarCond, v pb.Severity) *FilteredCommentQuery {
+	return q.Where(cond(Schema.FilteredComment.Severity, v))
+}
+
+// FindByRule adds a new filter to the query that will require that
+// the Rule property is equal to the passed value.
+func (q *FilteredCommentQuery) FindByRule(v string) *FilteredCommentQuery {
+	return q.Where(kallax.Eq(Schema.FilteredComment.Rule, v))
+}
+
+// FindByEndLine adds a new filter to the query that will require that
+// the EndLine property is equal to the passed value.
+func (q *FilteredCommentQuery) FindByEndLine(cond kallax.ScalarCond, v int32) *FilteredCommentQuery {
+	return q.Where(cond(Schema.FilteredComment.EndLine, v))
 }
 
 // FindByAnalyzer adds a new filter to the query that will require that
 // the Analyzer property is equal to the passed value.
-func (q *CommentQuery) FindByAnalyzer(v string) *CommentQuery {
-	return q.Where(kallax.Eq(Schema.Comment.Analyzer, v))
+func (q *FilteredCommentQuery) FindByAnalyzer(v string) *FilteredCommentQuery {
+	return q.Where(kallax.Eq(Schema.FilteredComment.Analyzer, v))
 }
 
-// CommentResultSet is the set of results returned by a query to the
+// FindByMinConfidence adds a new filter to the query that will require that
+// the MinConfidence property is equal to the passed value.
+func (q *FilteredCommentQuery) FindByMinConfidence(cond kallax.ScalarCond, v uint32) *FilteredCommentQuery {
+	return q.Where(cond(Schema.FilteredComment.MinConfidence, v))
+}
+
+// FilteredCommentResultSet is the set of results returned by a query to the
 // database.
-type CommentResultSet struct {
+type FilteredCommentResultSet struct {
 	ResultSet kallax.ResultSet
-	last      *Comment
+	last      *FilteredComment
 	lastErr   error
 }
 
-// NewCommentResultSet creates a new result set for rows of the type
-// Comment.
-func NewCommentResultSet(rs kallax.ResultSet) *CommentResultSet {
-	return &CommentResultSet{ResultSet: rs}
+// NewFilteredCommentResultSet creates a new result set for rows of the type
+// FilteredComment.
+func NewFilteredCommentResultSet(rs kallax.ResultSet) *FilteredCommentResultSet {
+	return &FilteredCommentResultSet{ResultSet: rs}
 }
 
 // Next fetches the next item in the result set and returns true if there is
 // a next item.
 // The result set is closed automatically when there are no more items.
-func (rs *CommentResultSet) Next() bool {
+func (rs *FilteredCommentResultSet) Next() bool {
 	if !rs.ResultSet.Next() {
 		rs.lastErr = rs.ResultSet.Close()
 		rs.last = nil
@@ -522,14 +2704,14 @@ func (rs *CommentResultSet) Next() bool {
 	}
 
 	var record kallax.Record
-	record, rs.lastErr = rs.ResultSet.Get(Schema.Comment.BaseSchema)
+	record, rs.lastErr = rs.ResultSet.Get(Schema.FilteredComment.BaseSchema)
 	if rs.lastErr != nil {
 		rs.last = nil
 	} else {
 		var ok bool
-		rs.last, ok = record.(*Comment)
+		rs.last, ok = record.(*FilteredComment)
 		if !ok {
-			rs.lastErr = fmt.Errorf("kallax: unable to convert record to *Comment")
+			rs.lastErr = fmt.Errorf("kallax: unable to convert record to *FilteredComment")
 			rs.last = nil
 		}
 	}
@@ -538,7 +2720,7 @@ func (rs *CommentResultSet) Next() bool {
 }
 
 // Get retrieves the last fetched item from the result set and the last error.
-func (rs *CommentResultSet) Get() (*Comment, error) {
+func (rs *FilteredCommentResultSet) Get() (*FilteredComment, error) {
 	return rs.last, rs.lastErr
 }
 
@@ -546,7 +2728,7 @@ func (rs *CommentResultSet) Get() (*Comment, error) {
 // the given callback. It is possible to stop the iteration by returning
 // `kallax.ErrStop` in the callback.
 // Result set is always closed at the end.
-func (rs *CommentResultSet) ForEach(fn func(*Comment) error) error {
+func (rs *FilteredCommentResultSet) ForEach(fn func(*FilteredComment) error) error {
 	for rs.Next() {
 		record, err := rs.Get()
 		if err != nil {
@@ -565,8 +2747,8 @@ func (rs *CommentResultSet) ForEach(fn func(*Comment) error) error {
 }
 
 // All returns all records on the result set and closes the result set.
-func (rs *CommentResultSet) All() ([]*Comment, error) {
-	var result []*Comment
+func (rs *FilteredCommentResultSet) All() ([]*FilteredComment, error) {
+	var result []*FilteredComment
 	for rs.Next() {
 		record, err := rs.Get()
 		if err != nil {
@@ -578,7 +2760,7 @@ func (rs *CommentResultSet) All() ([]*Comment, error) {
 }
 
 // One returns the first record on the result set and closes the result set.
-func (rs *CommentResultSet) One() (*Comment, error) {
+func (rs *FilteredCommentResultSet) One() (*FilteredComment, error) {
 	if !rs.Next() {
 		return nil, kallax.ErrNotFound
 	}
@@ -596,12 +2778,12 @@ func (rs *CommentResultSet) One() (*Comment, error) {
 }
 
 // Err returns the last error occurred.
-func (rs *CommentResultSet) Err() error {
+func (rs *FilteredCommentResultSet) Err() error {
 	return rs.lastErr
 }
 
 // Close closes the result set.
-func (rs *CommentResultSet) Close() error {
+func (rs *FilteredCommentResultSet) Close() error {
 	return rs.ResultSet.Close()
 }
 
@@ -1060,6 +3242,10 @@ func (r *PushEvent) ColumnAddress(col string) (interface{}, error) {
 		return (*kallax.ULID)(&r.ID), nil
 	case "status":
 		return (*string)(&r.Status), nil
+	case "attempts":
+		return &r.Attempts, nil
+	case "next_retry_at":
+		return &r.NextRetryAt, nil
 	case "provider":
 		return &r.PushEvent.PushEvent.Provider, nil
 	case "internal_id":
@@ -1091,6 +3277,10 @@ func (r *PushEvent) Value(col string) (interface{}, error) {
 		return r.ID, nil
 	case "status":
 		return (string)(r.Status), nil
+	case "attempts":
+		return r.Attempts, nil
+	case "next_retry_at":
+		return r.NextRetryAt, nil
 	case "provider":
 		return r.PushEvent.PushEvent.Provider, nil
 	case "internal_id":
@@ -1171,6 +3361,7 @@ func (s *PushEventStore) Insert(record *PushEvent) error {
 	record.SetSaving(true)
 	defer record.SetSaving(false)
 
+	record.NextRetryAt = record.NextRetryAt.Truncate(time.Microsecond)
 	record.CreatedAt = record.CreatedAt.Truncate(time.Microsecond)
 
 	return s.Store.Insert(Schema.PushEvent.BaseSchema, record)
@@ -1183,6 +3374,7 @@ func (s *PushEventStore) Insert(record *PushEvent) error {
 // Only writable records can be updated. Writable objects are those that have
 // been just inserted or retrieved using a query with no custom select fields.
 func (s *PushEventStore) Update(record *PushEvent, cols ...kallax.SchemaField) (updated int64, err error) {
+	record.NextRetryAt = record.NextRetryAt.Truncate(time.Microsecond)
 	record.CreatedAt = record.CreatedAt.Truncate(time.Microsecond)
 
 	record.SetSaving(true)
@@ -1393,6 +3585,18 @@ func (q *PushEventQuery) FindByStatus(v EventStatus) *PushEventQuery {
 	return q.Where(kallax.Eq(Schema.PushEvent.Status, v))
 }
 
+// FindByAttempts adds a new filter to the query that will require that
+// the Attempts property is equal to the passed value.
+func (q *PushEventQuery) FindByAttempts(cond kallax.ScalarCond, v int) *PushEventQuery {
+	return q.Where(cond(Schema.PushEvent.Attempts, v))
+}
+
+// FindByNextRetryAt adds a new filter to the query that will require that
+// the NextRetryAt property is equal to the passed value.
+func (q *PushEventQuery) FindByNextRetryAt(cond kallax.ScalarCond, v time.Time) *PushEventQuery {
+	return q.Where(cond(Schema.PushEvent.NextRetryAt, v))
+}
+
 // FindByProvider adds a new filter to the query that will require that
 // the Provider property is equal to the passed value.
 func (q *PushEventQuery) FindByProvider(v string) *PushEventQuery {
@@ -1556,6 +3760,10 @@ func (r *ReviewEvent) ColumnAddress(col string) (interface{}, error) {
 		return (*string)(&r.Status), nil
 	case "internal_id":
 		return &r.InternalID, nil
+	case "attempts":
+		return &r.Attempts, nil
+	case "next_retry_at":
+		return &r.NextRetryAt, nil
 	case "is_mergeable":
 		return &r.IsMergeable, nil
 	case "source":
@@ -1587,6 +3795,10 @@ func (r *ReviewEvent) Value(col string) (interface{}, error) {
 		return (string)(r.Status), nil
 	case "internal_id":
 		return r.InternalID, nil
+	case "attempts":
+		return r.Attempts, nil
+	case "next_retry_at":
+		return r.NextRetryAt, nil
 	case "is_mergeable":
 		return r.IsMergeable, nil
 	case "source":
@@ -1701,6 +3913,7 @@ func (s *ReviewEventStore) Insert(record *ReviewEvent) error {
 	record.SetSaving(true)
 	defer record.SetSaving(false)
 
+	record.NextRetryAt = record.NextRetryAt.Truncate(time.Microsecond)
 	record.CreatedAt = record.CreatedAt.Truncate(time.Microsecond)
 	record.UpdatedAt = record.UpdatedAt.Truncate(time.Microsecond)
 
@@ -1732,6 +3945,7 @@ func (s *ReviewEventStore) Insert(record *ReviewEvent) error {
 // Only writable records can be updated. Writable objects are those that have
 // been just inserted or retrieved using a query with no custom select fields.
 func (s *ReviewEventStore) Update(record *ReviewEvent, cols ...kallax.SchemaField) (updated int64, err error) {
+	record.NextRetryAt = record.NextRetryAt.Truncate(time.Microsecond)
 	record.CreatedAt = record.CreatedAt.Truncate(time.Microsecond)
 	record.UpdatedAt = record.UpdatedAt.Truncate(time.Microsecond)
 
@@ -1978,6 +4192,18 @@ func (q *ReviewEventQuery) FindByInternalID(v string) *ReviewEventQuery {
 	return q.Where(kallax.Eq(Schema.ReviewEvent.InternalID, v))
 }
 
+// FindByAttempts adds a new filter to the query that will require that
+// the Attempts property is equal to the passed value.
+func (q *ReviewEventQuery) FindByAttempts(cond kallax.ScalarCond, v int) *ReviewEventQuery {
+	return q.Where(cond(Schema.ReviewEvent.Attempts, v))
+}
+
+// FindByNextRetryAt adds a new filter to the query that will require that
+// the NextRetryAt property is equal to the passed value.
+func (q *ReviewEventQuery) FindByNextRetryAt(cond kallax.ScalarCond, v time.Time) *ReviewEventQuery {
+	return q.Where(cond(Schema.ReviewEvent.NextRetryAt, v))
+}
+
 // FindByIsMergeable adds a new filter to the query that will require that
 // the IsMergeable property is equal to the passed value.
 func (q *ReviewEventQuery) FindByIsMergeable(v bool) *ReviewEventQuery {
@@ -2593,11 +4819,37 @@ func (rs *ReviewTargetResultSet) Close() error {
 }
 
 type schema struct {
-	Comment      *schemaComment
-	Organization *schemaOrganization
-	PushEvent    *schemaPushEvent
-	ReviewEvent  *schemaReviewEvent
-	ReviewTarget *schemaReviewTarget
+	AnalyzerRun     *schemaAnalyzerRun
+	CachedResponse  *schemaCachedResponse
+	Comment         *schemaComment
+	CommentFeedback *schemaCommentFeedback
+	FilteredComment *schemaFilteredComment
+	Organization    *schemaOrganization
+	PushEvent       *schemaPushEvent
+	ReviewEvent     *schemaReviewEvent
+	ReviewTarget    *schemaReviewTarget
+}
+
+type schemaAnalyzerRun struct {
+	*kallax.BaseSchema
+	ID         kallax.SchemaField
+	EventType  kallax.SchemaField
+	EventID    kallax.SchemaField
+	Analyzer   kallax.SchemaField
+	Version    kallax.SchemaField
+	StartedAt  kallax.SchemaField
+	FinishedAt kallax.SchemaField
+	StatusCode kallax.SchemaField
+	Comments   kallax.SchemaField
+}
+
+type schemaCachedResponse struct {
+	*kallax.BaseSchema
+	ID        kallax.SchemaField
+	CacheKey  kallax.SchemaField
+	Response  kallax.SchemaField
+	CreatedAt kallax.SchemaField
+	ExpiresAt kallax.SchemaField
 }
 
 type schemaComment struct {
@@ -2606,11 +4858,48 @@ type schemaComment struct {
 	CreatedAt     kallax.SchemaField
 	UpdatedAt     kallax.SchemaField
 	ReviewEventFK kallax.SchemaField
+	PushEventFK   kallax.SchemaField
+	File          kallax.SchemaField
+	Line          kallax.SchemaField
+	Text          kallax.SchemaField
+	Confidence    kallax.SchemaField
+	Severity      kallax.SchemaField
+	Rule          kallax.SchemaField
+	Fix           *schemaCommentFix
+	EndLine       kallax.SchemaField
+	Analyzer      kallax.SchemaField
+}
+
+type schemaCommentFeedback struct {
+	*kallax.BaseSchema
+	ID         kallax.SchemaField
+	CreatedAt  kallax.SchemaField
+	UpdatedAt  kallax.SchemaField
+	CommentFK  kallax.SchemaField
+	InternalID kallax.SchemaField
+	ThumbsUp   kallax.SchemaField
+	ThumbsDown kallax.SchemaField
+	Confused   kallax.SchemaField
+	Replies    kallax.SchemaField
+}
+
+type schemaFilteredComment struct {
+	*kallax.BaseSchema
+	ID            kallax.SchemaField
+	CreatedAt     kallax.SchemaField
+	UpdatedAt     kallax.SchemaField
+	EventType     kallax.SchemaField
+	EventID       kallax.SchemaField
 	File          kallax.SchemaField
 	Line          kallax.SchemaField
 	Text          kallax.SchemaField
 	Confidence    kallax.SchemaField
+	Severity      kallax.SchemaField
+	Rule          kallax.SchemaField
+	Fix           *schemaFilteredCommentFix
+	EndLine       kallax.SchemaField
 	Analyzer      kallax.SchemaField
+	MinConfidence kallax.SchemaField
 }
 
 type schemaOrganization struct {
@@ -2625,6 +4914,8 @@ type schemaPushEvent struct {
 	*kallax.BaseSchema
 	ID              kallax.SchemaField
 	Status          kallax.SchemaField
+	Attempts        kallax.SchemaField
+	NextRetryAt     kallax.SchemaField
 	Provider        kallax.SchemaField
 	InternalID      kallax.SchemaField
 	CreatedAt       kallax.SchemaField
@@ -2641,6 +4932,8 @@ type schemaReviewEvent struct {
 	ID             kallax.SchemaField
 	Status         kallax.SchemaField
 	InternalID     kallax.SchemaField
+	Attempts       kallax.SchemaField
+	NextRetryAt    kallax.SchemaField
 	IsMergeable    kallax.SchemaField
 	Source         *schemaReviewEventSource
 	Configuration  *schemaReviewEventConfiguration
@@ -2662,6 +4955,20 @@ type schemaReviewTarget struct {
 	Number       kallax.SchemaField
 }
 
+type schemaCommentFix struct {
+	*kallax.BaseSchemaField
+	StartLine kallax.SchemaField
+	EndLine   kallax.SchemaField
+	Text      kallax.SchemaField
+}
+
+type schemaFilteredCommentFix struct {
+	*kallax.BaseSchemaField
+	StartLine kallax.SchemaField
+	EndLine   kallax.SchemaField
+	Text      kallax.SchemaField
+}
+
 type schemaPushEventBase struct {
 	*kallax.BaseSchemaField
 	InternalRepositoryURL kallax.SchemaField
@@ -2714,6 +5021,58 @@ type schemaReviewEventSource struct {
 }
 
 var Schema = &schema{
+	AnalyzerRun: &schemaAnalyzerRun{
+		BaseSchema: kallax.NewBaseSchema(
+			"analyzer_run",
+			"__analyzerrun",
+			kallax.NewSchemaField("id"),
+			kallax.ForeignKeys{},
+			func() kallax.Record {
+				return new(AnalyzerRun)
+			},
+			false,
+			kallax.NewSchemaField("id"),
+			kallax.NewSchemaField("event_type"),
+			kallax.NewSchemaField("event_id"),
+			kallax.NewSchemaField("analyzer"),
+			kallax.NewSchemaField("version"),
+			kallax.NewSchemaField("started_at"),
+			kallax.NewSchemaField("finished_at"),
+			kallax.NewSchemaField("status_code"),
+			kallax.NewSchemaField("comments"),
+		),
+		ID:         kallax.NewSchemaField("id"),
+		EventType:  kallax.NewSchemaField("event_type"),
+		EventID:    kallax.NewSchemaField("event_id"),
+		Analyzer:   kallax.NewSchemaField("analyzer"),
+		Version:    kallax.NewSchemaField("version"),
+		StartedAt:  kallax.NewSchemaField("started_at"),
+		FinishedAt: kallax.NewSchemaField("finished_at"),
+		StatusCode: kallax.NewSchemaField("status_code"),
+		Comments:   kallax.NewSchemaField("comments"),
+	},
+	CachedResponse: &schemaCachedResponse{
+		BaseSchema: kallax.NewBaseSchema(
+			"cached_response",
+			"__cachedresponse",
+			kallax.NewSchemaField("id"),
+			kallax.ForeignKeys{},
+			func() kallax.Record {
+				return new(CachedResponse)
+			},
+			false,
+			kallax.NewSchemaField("id"),
+			kallax.NewSchemaField("cache_key"),
+			kallax.NewSchemaField("response"),
+			kallax.NewSchemaField("created_at"),
+			kallax.NewSchemaField("expires_at"),
+		),
+		ID:        kallax.NewSchemaField("id"),
+		CacheKey:  kallax.NewSchemaField("cache_key"),
+		Response:  kallax.NewSchemaField("response"),
+		CreatedAt: kallax.NewSchemaField("created_at"),
+		ExpiresAt: kallax.NewSchemaField("expires_at"),
+	},
 	Comment: &schemaComment{
 		BaseSchema: kallax.NewBaseSchema(
 			"comment",
@@ -2721,6 +5080,7 @@ var Schema = &schema{
 			kallax.NewSchemaField("id"),
 			kallax.ForeignKeys{
 				"ReviewEvent": kallax.NewForeignKey("review_event_id", true),
+				"PushEvent":   kallax.NewForeignKey("push_event_id", true),
 			},
 			func() kallax.Record {
 				return new(Comment)
@@ -2730,21 +5090,115 @@ var Schema = &schema{
 			kallax.NewSchemaField("created_at"),
 			kallax.NewSchemaField("updated_at"),
 			kallax.NewSchemaField("review_event_id"),
+			kallax.NewSchemaField("push_event_id"),
 			kallax.NewSchemaField("file"),
 			kallax.NewSchemaField("line"),
 			kallax.NewSchemaField("text"),
 			kallax.NewSchemaField("confidence"),
+			kallax.NewSchemaField("severity"),
+			kallax.NewSchemaField("rule"),
+			kallax.NewSchemaField("fix"),
+			kallax.NewSchemaField("end_line"),
 			kallax.NewSchemaField("analyzer"),
 		),
 		ID:            kallax.NewSchemaField("id"),
 		CreatedAt:     kallax.NewSchemaField("created_at"),
 		UpdatedAt:     kallax.NewSchemaField("updated_at"),
 		ReviewEventFK: kallax.NewSchemaField("review_event_id"),
+		PushEventFK:   kallax.NewSchemaField("push_event_id"),
 		File:          kallax.NewSchemaField("file"),
 		Line:          kallax.NewSchemaField("line"),
 		Text:          kallax.NewSchemaField("text"),
 		Confidence:    kallax.NewSchemaField("confidence"),
+		Severity:      kallax.NewSchemaField("severity"),
+		Rule:          kallax.NewSchemaField("rule"),
+		Fix: &schemaCommentFix{
+			BaseSchemaField: kallax.NewSchemaField("fix").(*kallax.BaseSchemaField),
+			StartLine:       kallax.NewJSONSchemaKey(kallax.JSONInt, "comment", "fix", "start_line"),
+			EndLine:         kallax.NewJSONSchemaKey(kallax.JSONInt, "comment", "fix", "end_line"),
+			Text:            kallax.NewJSONSchemaKey(kallax.JSONText, "comment", "fix", "text"),
+		},
+		EndLine:  kallax.NewSchemaField("end_line"),
+		Analyzer: kallax.NewSchemaField("analyzer"),
+	},
+	CommentFeedback: &schemaCommentFeedback{
+		BaseSchema: kallax.NewBaseSchema(
+			"comment_feedback",
+			"__commentfeedback",
+			kallax.NewSchemaField("id"),
+			kallax.ForeignKeys{
+				"Comment": kallax.NewForeignKey("comment_id", true),
+			},
+			func() kallax.Record {
+				return new(CommentFeedback)
+			},
+			false,
+			kallax.NewSchemaField("id"),
+			kallax.NewSchemaField("created_at"),
+			kallax.NewSchemaField("updated_at"),
+			kallax.NewSchemaField("comment_id"),
+			kallax.NewSchemaField("internal_id"),
+			kallax.NewSchemaField("thumbs_up"),
+			kallax.NewSchemaField("thumbs_down"),
+			kallax.NewSchemaField("confused"),
+			kallax.NewSchemaField("replies"),
+		),
+		ID:         kallax.NewSchemaField("id"),
+		CreatedAt:  kallax.NewSchemaField("created_at"),
+		UpdatedAt:  kallax.NewSchemaField("updated_at"),
+		CommentFK:  kallax.NewSchemaField("comment_id"),
+		InternalID: kallax.NewSchemaField("internal_id"),
+		ThumbsUp:   kallax.NewSchemaField("thumbs_up"),
+		ThumbsDown: kallax.NewSchemaField("thumbs_down"),
+		Confused:   kallax.NewSchemaField("confused"),
+		Replies:    kallax.NewSchemaField("replies"),
+	},
+	FilteredComment: &schemaFilteredComment{
+		BaseSchema: kallax.NewBaseSchema(
+			"filtered_comment",
+			"__filteredcomment",
+			kallax.NewSchemaField("id"),
+			kallax.ForeignKeys{},
+			func() kallax.Record {
+				return new(FilteredComment)
+			},
+			false,
+			kallax.NewSchemaField("id"),
+			kallax.NewSchemaField("created_at"),
+			kallax.NewSchemaField("updated_at"),
+			kallax.NewSchemaField("event_type"),
+			kallax.NewSchemaField("event_id"),
+			kallax.NewSchemaField("file"),
+			kallax.NewSchemaField("line"),
+			kallax.NewSchemaField("text"),
+			kallax.NewSchemaField("confidence"),
+			kallax.NewSchemaField("severity"),
+			kallax.NewSchemaField("rule"),
+			kallax.NewSchemaField("fix"),
+			kallax.NewSchemaField("end_line"),
+			kallax.NewSchemaField("analyzer"),
+			kallax.NewSchemaField("min_confidence"),
+		),
+		ID:         kallax.NewSchemaField("id"),
+		CreatedAt:  kallax.NewSchemaField("created_at"),
+		UpdatedAt:  kallax.NewSchemaField("updated_at"),
+		EventType:  kallax.NewSchemaField("event_type"),
+		EventID:    kallax.NewSchemaField("event_id"),
+		File:       kallax.NewSchemaField("file"),
+		Line:       kallax.NewSchemaField("line"),
+		Text:       kallax.NewSchemaField("text"),
+		Confidence: kallax.NewSchemaField("confidence"),
+		Severity:   kallax.NewSchemaField("severity"),
+		Rule:       kallax.NewSchemaField("rule"),
+		Fix: &schemaFilteredCommentFix{
+			BaseSchemaField: kallax.NewSchemaField("fix").(*kallax.BaseSchemaField),
+			StartLine:       kallax.NewJSONSchemaKey(kallax.JSONInt, "comment", "fix", "start_line"),
+			EndLine:         kallax.NewJSONSchemaKey(kallax.JSONInt, "comment", "fix", "end_line"),
+			Text:            kallax.NewJSONSchemaKey(kallax.JSONText, "comment", "fix", "text"),
+		},
+		EndLine:       kallax.NewSchemaField("end_line"),
 		Analyzer:      kallax.NewSchemaField("analyzer"),
+		MinConfidence: kallax.NewSchemaField("min_confidence"),
 	},
 	Organization: &schemaOrganization{
 		BaseSchema: kallax.NewBaseSchema(
@@ -2778,6 +5232,8 @@ var Schema = &schema{
 			false,
 			kallax.NewSchemaField("id"),
 			kallax.NewSchemaField("status"),
+			kallax.NewSchemaField("attempts"),
+			kallax.NewSchemaField("next_retry_at"),
 			kallax.NewSchemaField("provider"),
 			kallax.NewSchemaField("internal_id"),
 			kallax.NewSchemaField("created_at"),
@@ -2790,6 +5246,8 @@ var Schema = &schema{
 		),
 		ID:              kallax.NewSchemaField("id"),
 		Status:          kallax.NewSchemaField("status"),
+		Attempts:        kallax.NewSchemaField("attempts"),
+		NextRetryAt:     kallax.NewSchemaField("next_retry_at"),
 		Provider:        kallax.NewSchemaField("provider"),
 		InternalID:      kallax.NewSchemaField("internal_id"),
 		CreatedAt:       kallax.NewSchemaField("created_at"),
@@ -2831,6 +5289,8 @@ var Schema = &schema{
 			kallax.NewSchemaField("id"),
 			kallax.NewSchemaField("status"),
 			kallax.NewSchemaField("internal_id"),
+			kallax.NewSchemaField("attempts"),
+			kallax.NewSchemaField("next_retry_at"),
 			kallax.NewSchemaField("is_mergeable"),
 			kallax.NewSchemaField("source"),
 			kallax.NewSchemaField("configuration"),
@@ -2843,6 +5303,8 @@ var Schema = &schema{
 		ID:          kallax.NewSchemaField("id"),
 		Status:      kallax.NewSchemaField("status"),
 		InternalID:  kallax.NewSchemaField("internal_id"),
+		Attempts:    kallax.NewSchemaField("attempts"),
+		NextRetryAt: kallax.NewSchemaField("next_retry_at"),
 		IsMergeable: kallax.NewSchemaField("is_mergeable"),
 		Source: &schemaReviewEventSource{
 			BaseSchemaField:       kallax.NewSchemaField("source").(*kallax.BaseSchemaField),
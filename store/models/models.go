@@ -17,6 +17,11 @@ type ReviewEvent struct {
 	Status       EventStatus
 	InternalID   string
 
+	// number of processing attempts and the time when the event can be
+	// processed again after a transient failure
+	Attempts    int
+	NextRetryAt time.Time
+
 	// those fields can change with each push
 	IsMergeable   bool
 	Source        lookout.ReferencePointer
@@ -73,6 +78,11 @@ type PushEvent struct {
 	ID           kallax.ULID
 	Status       EventStatus
 
+	// number of processing attempts and the time when the event can be
+	// processed again after a transient failure
+	Attempts    int
+	NextRetryAt time.Time
+
 	// can't be pointer or kallax panics
 	lookout.PushEvent `kallax:",inline"`
 }
@@ -87,6 +97,9 @@ type Comment struct {
 	kallax.Timestamps
 	ID          kallax.ULID
 	ReviewEvent *ReviewEvent `fk:",inverse"`
+	// PushEvent is the push event of the comment, it is set instead of
+	// ReviewEvent for the comments of a push
+	PushEvent *PushEvent `fk:",inverse"`
 
 	lookout.Comment `kallax:",inline"`
 	Analyzer        string
@@ -96,6 +109,112 @@ func newComment(r *ReviewEvent, c *lookout.Comment) *Comment {
 	return &Comment{ID: kallax.NewULID(), ReviewEvent: r, Comment: *c}
 }
 
+// CommentFeedback is a persisted model for the feedback of the users on a
+// posted comment: the reactions to it and the replies
+type CommentFeedback struct {
+	kallax.Model `pk:"id"`
+	kallax.Timestamps
+	ID      kallax.ULID
+	Comment *Comment `fk:",inverse"`
+
+	// InternalID identifies the posted comment in the provider, like the id
+	// of a GitHub review comment
+	InternalID string
+	ThumbsUp   int
+	ThumbsDown int
+	Confused   int
+	Replies    int
+}
+
+func newCommentFeedback(c *Comment, internalID string) *CommentFeedback {
+	return &CommentFeedback{ID: kallax.NewULID(), Comment: c, InternalID: internalID}
+}
+
+// FilteredComment is a persisted model for a comment that was not posted
+// because its confidence is below the min_confidence of the analyzer
+type FilteredComment struct {
+	kallax.Model `pk:"id"`
+	kallax.Timestamps
+	ID kallax.ULID
+
+	// EventType and EventID identify the analyzed event, EventID is the
+	// value of lookout.Event ID()
+	EventType lookout.EventType
+	EventID   string
+
+	lookout.Comment `kallax:",inline"`
+	Analyzer        string
+	// MinConfidence is the threshold the comment was filtered with
+	MinConfidence uint32
+}
+
+func newFilteredComment(e lookout.Event, c *lookout.Comment, analyzer string, minConfidence uint32) *FilteredComment {
+	return &FilteredComment{
+		ID:            kallax.NewULID(),
+		EventType:     e.Type(),
+		EventID:       e.ID().String(),
+		Comment:       *c,
+		Analyzer:      analyzer,
+		MinConfidence: minConfidence,
+	}
+}
+
+// AnalyzerRun is a persisted model for a request sent to an analyzer
+// to analyze an event
+type AnalyzerRun struct {
+	kallax.Model `pk:"id"`
+	ID           kallax.ULID
+
+	// EventType and EventID identify the analyzed event, EventID is the
+	// value of lookout.Event ID()
+	EventType lookout.EventType
+	EventID   string
+
+	Analyzer string
+	// Version is the analyzer version reported in the response
+	Version    string
+	StartedAt  time.Time
+	FinishedAt time.Time
+	// StatusCode is the gRPC status code of the request, 0 (OK) on success
+	StatusCode uint32
+	Comments   int
+}
+
+func newAnalyzerRun(e lookout.Event, analyzer string, startedAt time.Time) *AnalyzerRun {
+	return &AnalyzerRun{
+		ID:        kallax.NewULID(),
+		EventType: e.Type(),
+		EventID:   e.ID().String(),
+		Analyzer:  analyzer,
+		StartedAt: startedAt,
+	}
+}
+
+// CachedResponse is a persisted analyzer response, reused for the requests
+// with the same input until it expires
+type CachedResponse struct {
+	kallax.Model `pk:"id"`
+	ID           kallax.ULID
+
+	// CacheKey identifies the input of the request: analyzer, version,
+	// revisions and settings
+	CacheKey string
+	// Response is the protobuf encoded lookout.EventResponse
+	Response  []byte
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+func newCachedResponse(key string, response []byte, createdAt, expiresAt time.Time) *CachedResponse {
+	return &CachedResponse{
+		ID:        kallax.NewULID(),
+		CacheKey:  key,
+		Response:  response,
+		CreatedAt: createdAt,
+		ExpiresAt: expiresAt,
+	}
+}
+
 // Organization is a persisted model for an Organization (e.g. a GitHub App
 // installation). It contains settings for a group of repositories.
 // The primary key should be (Provider,InternalID), but kallax does not support
@@ -7,4 +7,10 @@ const (
 	EventStatusPosting   = EventStatus("posting")
 	EventStatusProcessed = EventStatus("processed")
 	EventStatusFailed    = EventStatus("failed")
+	// EventStatusRetry means the processing failed because of a transient
+	// error and it will be retried
+	EventStatusRetry = EventStatus("retry")
+	// EventStatusSuperseded means the analysis was canceled because a newer
+	// event for the same review target arrived
+	EventStatusSuperseded = EventStatus("superseded")
 )
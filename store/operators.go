@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"time"
 
 	"github.com/src-d/lookout"
 	"github.com/src-d/lookout/store/models"
@@ -13,6 +14,15 @@ type EventOperator interface {
 	Save(context.Context, lookout.Event) (models.EventStatus, error)
 	// UpdateStatus updates Status of event in a store
 	UpdateStatus(context.Context, lookout.Event, models.EventStatus) error
+	// Attempts returns the number of processing attempts of the event and
+	// the time when it can be processed again
+	Attempts(context.Context, lookout.Event) (int, time.Time, error)
+	// UpdateAttempts updates the number of processing attempts of the event
+	// and the time when it can be processed again
+	UpdateAttempts(context.Context, lookout.Event, int, time.Time) error
+	// Superseded returns true if a newer event for the same review target,
+	// with a different head, is stored. Only review events can be superseded.
+	Superseded(context.Context, lookout.Event) (bool, error)
 }
 
 // CommentOperator manages persistence of Comments
@@ -21,6 +31,12 @@ type CommentOperator interface {
 	Save(context.Context, lookout.Event, *lookout.Comment, string) error
 	// Posted checks if a comment was already posted for review
 	Posted(context.Context, lookout.Event, *lookout.Comment) (bool, error)
+	// SaveFiltered persists a comment that was not posted because of its
+	// low confidence
+	SaveFiltered(context.Context, *models.FilteredComment) error
+	// Filtered returns the comments of the given Event that were not posted
+	// because of their low confidence
+	Filtered(context.Context, lookout.Event) ([]*models.FilteredComment, error)
 }
 
 // OrganizationOperator manages persistence of default config for organizations
@@ -33,6 +49,84 @@ type OrganizationOperator interface {
 	Config(ctx context.Context, provider string, orgID string) (string, error)
 }
 
+// AnalyzerRunOperator manages persistence of the requests sent to analyzers
+type AnalyzerRunOperator interface {
+	// Save persists an AnalyzerRun
+	Save(context.Context, *models.AnalyzerRun) error
+	// Runs returns the AnalyzerRuns of the given Event ordered by start time
+	Runs(context.Context, lookout.Event) ([]*models.AnalyzerRun, error)
+}
+
+// ResponseCacheOperator manages persistence of cached analyzer responses
+type ResponseCacheOperator interface {
+	// Get returns the response cached with the given key, or nil if there is
+	// none or it expired
+	Get(ctx context.Context, key string) (*lookout.EventResponse, error)
+	// Save caches the response with the given key until expiresAt, replacing
+	// the previous one
+	Save(ctx context.Context, key string, resp *lookout.EventResponse, expiresAt time.Time) error
+}
+
+// FeedbackOperator manages persistence of the feedback of the users on the
+// posted comments
+type FeedbackOperator interface {
+	// Posted returns the line comments posted since the given time, grouped
+	// by review target (pull request)
+	Posted(ctx context.Context, since time.Time) ([]*PostedComments, error)
+	// Save persists the feedback of a comment, replacing the previous one
+	Save(context.Context, *models.CommentFeedback) error
+	// Acceptance returns the feedback of the comments posted on the
+	// repositories of the organization for each analyzer and rule. If
+	// analyzer is not empty, only its rules are returned.
+	Acceptance(ctx context.Context, org, analyzer string) ([]*Acceptance, error)
+}
+
+// PostedComments are the comments posted on a review target (pull request)
+type PostedComments struct {
+	Provider string
+	// Repository is the repository of the review target
+	Repository *lookout.RepositoryInfo
+	Number     uint32
+	Comments   []*models.Comment
+}
+
+// Acceptance is the feedback of the users on the posted comments of an
+// analyzer rule
+type Acceptance struct {
+	Analyzer string `json:"analyzer"`
+	Rule     string `json:"rule"`
+	// Comments is the number of posted line comments
+	Comments   int `json:"comments"`
+	ThumbsUp   int `json:"thumbs_up"`
+	ThumbsDown int `json:"thumbs_down"`
+	Confused   int `json:"confused"`
+	Replies    int `json:"replies"`
+	// Rate is the ratio of positive reactions over all the reactions, nil if
+	// the comments have no reactions
+	Rate *float64 `json:"acceptance_rate"`
+}
+
+// NewAcceptance returns the Acceptance of an analyzer rule with the given
+// feedback, calculating its Rate
+func NewAcceptance(analyzer, rule string, comments, thumbsUp, thumbsDown, confused, replies int) *Acceptance {
+	a := &Acceptance{
+		Analyzer:   analyzer,
+		Rule:       rule,
+		Comments:   comments,
+		ThumbsUp:   thumbsUp,
+		ThumbsDown: thumbsDown,
+		Confused:   confused,
+		Replies:    replies,
+	}
+
+	if reactions := thumbsUp + thumbsDown + confused; reactions > 0 {
+		rate := float64(thumbsUp) / float64(reactions)
+		a.Rate = &rate
+	}
+
+	return a
+}
+
 // NoopEventOperator satisfies EventOperator interface but does nothing
 type NoopEventOperator struct{}
 
@@ -48,6 +142,21 @@ func (o *NoopEventOperator) UpdateStatus(context.Context, lookout.Event, models.
 	return nil
 }
 
+// Attempts implements EventOperator interface and always returns zero attempts
+func (o *NoopEventOperator) Attempts(context.Context, lookout.Event) (int, time.Time, error) {
+	return 0, time.Time{}, nil
+}
+
+// UpdateAttempts implements EventOperator interface and does nothing
+func (o *NoopEventOperator) UpdateAttempts(context.Context, lookout.Event, int, time.Time) error {
+	return nil
+}
+
+// Superseded implements EventOperator interface and always returns false
+func (o *NoopEventOperator) Superseded(context.Context, lookout.Event) (bool, error) {
+	return false, nil
+}
+
 // NoopCommentOperator satisfies CommentOperator interface but does nothing
 type NoopCommentOperator struct{}
 
@@ -63,6 +172,48 @@ func (o *NoopCommentOperator) Posted(context.Context, lookout.Event, *lookout.Co
 	return false, nil
 }
 
+// SaveFiltered implements CommentOperator interface and does nothing
+func (o *NoopCommentOperator) SaveFiltered(context.Context, *models.FilteredComment) error {
+	return nil
+}
+
+// Filtered implements CommentOperator interface and always returns an empty
+// list
+func (o *NoopCommentOperator) Filtered(context.Context, lookout.Event) ([]*models.FilteredComment, error) {
+	return nil, nil
+}
+
+// NoopAnalyzerRunOperator satisfies AnalyzerRunOperator interface but does nothing
+type NoopAnalyzerRunOperator struct{}
+
+var _ AnalyzerRunOperator = &NoopAnalyzerRunOperator{}
+
+// Save implements AnalyzerRunOperator interface and does nothing
+func (o *NoopAnalyzerRunOperator) Save(context.Context, *models.AnalyzerRun) error {
+	return nil
+}
+
+// Runs implements AnalyzerRunOperator interface and always returns an empty list
+func (o *NoopAnalyzerRunOperator) Runs(context.Context, lookout.Event) ([]*models.AnalyzerRun, error) {
+	return nil, nil
+}
+
+// NoopResponseCacheOperator satisfies ResponseCacheOperator interface but does
+// nothing
+type NoopResponseCacheOperator struct{}
+
+var _ ResponseCacheOperator = &NoopResponseCacheOperator{}
+
+// Get implements ResponseCacheOperator interface and always returns nil
+func (o *NoopResponseCacheOperator) Get(context.Context, string) (*lookout.EventResponse, error) {
+	return nil, nil
+}
+
+// Save implements ResponseCacheOperator interface and does nothing
+func (o *NoopResponseCacheOperator) Save(context.Context, string, *lookout.EventResponse, time.Time) error {
+	return nil
+}
+
 // NoopOrganizationOperator satisfies OrganizationOperator interface but does nothing
 type NoopOrganizationOperator struct{}
 
@@ -79,4 +230,3 @@ func (o *NoopOrganizationOperator) Save(ctx context.Context, provider string, or
 func (o *NoopOrganizationOperator) Config(ctx context.Context, provider string, orgID string) (string, error) {
 	return "", nil
 }
-
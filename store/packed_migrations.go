@@ -536,21 +536,235 @@ tebicvb39fUMseYCBAAA//+Y8F+aPQAAAA==
 `,
 	},
 
+	"/store/migrations/1792196123_event_retries.down.sql": {
+		name:    "1792196123_event_retries.down.sql",
+		local:   "store/migrations/1792196123_event_retries.down.sql",
+		size:    214,
+		modtime: 1,
+		compressed: `
+H4sIAAAAAAAC/3Jydff0s+bicvQJcQ1SCHF08nFVKCgtzohPLUvNK1FwCfIPUHD29wn19VNILClJzS0o
+KSZOdV5qRUl8UWpJUWV8YgmalqLUsszUcuKtwKke3RJnf19fzxBrLkAAAAD//8a7P9TWAAAA
+`,
+	},
+
+	"/store/migrations/1792196123_event_retries.up.sql": {
+		name:    "1792196123_event_retries.up.sql",
+		local:   "store/migrations/1792196123_event_retries.up.sql",
+		size:    370,
+		modtime: 1,
+		compressed: `
+H4sIAAAAAAAC/6zOPcuDMBTF8d1PcTaHB+E6PbROUdMixAglzmLh0mZQxNzal09f6NhS6CCc+Xf+ud5X
+NosiZZw+wKncaEyXcO544VGgyhJFY9raohfhYZKAoz/5UWAbB9sag1LvVGsc6Cdm5Jt0M8t873qB+IGD
+9MMkj08wTjf/lFCaUAqi7Wt/RPHbz8yL5+sKwd+gdZKLpq4rl0XPAAAA///139LVcgEAAA==
+`,
+	},
+
+	"/store/migrations/1792196474_analyzer_runs.down.sql": {
+		name:    "1792196474_analyzer_runs.down.sql",
+		local:   "store/migrations/1792196474_analyzer_runs.down.sql",
+		size:    42,
+		modtime: 1,
+		compressed: `
+H4sIAAAAAAAC/3Jydff0s+bicgnyD1AIcXTycVVIzEvMqaxKLYovKs2z5uJy9vf19Qyx5gIEAAD//+LB
+UkQqAAAA
+`,
+	},
+
+	"/store/migrations/1792196474_analyzer_runs.up.sql": {
+		name:    "1792196474_analyzer_runs.up.sql",
+		local:   "store/migrations/1792196474_analyzer_runs.up.sql",
+		size:    465,
+		modtime: 1,
+		compressed: `
+H4sIAAAAAAAC/3yQwUoDMRCGz5unmKNC36CnrQZZ3M3KEsGeQmxGHTDZkkxK26cXhOza1vY4+T7+zPwr
++dSopRAPg6y1BF2vWgk22O/DEaOJOcCdqMhBzuRA9RrUa9vCy9B09bCGZ7leiAp3GNjwYYvwTp8UeBIn
+SA4Y9yegfHIBdhgTjeHiPbGNjM5YBiaPia3f8vGv8UGB0tdNJbHlnMxmdP8tuxm9x8DpHIn7uaJGPcq3
+k4pMuXEvql6dtVfY7YRpmI+8klemBczqb3bfdY1eip8AAAD//23gZ6PRAQAA
+`,
+	},
+
+	"/store/migrations/1792197063_comment_severity.down.sql": {
+		name:    "1792197063_comment_severity.down.sql",
+		local:   "store/migrations/1792197063_comment_severity.down.sql",
+		size:    59,
+		modtime: 1,
+		compressed: `
+H4sIAAAAAAAC/3Jydff0s+bicvQJcQ1SCHF08nFVSM7PzU3NK1FwCfIPUHD29wn19VMoTi1LLcosqbTm
+4nL29/X1DLHmAgQAAP//E/oduzsAAAA=
+`,
+	},
+
+	"/store/migrations/1792197063_comment_severity.up.sql": {
+		name:    "1792197063_comment_severity.up.sql",
+		local:   "store/migrations/1792197063_comment_severity.up.sql",
+		size:    85,
+		modtime: 1,
+		compressed: `
+H4sIAAAAAAAC/wTAQQoCMQwF0H1O8Y/gvqvMNMpAmoKkJ5Ags2iFWgRvP2+Tx2GJiNXlCedNBa9P7zEW
+OGfsVVsxfOMX81x/nGPFOyasOqypIsudmzpuiWivpRye6AoAAP//yUgH0FUAAAA=
+`,
+	},
+
+	"/store/migrations/1792197330_cached_responses.down.sql": {
+		name:    "1792197330_cached_responses.down.sql",
+		local:   "store/migrations/1792197330_cached_responses.down.sql",
+		size:    45,
+		modtime: 1,
+		compressed: `
+H4sIAAAAAAAC/3Jydff0s+bicgnyD1AIcXTycVVITkzOSE2JL0otLsjPK0615uJy9vf19Qyx5gIEAAD/
+/2AzTCgtAAAA
+`,
+	},
+
+	"/store/migrations/1792197330_cached_responses.up.sql": {
+		name:    "1792197330_cached_responses.up.sql",
+		local:   "store/migrations/1792197330_cached_responses.up.sql",
+		size:    364,
+		modtime: 1,
+		compressed: `
+H4sIAAAAAAAC/3SPQWqGMBBG15lTzLIFb+BK21BCNbYSoa5CagYaiq2YEbSnL7iI0v6u583jfaV8UjoH
+eGhlYSSaoqwkDm74IG9nitP3VyS8AxE8LkvwqBuDuqsqfGlVXbQ9Pss+A7F/2E/akGnlRGUgkuR9Y3Ln
+yzCTY/LWMXIYKbIbJ/45E7ROYaZ4RcD9Ed5p9dpJVPpRvv3tt6nOBr+CaPT/hQk5OW/Ljqhr28Hsuqau
+lcnhNwAA//8BCdl9bAEAAA==
+`,
+	},
+
+	"/store/migrations/1792197497_filtered_comments.down.sql": {
+		name:    "1792197497_filtered_comments.down.sql",
+		local:   "store/migrations/1792197497_filtered_comments.down.sql",
+		size:    46,
+		modtime: 1,
+		compressed: `
+H4sIAAAAAAAC/3Jydff0s+bicgnyD1AIcXTycVVIy8wpSS1KTYlPzs/NTc0rsebicvb39fUMseYCBAAA
+//9+VZsyLgAAAA==
+`,
+	},
+
+	"/store/migrations/1792197497_filtered_comments.up.sql": {
+		name:    "1792197497_filtered_comments.up.sql",
+		local:   "store/migrations/1792197497_filtered_comments.up.sql",
+		size:    548,
+		modtime: 1,
+		compressed: `
+H4sIAAAAAAAC/4SRwWrrMBBF19ZXzPI98B9k5bSimNpyMS40K6Fa4zBgTYwyDnG+vhBw09Zus517OBrd
+2eqn3GyUeqh11mhosm2hoaNeMKK37SEEZIF/KiEP40geTNWAeS0KeKnzMqt38Kx3qUraiE7QWycgFPAo
+Lgxy+aRTlYyDv0PgCVmsTAPCO+2JZRmSB8Hzt6CjHhfDnhiBWHCP8ev8yv2E2wN35JHbtXePeMJIMq3Z
+HLt+umBcGAOx/d2q/t8Kz82jflsUbuffnlVSmZV7zPl907zjH7IZSeG281VclWXebNRHAAAA//+gsSQ/
+JAIAAA==
+`,
+	},
+
+	"/store/migrations/1792200430_comment_feedback.down.sql": {
+		name:    "1792200430_comment_feedback.down.sql",
+		local:   "store/migrations/1792200430_comment_feedback.down.sql",
+		size:    172,
+		modtime: 1,
+		compressed: `
+H4sIAAAAAAAC/3Jydff0s+bicgnyD1AIcXTycVVIzs/NTc0riU9LTU1JSkzOhsl6+rm4RsBlE/MScyqr
+Uovii0pzUuMzUyqsubgcfUJcg1BNUQBrdfb3CfX1UwApRVOWlplTklqUmhKPR72zv6+vZ4g1FyAAAP//
+cyNRYKwAAAA=
+`,
+	},
+
+	"/store/migrations/1792200430_comment_feedback.up.sql": {
+		name:    "1792200430_comment_feedback.up.sql",
+		local:   "store/migrations/1792200430_comment_feedback.up.sql",
+		size:    620,
+		modtime: 1,
+		compressed: `
+H4sIAAAAAAAC/5yR0U6DMBSGr+lTnLttCW+wKzbODBGKIiTuqunomTZCR0ob557ezARxSGLi9ff3nO/8
+3eBdwteMbQuMSoQy2qQI9altyThxJFIHWb/BkgVagfdaAc9L4FWawkORZFGxh3vchyyoLUlHSkgHTrfU
+O9l27vKdDlngO/VHYlg7rCpwhwXyLT4NRkutViELtHFkjWyuSUdn93OIe/XtoRe+g4N+0WaOqdO7maH1
+yRx9T2oGWeoaTf2UsNXYXMWTxwoh4TE+/ypQjKedWZDzmYbHxHVolJZY3P4GRHEM2zytMg7WN3R7OcS4
+i6q0hMVidLqVkUY2Hxey4vp6agLLAYdf06cSR904sqTEf2zyLEvKNfsMAAD//3CLJ4FsAgAA
+`,
+	},
+
+	"/store/migrations/1792200715_comment_fix.down.sql": {
+		name:    "1792200715_comment_fix.down.sql",
+		local:   "store/migrations/1792200715_comment_fix.down.sql",
+		size:    101,
+		modtime: 1,
+		compressed: `
+H4sIAAAAAAAC/3Jydff0s+bicvQJcQ1SCHF08nFVSM7PzU3NK1FwCfIPUHD29wn19VNIy6xAU5WWmVOS
+WpSaEo9bubO/r69niDUXIAAA//9zIn1EZQAAAA==
+`,
+	},
+
+	"/store/migrations/1792200715_comment_fix.up.sql": {
+		name:    "1792200715_comment_fix.up.sql",
+		local:   "store/migrations/1792200715_comment_fix.up.sql",
+		size:    111,
+		modtime: 1,
+		compressed: `
+H4sIAAAAAAAC/3Jydff0s+bicvQJcQ1SCHF08nFVSM7PzU3NK1FwdHFRcPb3CfX1U0jLrFDIKs7PS0JT
+mpaZU5JalJoST0CPs7+vr2eINRcgAAD//3PwV9ZvAAAA
+`,
+	},
+
+	"/store/migrations/1792201340_comment_end_line.down.sql": {
+		name:    "1792201340_comment_end_line.down.sql",
+		local:   "store/migrations/1792201340_comment_end_line.down.sql",
+		size:    111,
+		modtime: 1,
+		compressed: `
+H4sIAAAAAAAC/3Jydff0s+bicvQJcQ1SCHF08nFVSM7PzU3NK1FwCfIPUHD29wn19VNIzUuJz8nMS0VT
+mpaZU5JalJoST0CPs7+vr2eINRcgAAD//0B0SDdvAAAA
+`,
+	},
+
+	"/store/migrations/1792201340_comment_end_line.up.sql": {
+		name:    "1792201340_comment_end_line.up.sql",
+		local:   "store/migrations/1792201340_comment_end_line.up.sql",
+		size:    163,
+		modtime: 1,
+		compressed: `
+H4sIAAAAAAAC/5zMMQrDMAwF0N2n+EfonsmJ1RKQZSjynKFRiyFRwfj+dO+YC7yZHqtMIURWekLjzITX
+9zzNB2JKWArXLDDft6O5ofmwj3VIUUhlRqJ7rKy4/SHvdgzrtm+XtaXkvOoUfgEAAP//0GvJr6MAAAA=
+`,
+	},
+
+	"/store/migrations/1792202551_comment_push_event.down.sql": {
+		name:    "1792202551_comment_push_event.down.sql",
+		local:   "store/migrations/1792202551_comment_push_event.down.sql",
+		size:    64,
+		modtime: 1,
+		compressed: `
+H4sIAAAAAAAC/3Jydff0s+bicvQJcQ1SCHF08nFVSM7PzU3NK1FwCfIPUHD29wn19VMoKC3OiE8tS80r
+ic9Msebicvb39fUMseYCBAAA//9LR/lwQAAAAA==
+`,
+	},
+
+	"/store/migrations/1792202551_comment_push_event.up.sql": {
+		name:    "1792202551_comment_push_event.up.sql",
+		local:   "store/migrations/1792202551_comment_push_event.up.sql",
+		size:    94,
+		modtime: 1,
+		compressed: `
+H4sIAAAAAAAC/3Jydff0s+bicvQJcQ1SCHF08nFVSM7PzU3NK1FwdHFRcPb3CfX1UygoLc6ITy1LzSuJ
+z0xRKC3NTFEIcnVzDXL1c3YNRpLVyEzRtObicvb39fUMseYCBAAA//9hQKXwXgAAAA==
+`,
+	},
+
 	"/store/migrations/lock.json": {
 		name:    "lock.json",
 		local:   "store/migrations/lock.json",
-		size:    8563,
+		size:    18032,
 		modtime: 1,
 		compressed: `
-H4sIAAAAAAAC/+xZzW7bMAy+5ykEn/sEue44IBiG7jQMBm0zLgeJ8iQqm1Pk3Ye4bWY7drYVWyunugSG
-CYbfR/HP4v1KqewWCo0+W6vPK6WUuu9+lco2YDBbq6y0xiBLdvMkeGd1MPxLo6810KTqpNS9v22b7n0I
-Y8kHRwZc+x7bbK3EBRxIP+IWHXJ5VOag9UC4sbIJWk/pfWL6Fo5KW9AeT5LDzWXYpUMQrHKQafhCBr2A
-aWR/gUVn8zVphKa6BhoOd4Tfc9whS/7MiLpMom/2KR/Glgd/fsqBxyDviQ4z3jhH8Ex3bEnjzHniD4n5
-IDXxDHJiwRpdzODPnbsUt5eWt1R19ibxF1QTR80AGHS7H8dHNP5/fPqy6nE5a6LW1cC0ByHLb6eTNs7u
-qIr25H6D/liWHIOe7TqLSP16yWnTBH83asBXnzReQIJfZsSlhE9fLv+ibhlD4pc6r1TkhbiUfOE8HtpH
-cMOhZUDiq7dcxMyhAI8LhX6HUC0Uen/ajbeY/tEEMnkJkGaQ1MX/IwGfG3Q1dvdQ073DWo3AMZPwNrhy
-qaU3db7U+dLu4Gp2BwKuxtdZHjyYfrntwd/MNCNsaa+XcjNdFb2VIdNhYz2Jde0shfhvKDiYYi6CIkA/
-LMar49PhZwAAAP//f8ci+3MhAAA=
+H4sIAAAAAAAC/+xbzW7bMAy+5ymMnPsEve44oBiG7jQMgmzRCVdZ8igqjVv03Yc4/cmf03boGtHRpQgs
+qPooU9THj/T9pCim17q0EKaXxc9JURTFff+3KKZXuoHpZTHVTtvuDkhRdNOLp9Ev3sbGvUzbnLo1Hc3z
+pP75ddf2z2PcHflG2GjqvkI3vSyYImyNfocaCFy1muyitVuDV56vorWH5v1w+CeuJtXaBngeebg4DhsW
+4FjxCuxB+CXO0PERA/rlTm/B0PYzLJNG/+R1MtEvgAJ6JxN8YE0MRmkewI8NBNZNy3cpm1GjwzCXb0dg
+zTGoyhuxoajyTQOOQ7L4H3/9mmxYs3cRVrpauRNBaL0LcD53YW+4uoFOZjzbe2Hb3tcx6KQPD4EeQTiG
+ZYsEIXkz3hYK1gHtjELAOJwwtmYMZhAsEG7VcYb/ikcdN2Jz2acccXflrX/+fAYenXxj6GFgN/YR/ON2
+tDHMT7EZL+smsxU1WpB5S1t0A8jRMcx2M8G0wO9vrpRtr7yr0fTrCU0uAiyAkDuxzkNR6pmtcXkY+O/g
+Xfk/kH9YoARnlOiQk7hA9h4arWoAU+rqJvPpzKdPqE99LnvczSFPTR1XMY+ctmJLBjyPTRlUbKUymUcD
+jL91cpVeV8cARip+gtYiCBeqa7QMBEZlmSpfq7mGfrY19CwGZTEoi0FZDMpiUBaDUkbfoFMCQs+buLen
+mXZ4p3mz/Wv0vLslv0Aj1f/EKx/94ZlJVoIPlFJHf2jWjX1C23OZoWkT7uh7Bb+DJSsCpk56kp1Db1ac
+PqYCgnKPs8HA6CpWwu1YX+SRtunj52RTH2VDqYdabZOHPgdthELfzDvSDaZv4oIHewwzG8y3eKaz46ez
+GFQDNIO+S+Lw2/DegnZJq9A+UiX1EswcJHOQXH0fzUcirGkGp/lKZL305zW4vYdd7mDLnTH5bGbR7lzo
+PkHrA7KnbtAEAZw/NuWQByVXLZ2sfj38DQAA//+brPXrcEYAAA==
 `,
 	},
 
@@ -592,6 +806,24 @@ var _escDirs = map[string][]os.FileInfo{
 		_escData["/store/migrations/1548435439_event_wrappers.up.sql"],
 		_escData["/store/migrations/1550864142_remove_merge_field.down.sql"],
 		_escData["/store/migrations/1550864142_remove_merge_field.up.sql"],
+		_escData["/store/migrations/1792196123_event_retries.down.sql"],
+		_escData["/store/migrations/1792196123_event_retries.up.sql"],
+		_escData["/store/migrations/1792196474_analyzer_runs.down.sql"],
+		_escData["/store/migrations/1792196474_analyzer_runs.up.sql"],
+		_escData["/store/migrations/1792197063_comment_severity.down.sql"],
+		_escData["/store/migrations/1792197063_comment_severity.up.sql"],
+		_escData["/store/migrations/1792197330_cached_responses.down.sql"],
+		_escData["/store/migrations/1792197330_cached_responses.up.sql"],
+		_escData["/store/migrations/1792197497_filtered_comments.down.sql"],
+		_escData["/store/migrations/1792197497_filtered_comments.up.sql"],
+		_escData["/store/migrations/1792200430_comment_feedback.down.sql"],
+		_escData["/store/migrations/1792200430_comment_feedback.up.sql"],
+		_escData["/store/migrations/1792200715_comment_fix.down.sql"],
+		_escData["/store/migrations/1792200715_comment_fix.up.sql"],
+		_escData["/store/migrations/1792201340_comment_end_line.down.sql"],
+		_escData["/store/migrations/1792201340_comment_end_line.up.sql"],
+		_escData["/store/migrations/1792202551_comment_push_event.down.sql"],
+		_escData["/store/migrations/1792202551_comment_push_event.up.sql"],
 		_escData["/store/migrations/lock.json"],
 	},
 }
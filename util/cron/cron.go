@@ -0,0 +1,217 @@
+// Package cron parses cron expressions and computes their activation times.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed cron expression
+type Schedule struct {
+	minute, hour, dom, month, dow bits
+	// domStar and dowStar are true if the day of month or day of week fields
+	// are *, following cron, if both are restricted a day matching any of
+	// them is activated
+	domStar, dowStar bool
+}
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+type bounds struct {
+	name     string
+	min, max int
+}
+
+var (
+	minuteBounds = bounds{"minute", 0, 59}
+	hourBounds   = bounds{"hour", 0, 23}
+	domBounds    = bounds{"day of month", 1, 31}
+	monthBounds  = bounds{"month", 1, 12}
+	// 7 is also sunday
+	dowBounds = bounds{"day of week", 0, 7}
+)
+
+// Parse parses a cron expression with the fields minute, hour, day of month,
+// month and day of week. Each field can be *, a value, a range like 1-5, a
+// step like */15 or 0-30/10, or a comma-separated list of them. The
+// descriptors @yearly, @annually, @monthly, @weekly, @daily, @midnight and
+// @hourly are accepted too.
+func Parse(spec string) (*Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if d, ok := descriptors[spec]; ok {
+		spec = d
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields, found %d: %q", len(fields), spec)
+	}
+
+	s := &Schedule{
+		domStar: fields[2] == "*",
+		dowStar: fields[4] == "*",
+	}
+
+	var err error
+	for i, f := range []struct {
+		b    bounds
+		bits *bits
+	}{
+		{minuteBounds, &s.minute},
+		{hourBounds, &s.hour},
+		{domBounds, &s.dom},
+		{monthBounds, &s.month},
+		{dowBounds, &s.dow},
+	} {
+		if *f.bits, err = parseField(fields[i], f.b); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.dow.has(7) {
+		s.dow |= 1
+	}
+
+	return s, nil
+}
+
+// maxYears is how far in the future Next looks for an activation time
+const maxYears = 5
+
+// Next returns the first activation time after t, in the location of t. It
+// returns the zero time if there is none in the next years, like for the
+// 30th of February.
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc).
+		Add(time.Minute)
+	limit := t.AddDate(maxYears, 0, 0)
+
+	for t.Before(limit) {
+		if !s.month.has(int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if !s.hour.has(t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+
+		if !s.minute.has(t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dom := s.dom.has(t.Day())
+	dow := s.dow.has(int(t.Weekday()))
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+
+	return dom || dow
+}
+
+// bits has the bit n set if the value n is allowed
+type bits uint64
+
+func (b bits) has(n int) bool {
+	return b&(1<<uint(n)) != 0
+}
+
+func parseField(field string, b bounds) (bits, error) {
+	var res bits
+	for _, part := range strings.Split(field, ",") {
+		r, err := parseRange(part, b)
+		if err != nil {
+			return 0, err
+		}
+
+		res |= r
+	}
+
+	return res, nil
+}
+
+func parseRange(part string, b bounds) (bits, error) {
+	step := 1
+	if i := strings.Index(part, "/"); i >= 0 {
+		var err error
+		step, err = strconv.Atoi(part[i+1:])
+		if err != nil || step <= 0 {
+			return 0, fmt.Errorf("invalid step in %s field: %q", b.name, part)
+		}
+
+		part = part[:i]
+	}
+
+	var from, to int
+	switch {
+	case part == "*":
+		from, to = b.min, b.max
+	case strings.Contains(part, "-"):
+		i := strings.Index(part, "-")
+		var err error
+		if from, err = parseValue(part[:i], b); err != nil {
+			return 0, err
+		}
+
+		if to, err = parseValue(part[i+1:], b); err != nil {
+			return 0, err
+		}
+
+		if from > to {
+			return 0, fmt.Errorf("invalid range in %s field: %q", b.name, part)
+		}
+	default:
+		v, err := parseValue(part, b)
+		if err != nil {
+			return 0, err
+		}
+
+		// a single value with a step, like 5/10, means 5-max/10
+		from, to = v, v
+		if step > 1 {
+			to = b.max
+		}
+	}
+
+	var res bits
+	for v := from; v <= to; v += step {
+		res |= 1 << uint(v)
+	}
+
+	return res, nil
+}
+
+func parseValue(s string, b bounds) (int, error) {
+	v, err := strconv.Atoi(s)
+	if err != nil || v < b.min || v > b.max {
+		return 0, fmt.Errorf("invalid value in %s field, it must be between %d and %d: %q",
+			b.name, b.min, b.max, s)
+	}
+
+	return v, nil
+}
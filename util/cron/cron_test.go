@@ -0,0 +1,59 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNext(t *testing.T) {
+	// a wednesday
+	now := time.Date(2019, 1, 16, 10, 30, 45, 0, time.UTC)
+
+	cases := []struct {
+		spec     string
+		expected time.Time
+	}{
+		{"* * * * *", time.Date(2019, 1, 16, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2019, 1, 16, 10, 45, 0, 0, time.UTC)},
+		{"0 3 * * *", time.Date(2019, 1, 17, 3, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2019, 1, 17, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2019, 1, 16, 11, 0, 0, 0, time.UTC)},
+		{"0 2 * * 1-5", time.Date(2019, 1, 17, 2, 0, 0, 0, time.UTC)},
+		{"0 2 * * 6,7", time.Date(2019, 1, 19, 2, 0, 0, 0, time.UTC)},
+		{"0 2 * * 0", time.Date(2019, 1, 20, 2, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"30 4 1,15 * 5", time.Date(2019, 1, 18, 4, 30, 0, 0, time.UTC)},
+		{"5/20 10 * * *", time.Date(2019, 1, 16, 10, 45, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.spec, func(t *testing.T) {
+			s, err := Parse(c.spec)
+			require.NoError(t, err)
+			require.Equal(t, c.expected, s.Next(now))
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"@never",
+	} {
+		_, err := Parse(spec)
+		require.Error(t, err, spec)
+	}
+}
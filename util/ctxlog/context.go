@@ -2,6 +2,7 @@ package ctxlog
 
 import (
 	"context"
+	"sync"
 
 	log "gopkg.in/src-d/go-log.v1"
 )
@@ -9,6 +10,10 @@ import (
 // NewLogger function to create new log.Logger, needed for mocking in tests
 var NewLogger = log.New
 
+// newLoggerMu serializes the calls to NewLogger, the default log factory
+// is not safe for concurrent use
+var newLoggerMu sync.Mutex
+
 type ctxKey int
 
 // logFieldsKey is the key that holds log Fields in a context.
@@ -22,6 +27,9 @@ func Get(ctx context.Context) log.Logger {
 		fields = v.(log.Fields)
 	}
 
+	newLoggerMu.Lock()
+	defer newLoggerMu.Unlock()
+
 	return NewLogger(fields)
 }
 
@@ -2,6 +2,8 @@ package lookout
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	lru "github.com/hashicorp/golang-lru"
 	"gopkg.in/src-d/go-errors.v1"
@@ -13,6 +15,29 @@ var (
 	NoErrStopWatcher = errors.NewKind("Stop watcher")
 )
 
+// RetryEventError is returned by an EventHandler when the Event could not be
+// processed because of a transient error, and it should be handled again once
+// Delay has passed.
+type RetryEventError struct {
+	// Delay is the minimum time to wait before handling the Event again
+	Delay time.Duration
+	// Err is the error that caused the retry, it can be nil
+	Err error
+}
+
+func (e *RetryEventError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("event processing will be retried in %s", e.Delay)
+	}
+
+	return fmt.Sprintf("event processing will be retried in %s: %s", e.Delay, e.Err)
+}
+
+// Cause returns the error that caused the retry
+func (e *RetryEventError) Cause() error {
+	return e.Err
+}
+
 // Watcher watch for new events in given provider.
 type Watcher interface {
 	// Watch for new events triggering the EventHandler for each new issue,
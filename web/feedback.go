@@ -0,0 +1,44 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/src-d/lookout/store"
+	"github.com/src-d/lookout/util/ctxlog"
+
+	"github.com/go-chi/chi"
+)
+
+// Feedback is an HTTP service to read the feedback of the users on the
+// posted comments
+type Feedback struct {
+	FeedbackOp store.FeedbackOperator
+}
+
+// Acceptance writes in the response the acceptance of the comments of each
+// analyzer and rule on the organization of the URL parameter "orgName". The
+// optional query parameter "analyzer" returns only the rules of that
+// analyzer. The access of the user to the organization must be checked
+// before, with GitHub.OrgAccess.
+func (f *Feedback) Acceptance(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	if orgName == "" {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	list, err := f.FeedbackOp.Acceptance(r.Context(), orgName, r.URL.Query().Get("analyzer"))
+	if err != nil {
+		ctxlog.Get(r.Context()).Errorf(err, "failed to read the comments feedback")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	// initialized as empty array because otherwise json response will be null
+	// instead of []
+	if list == nil {
+		list = []*store.Acceptance{}
+	}
+
+	successJSON(w, r, list)
+}
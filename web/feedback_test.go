@@ -0,0 +1,79 @@
+package web
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/src-d/lookout/store"
+	"github.com/src-d/lookout/store/models"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/require"
+)
+
+type acceptanceOperatorMock struct {
+	org      string
+	analyzer string
+}
+
+func (o *acceptanceOperatorMock) Posted(context.Context, time.Time) ([]*store.PostedComments, error) {
+	return nil, nil
+}
+
+func (o *acceptanceOperatorMock) Save(context.Context, *models.CommentFeedback) error {
+	return nil
+}
+
+func (o *acceptanceOperatorMock) Acceptance(ctx context.Context, org, analyzer string) ([]*store.Acceptance, error) {
+	o.org = org
+	o.analyzer = analyzer
+	if analyzer == "empty" {
+		return nil, nil
+	}
+
+	return []*store.Acceptance{
+		store.NewAcceptance("style", "indentation", 4, 3, 1, 0, 2),
+		store.NewAcceptance("style", "naming", 2, 0, 0, 0, 0),
+	}, nil
+}
+
+func TestFeedbackAcceptance(t *testing.T) {
+	require := require.New(t)
+
+	op := &acceptanceOperatorMock{}
+	f := &Feedback{FeedbackOp: op}
+
+	r := chi.NewRouter()
+	r.Get("/api/org/{orgName}/feedback", f.Acceptance)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/api/org/src-d/feedback?analyzer=style", nil))
+
+	require.Equal("src-d", op.org)
+	require.Equal("style", op.analyzer)
+	require.JSONEq(`{"data": [{
+		"analyzer": "style",
+		"rule": "indentation",
+		"comments": 4,
+		"thumbs_up": 3,
+		"thumbs_down": 1,
+		"confused": 0,
+		"replies": 2,
+		"acceptance_rate": 0.75
+	}, {
+		"analyzer": "style",
+		"rule": "naming",
+		"comments": 2,
+		"thumbs_up": 0,
+		"thumbs_down": 0,
+		"confused": 0,
+		"replies": 0,
+		"acceptance_rate": null
+	}]}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/api/org/src-d/feedback?analyzer=empty", nil))
+	require.JSONEq(`{"data": []}`, w.Body.String())
+}
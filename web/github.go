@@ -8,15 +8,16 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strconv"
+	"strings"
 
 	github_provider "github.com/src-d/lookout/provider/github"
+	"github.com/src-d/lookout/server"
 
 	"github.com/bradleyfalzon/ghinstallation"
 	"github.com/go-chi/chi"
 	"github.com/google/go-github/github"
 	"github.com/src-d/lookout/store"
 	"github.com/src-d/lookout/util/ctxlog"
-	yaml "gopkg.in/yaml.v2"
 )
 
 // GitHub is an HTTP service to call GitHub endpoints
@@ -210,6 +211,19 @@ func (g *GitHub) orgInstallation(w http.ResponseWriter, r *http.Request) (*githu
 	return installation, nil
 }
 
+// OrgAccess is a middleware that lets the request through only if the
+// logged-in user is an administrator of the organization of the URL parameter
+// "orgName"
+func (g *GitHub) OrgAccess(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := g.orgInstallation(w, r); err != nil {
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
 // orgResponse is the response type used by the individual organization handler
 type orgResponse struct {
 	ID     int64  `json:"id"`
@@ -261,10 +275,9 @@ func (g *GitHub) UpdateOrg(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var empty struct{}
-	if err := yaml.Unmarshal([]byte(configRequest.Config), &empty); err != nil {
+	if problems := server.ValidateConfig([]byte(configRequest.Config)); len(problems) > 0 {
 		http.Error(w,
-			fmt.Sprintf("Bad Request. The configuration is not valid YAML: %s", err),
+			fmt.Sprintf("Bad Request. The configuration is not valid: %s", strings.Join(problems, "; ")),
 			http.StatusBadRequest)
 		return
 	}
@@ -12,7 +12,7 @@ type HTTPServer struct {
 	mux http.Handler
 }
 
-func NewHTTPServer(auth *Auth, gh *GitHub, static *Static) *HTTPServer {
+func NewHTTPServer(auth *Auth, gh *GitHub, feedback *Feedback, static *Static) *HTTPServer {
 	corsOptions := cors.Options{
 		// TODO: make it customizable
 		// we can't pass "*" because it's incompatible with "credentials: include" request
@@ -41,6 +41,7 @@ func NewHTTPServer(auth *Auth, gh *GitHub, static *Static) *HTTPServer {
 		r.Route("/org/{orgName}", func(r chi.Router) {
 			r.Get("/", gh.Org)
 			r.Put("/", gh.UpdateOrg)
+			r.With(gh.OrgAccess).Get("/feedback", feedback.Acceptance)
 		})
 	})
 	r.Get("/static/*", static.ServeHTTP)